@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+// createAdmin implements the create-admin command, which creates an admin account
+// straight in the database. The register mutation is limited to admins, so this is
+// how the first one is made. The password is read from standard input so it doesn't
+// end up in the shell history.
+func createAdmin(args []string) {
+	flags := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	dbFilePath := flags.String("db-path", "db.sqlite", "The path to the sqlite3 database. Default is db.sqlite")
+	email := flags.String("email", "", "The email of the admin. Required")
+	flags.Parse(args)
+
+	if *email == "" {
+		log.Fatal("usage: create-admin -email <email> [-db-path <path>] < password")
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		log.Fatalf("error reading password: %v", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if password == "" {
+		log.Fatal("password must not be empty")
+	}
+
+	database := db.NewDB(*dbFilePath)
+
+	existing, err := database.GetUserByEmail(*email)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		log.Fatalf("error getting user: %v", err)
+	}
+	if existing != nil {
+		log.Fatalf("user %s already exists", *email)
+	}
+
+	passwordHash, err := models.HashPassword(password)
+	if err != nil {
+		log.Fatalf("error hashing password: %v", err)
+	}
+
+	_, err = database.CreateUser(*email, passwordHash, models.UserRoleAdmin)
+	if err != nil {
+		log.Fatalf("error creating user: %v", err)
+	}
+
+	fmt.Printf("created admin %s\n", *email)
+}
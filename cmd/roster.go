@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/roster"
+)
+
+// importRoster implements the import-roster command, which loads a roster CSV
+// straight into the database without going through the API.
+func importRoster(args []string) {
+	flags := flag.NewFlagSet("import-roster", flag.ExitOnError)
+	dbFilePath := flags.String("db-path", "db.sqlite", "The path to the sqlite3 database. Default is db.sqlite")
+	classID := flags.Uint("class", 0, "The ID of the class to enrol students in. Required")
+	flags.Parse(args)
+
+	if *classID == 0 || flags.NArg() != 1 {
+		log.Fatal("usage: import-roster -class <id> [-db-path <path>] <roster.csv>")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("error opening roster: %v", err)
+	}
+	defer file.Close()
+
+	database := db.NewDB(*dbFilePath)
+
+	class, err := database.GetClass(fmt.Sprintf("%d", *classID))
+	if err != nil {
+		log.Fatalf("error getting class: %v", err)
+	}
+
+	report, err := roster.Import(database, class.ID, file)
+	if err != nil {
+		log.Fatalf("error importing roster: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tSTUDENT\tSTATUS\tMESSAGE")
+	for _, row := range report.Rows {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", row.Line, row.StudentNumber, row.Status, row.Message)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d created, %d updated, %d skipped, %d errored\n",
+		report.Count(roster.RowStatusCreated),
+		report.Count(roster.RowStatusUpdated),
+		report.Count(roster.RowStatusSkipped),
+		report.Count(roster.RowStatusErrored),
+	)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
 	"regexp"
 
 	"github.com/99designs/gqlgen/graphql/handler"
@@ -15,6 +16,7 @@ import (
 	"github.com/COMP4050/square-team-5/api/internal/pkg/config"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/web/auth"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/web/downloads"
 )
 
 func allowedOrigin(origin string) bool {
@@ -22,28 +24,54 @@ func allowedOrigin(origin string) bool {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import-roster" {
+		importRoster(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "create-admin" {
+		createAdmin(os.Args[2:])
+		return
+	}
+
 	config := config.NewConfig()
 
 	db := db.NewDB(config.DBFilePath)
 
+	resolver := &graph.Resolver{
+		DB:              db,
+		Config:          config,
+		ExtractUser:     auth.ExtractUser,
+		ExtractIP:       auth.ExtractIP,
+		IPThrottle:      auth.NewLoginThrottle(auth.IPPolicy),
+		AccountThrottle: auth.NewLoginThrottle(auth.AccountPolicy),
+	}
+
 	srv := handler.NewDefaultServer(
 		generated.NewExecutableSchema(
-			generated.Config{
-				Resolvers: &graph.Resolver{DB: db, Config: config, ExtractUser: auth.ExtractUser},
-			},
+			generated.Config{Resolvers: resolver},
 		),
 	)
+	srv.AroundFields(resolver.AuditMiddleware)
 
 	r := gin.New()
+	// Login throttling is keyed on the client IP, so only trust forwarding headers from
+	// known proxies.
+	err := r.SetTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		log.Fatalf("invalid trusted proxies: %v", err)
+	}
 	r.Use(cors.New(cors.Config{
 		AllowOriginFunc:  allowedOrigin,
 		AllowCredentials: true,
 		AllowHeaders:     []string{"Content-Type", "Authorization", "baggage", "sentry-trace"},
 	}))
-	r.Use(auth.AuthHandler(config.JWTSecret))
+	r.Use(auth.AuthHandler(config.JWTSecret, db))
+	r.Use(auth.ClientIPHandler())
 
 	r.Any("/", gin.WrapH(playground.Handler("GraphQL playground", "/query")))
 	r.POST("/query", gin.WrapH(srv))
+	r.GET("/export/assignments/:id/grades", downloads.AssignmentGradesHandler(db))
+	r.GET("/export/classes/:id/grades", downloads.ClassGradesHandler(db))
 
 	log.Printf("connect to http://localhost:%d/ for GraphQL playground", config.Port)
 	r.Run(fmt.Sprintf(":%d", config.Port))
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	db "github.com/COMP4050/square-team-5/api/internal/pkg/db"
 	models "github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
@@ -35,6 +36,51 @@ func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
 	return m.recorder
 }
 
+// AdjustMemberGrade mocks base method.
+func (m *MockDatabase) AdjustMemberGrade(adjustment models.MemberAdjustment) (*models.MemberAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustMemberGrade", adjustment)
+	ret0, _ := ret[0].(*models.MemberAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustMemberGrade indicates an expected call of AdjustMemberGrade.
+func (mr *MockDatabaseMockRecorder) AdjustMemberGrade(adjustment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustMemberGrade", reflect.TypeOf((*MockDatabase)(nil).AdjustMemberGrade), adjustment)
+}
+
+// AllocateSubmissions mocks base method.
+func (m *MockDatabase) AllocateSubmissions(allocations []models.MarkingAllocation) ([]*models.MarkingAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateSubmissions", allocations)
+	ret0, _ := ret[0].([]*models.MarkingAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateSubmissions indicates an expected call of AllocateSubmissions.
+func (mr *MockDatabaseMockRecorder) AllocateSubmissions(allocations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateSubmissions", reflect.TypeOf((*MockDatabase)(nil).AllocateSubmissions), allocations)
+}
+
+// CloneOffering mocks base method.
+func (m *MockDatabase) CloneOffering(offeringID, termID uint, offset time.Duration) (*models.OfferingClone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneOffering", offeringID, termID, offset)
+	ret0, _ := ret[0].(*models.OfferingClone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneOffering indicates an expected call of CloneOffering.
+func (mr *MockDatabaseMockRecorder) CloneOffering(offeringID, termID, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneOffering", reflect.TypeOf((*MockDatabase)(nil).CloneOffering), offeringID, termID, offset)
+}
+
 // CreateAssignment mocks base method.
 func (m *MockDatabase) CreateAssignment(name string, dueDate int, classID uint) (*models.Assignment, error) {
 	m.ctrl.T.Helper()
@@ -50,64 +96,199 @@ func (mr *MockDatabaseMockRecorder) CreateAssignment(name, dueDate, classID inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssignment", reflect.TypeOf((*MockDatabase)(nil).CreateAssignment), name, dueDate, classID)
 }
 
+// CreateAuditEvent mocks base method.
+func (m *MockDatabase) CreateAuditEvent(event models.AuditEvent) (*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", event)
+	ret0, _ := ret[0].(*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockDatabaseMockRecorder) CreateAuditEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockDatabase)(nil).CreateAuditEvent), event)
+}
+
 // CreateClass mocks base method.
-func (m *MockDatabase) CreateClass(name string, unitID uint) (*models.Class, error) {
+func (m *MockDatabase) CreateClass(name string, unitID uint, offeringID *uint) (*models.Class, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateClass", name, unitID)
+	ret := m.ctrl.Call(m, "CreateClass", name, unitID, offeringID)
 	ret0, _ := ret[0].(*models.Class)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateClass indicates an expected call of CreateClass.
-func (mr *MockDatabaseMockRecorder) CreateClass(name, unitID interface{}) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) CreateClass(name, unitID, offeringID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClass", reflect.TypeOf((*MockDatabase)(nil).CreateClass), name, unitID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClass", reflect.TypeOf((*MockDatabase)(nil).CreateClass), name, unitID, offeringID)
+}
+
+// CreateCodeComment mocks base method.
+func (m *MockDatabase) CreateCodeComment(comment models.CodeComment) (*models.CodeComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCodeComment", comment)
+	ret0, _ := ret[0].(*models.CodeComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCodeComment indicates an expected call of CreateCodeComment.
+func (mr *MockDatabaseMockRecorder) CreateCodeComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCodeComment", reflect.TypeOf((*MockDatabase)(nil).CreateCodeComment), comment)
+}
+
+// CreateGroup mocks base method.
+func (m *MockDatabase) CreateGroup(name string, classID uint) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", name, classID)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockDatabaseMockRecorder) CreateGroup(name, classID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockDatabase)(nil).CreateGroup), name, classID)
+}
+
+// CreateOffering mocks base method.
+func (m *MockDatabase) CreateOffering(unitID, termID uint) (*models.Offering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOffering", unitID, termID)
+	ret0, _ := ret[0].(*models.Offering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOffering indicates an expected call of CreateOffering.
+func (mr *MockDatabaseMockRecorder) CreateOffering(unitID, termID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOffering", reflect.TypeOf((*MockDatabase)(nil).CreateOffering), unitID, termID)
 }
 
 // CreateResult mocks base method.
-func (m *MockDatabase) CreateResult(score float64, submissionID uint) (*models.Result, error) {
+func (m *MockDatabase) CreateResult(score float64, submissionID, submissionVersionID uint) (*models.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateResult", score, submissionID)
+	ret := m.ctrl.Call(m, "CreateResult", score, submissionID, submissionVersionID)
 	ret0, _ := ret[0].(*models.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateResult indicates an expected call of CreateResult.
-func (mr *MockDatabaseMockRecorder) CreateResult(score, submissionID interface{}) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) CreateResult(score, submissionID, submissionVersionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResult", reflect.TypeOf((*MockDatabase)(nil).CreateResult), score, submissionID, submissionVersionID)
+}
+
+// CreateStudent mocks base method.
+func (m *MockDatabase) CreateStudent(studentNumber, name, email string) (*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStudent", studentNumber, name, email)
+	ret0, _ := ret[0].(*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStudent indicates an expected call of CreateStudent.
+func (mr *MockDatabaseMockRecorder) CreateStudent(studentNumber, name, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResult", reflect.TypeOf((*MockDatabase)(nil).CreateResult), score, submissionID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStudent", reflect.TypeOf((*MockDatabase)(nil).CreateStudent), studentNumber, name, email)
+}
+
+// CreateStudentUser mocks base method.
+func (m *MockDatabase) CreateStudentUser(email, passwordHash string, studentID uint) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStudentUser", email, passwordHash, studentID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStudentUser indicates an expected call of CreateStudentUser.
+func (mr *MockDatabaseMockRecorder) CreateStudentUser(email, passwordHash, studentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStudentUser", reflect.TypeOf((*MockDatabase)(nil).CreateStudentUser), email, passwordHash, studentID)
 }
 
 // CreateSubmission mocks base method.
-func (m *MockDatabase) CreateSubmission(studentID string, assignmentID uint) (*models.Submission, error) {
+func (m *MockDatabase) CreateSubmission(studentID string, assignmentID uint, studentRecordID *uint, files []models.SubmissionFile) (*models.Submission, *models.SubmissionVersion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSubmission", studentID, assignmentID)
+	ret := m.ctrl.Call(m, "CreateSubmission", studentID, assignmentID, studentRecordID, files)
 	ret0, _ := ret[0].(*models.Submission)
+	ret1, _ := ret[1].(*models.SubmissionVersion)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateSubmission indicates an expected call of CreateSubmission.
+func (mr *MockDatabaseMockRecorder) CreateSubmission(studentID, assignmentID, studentRecordID, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubmission", reflect.TypeOf((*MockDatabase)(nil).CreateSubmission), studentID, assignmentID, studentRecordID, files)
+}
+
+// CreateSubmissionVersion mocks base method.
+func (m *MockDatabase) CreateSubmissionVersion(submissionID uint, files []models.SubmissionFile) (*models.SubmissionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubmissionVersion", submissionID, files)
+	ret0, _ := ret[0].(*models.SubmissionVersion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSubmission indicates an expected call of CreateSubmission.
-func (mr *MockDatabaseMockRecorder) CreateSubmission(studentID, assignmentID interface{}) *gomock.Call {
+// CreateSubmissionVersion indicates an expected call of CreateSubmissionVersion.
+func (mr *MockDatabaseMockRecorder) CreateSubmissionVersion(submissionID, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubmissionVersion", reflect.TypeOf((*MockDatabase)(nil).CreateSubmissionVersion), submissionID, files)
+}
+
+// CreateTerm mocks base method.
+func (m *MockDatabase) CreateTerm(name string, startDate, endDate time.Time) (*models.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTerm", name, startDate, endDate)
+	ret0, _ := ret[0].(*models.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTerm indicates an expected call of CreateTerm.
+func (mr *MockDatabaseMockRecorder) CreateTerm(name, startDate, endDate interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubmission", reflect.TypeOf((*MockDatabase)(nil).CreateSubmission), studentID, assignmentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTerm", reflect.TypeOf((*MockDatabase)(nil).CreateTerm), name, startDate, endDate)
 }
 
 // CreateTest mocks base method.
-func (m *MockDatabase) CreateTest(name string, assignmentID uint) (*models.Test, error) {
+func (m *MockDatabase) CreateTest(name string, assignmentID uint, maxPoints, weight float64) (*models.Test, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTest", name, assignmentID)
+	ret := m.ctrl.Call(m, "CreateTest", name, assignmentID, maxPoints, weight)
 	ret0, _ := ret[0].(*models.Test)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTest indicates an expected call of CreateTest.
-func (mr *MockDatabaseMockRecorder) CreateTest(name, assignmentID interface{}) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) CreateTest(name, assignmentID, maxPoints, weight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTest", reflect.TypeOf((*MockDatabase)(nil).CreateTest), name, assignmentID, maxPoints, weight)
+}
+
+// CreateTestOutcomes mocks base method.
+func (m *MockDatabase) CreateTestOutcomes(outcomes []models.TestOutcome) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTestOutcomes", outcomes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTestOutcomes indicates an expected call of CreateTestOutcomes.
+func (mr *MockDatabaseMockRecorder) CreateTestOutcomes(outcomes interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTest", reflect.TypeOf((*MockDatabase)(nil).CreateTest), name, assignmentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTestOutcomes", reflect.TypeOf((*MockDatabase)(nil).CreateTestOutcomes), outcomes)
 }
 
 // CreateUnit mocks base method.
@@ -140,6 +321,63 @@ func (mr *MockDatabaseMockRecorder) CreateUser(email, passwordHash, role interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockDatabase)(nil).CreateUser), email, passwordHash, role)
 }
 
+// DeleteCodeComment mocks base method.
+func (m *MockDatabase) DeleteCodeComment(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCodeComment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCodeComment indicates an expected call of DeleteCodeComment.
+func (mr *MockDatabaseMockRecorder) DeleteCodeComment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCodeComment", reflect.TypeOf((*MockDatabase)(nil).DeleteCodeComment), id)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockDatabase) DeleteGroup(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockDatabaseMockRecorder) DeleteGroup(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockDatabase)(nil).DeleteGroup), id)
+}
+
+// EnrolStudent mocks base method.
+func (m *MockDatabase) EnrolStudent(studentID, classID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrolStudent", studentID, classID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrolStudent indicates an expected call of EnrolStudent.
+func (mr *MockDatabaseMockRecorder) EnrolStudent(studentID, classID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrolStudent", reflect.TypeOf((*MockDatabase)(nil).EnrolStudent), studentID, classID)
+}
+
+// EnterSecondMark mocks base method.
+func (m *MockDatabase) EnterSecondMark(submissionID uint, firstMark, secondMark float64, status models.ModerationStatus) (*models.ModerationSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnterSecondMark", submissionID, firstMark, secondMark, status)
+	ret0, _ := ret[0].(*models.ModerationSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnterSecondMark indicates an expected call of EnterSecondMark.
+func (mr *MockDatabaseMockRecorder) EnterSecondMark(submissionID, firstMark, secondMark, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnterSecondMark", reflect.TypeOf((*MockDatabase)(nil).EnterSecondMark), submissionID, firstMark, secondMark, status)
+}
+
 // GetAllAssignments mocks base method.
 func (m *MockDatabase) GetAllAssignments(from int) ([]*models.Assignment, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +423,21 @@ func (mr *MockDatabaseMockRecorder) GetAllResults(from interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllResults", reflect.TypeOf((*MockDatabase)(nil).GetAllResults), from)
 }
 
+// GetAllStudents mocks base method.
+func (m *MockDatabase) GetAllStudents(from int) ([]*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllStudents", from)
+	ret0, _ := ret[0].([]*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllStudents indicates an expected call of GetAllStudents.
+func (mr *MockDatabaseMockRecorder) GetAllStudents(from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStudents", reflect.TypeOf((*MockDatabase)(nil).GetAllStudents), from)
+}
+
 // GetAllSubmissions mocks base method.
 func (m *MockDatabase) GetAllSubmissions(from int) ([]*models.Submission, error) {
 	m.ctrl.T.Helper()
@@ -245,6 +498,21 @@ func (mr *MockDatabaseMockRecorder) GetAssignment(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignment", reflect.TypeOf((*MockDatabase)(nil).GetAssignment), id)
 }
 
+// GetAssignmentStatistics mocks base method.
+func (m *MockDatabase) GetAssignmentStatistics(assignmentID uint) (*models.AssignmentStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentStatistics", assignmentID)
+	ret0, _ := ret[0].(*models.AssignmentStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentStatistics indicates an expected call of GetAssignmentStatistics.
+func (mr *MockDatabaseMockRecorder) GetAssignmentStatistics(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentStatistics", reflect.TypeOf((*MockDatabase)(nil).GetAssignmentStatistics), assignmentID)
+}
+
 // GetAssignmentsForClass mocks base method.
 func (m *MockDatabase) GetAssignmentsForClass(classID uint) ([]*models.Assignment, error) {
 	m.ctrl.T.Helper()
@@ -260,6 +528,36 @@ func (mr *MockDatabaseMockRecorder) GetAssignmentsForClass(classID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentsForClass", reflect.TypeOf((*MockDatabase)(nil).GetAssignmentsForClass), classID)
 }
 
+// GetAssignmentsForTerm mocks base method.
+func (m *MockDatabase) GetAssignmentsForTerm(termID uint, from int) ([]*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentsForTerm", termID, from)
+	ret0, _ := ret[0].([]*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentsForTerm indicates an expected call of GetAssignmentsForTerm.
+func (mr *MockDatabaseMockRecorder) GetAssignmentsForTerm(termID, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentsForTerm", reflect.TypeOf((*MockDatabase)(nil).GetAssignmentsForTerm), termID, from)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockDatabase) GetAuditEvents(filter models.AuditEventFilter, from int) ([]*models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", filter, from)
+	ret0, _ := ret[0].([]*models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockDatabaseMockRecorder) GetAuditEvents(filter, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockDatabase)(nil).GetAuditEvents), filter, from)
+}
+
 // GetClass mocks base method.
 func (m *MockDatabase) GetClass(id string) (*models.Class, error) {
 	m.ctrl.T.Helper()
@@ -275,137 +573,1360 @@ func (mr *MockDatabaseMockRecorder) GetClass(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClass", reflect.TypeOf((*MockDatabase)(nil).GetClass), id)
 }
 
-// GetResult mocks base method.
-func (m *MockDatabase) GetResult(id string) (*models.Result, error) {
+// GetClassesForOffering mocks base method.
+func (m *MockDatabase) GetClassesForOffering(offeringID uint) ([]*models.Class, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetResult", id)
-	ret0, _ := ret[0].(*models.Result)
+	ret := m.ctrl.Call(m, "GetClassesForOffering", offeringID)
+	ret0, _ := ret[0].([]*models.Class)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetResult indicates an expected call of GetResult.
-func (mr *MockDatabaseMockRecorder) GetResult(id interface{}) *gomock.Call {
+// GetClassesForOffering indicates an expected call of GetClassesForOffering.
+func (mr *MockDatabaseMockRecorder) GetClassesForOffering(offeringID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResult", reflect.TypeOf((*MockDatabase)(nil).GetResult), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassesForOffering", reflect.TypeOf((*MockDatabase)(nil).GetClassesForOffering), offeringID)
 }
 
-// GetSubmission mocks base method.
-func (m *MockDatabase) GetSubmission(id string) (*models.Submission, error) {
+// GetClassesForStudent mocks base method.
+func (m *MockDatabase) GetClassesForStudent(studentID uint) ([]*models.Class, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubmission", id)
-	ret0, _ := ret[0].(*models.Submission)
+	ret := m.ctrl.Call(m, "GetClassesForStudent", studentID)
+	ret0, _ := ret[0].([]*models.Class)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSubmission indicates an expected call of GetSubmission.
-func (mr *MockDatabaseMockRecorder) GetSubmission(id interface{}) *gomock.Call {
+// GetClassesForStudent indicates an expected call of GetClassesForStudent.
+func (mr *MockDatabaseMockRecorder) GetClassesForStudent(studentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmission", reflect.TypeOf((*MockDatabase)(nil).GetSubmission), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassesForStudent", reflect.TypeOf((*MockDatabase)(nil).GetClassesForStudent), studentID)
 }
 
-// GetSubmissionsForAssignment mocks base method.
-func (m *MockDatabase) GetSubmissionsForAssignment(assignmentID string) ([]*models.Submission, error) {
+// GetClassesForTerm mocks base method.
+func (m *MockDatabase) GetClassesForTerm(termID uint, from int) ([]*models.Class, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSubmissionsForAssignment", assignmentID)
-	ret0, _ := ret[0].([]*models.Submission)
+	ret := m.ctrl.Call(m, "GetClassesForTerm", termID, from)
+	ret0, _ := ret[0].([]*models.Class)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSubmissionsForAssignment indicates an expected call of GetSubmissionsForAssignment.
-func (mr *MockDatabaseMockRecorder) GetSubmissionsForAssignment(assignmentID interface{}) *gomock.Call {
+// GetClassesForTerm indicates an expected call of GetClassesForTerm.
+func (mr *MockDatabaseMockRecorder) GetClassesForTerm(termID, from interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionsForAssignment), assignmentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassesForTerm", reflect.TypeOf((*MockDatabase)(nil).GetClassesForTerm), termID, from)
 }
 
-// GetTest mocks base method.
-func (m *MockDatabase) GetTest(id string) (*models.Test, error) {
+// GetCodeComment mocks base method.
+func (m *MockDatabase) GetCodeComment(id string) (*models.CodeComment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTest", id)
-	ret0, _ := ret[0].(*models.Test)
+	ret := m.ctrl.Call(m, "GetCodeComment", id)
+	ret0, _ := ret[0].(*models.CodeComment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTest indicates an expected call of GetTest.
-func (mr *MockDatabaseMockRecorder) GetTest(id interface{}) *gomock.Call {
+// GetCodeComment indicates an expected call of GetCodeComment.
+func (mr *MockDatabaseMockRecorder) GetCodeComment(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTest", reflect.TypeOf((*MockDatabase)(nil).GetTest), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeComment", reflect.TypeOf((*MockDatabase)(nil).GetCodeComment), id)
 }
 
-// GetTestsForAssignment mocks base method.
-func (m *MockDatabase) GetTestsForAssignment(assignmentID string) ([]*models.Test, error) {
+// GetCodeCommentsForSubmission mocks base method.
+func (m *MockDatabase) GetCodeCommentsForSubmission(submissionID uint) ([]*models.CodeComment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTestsForAssignment", assignmentID)
-	ret0, _ := ret[0].([]*models.Test)
+	ret := m.ctrl.Call(m, "GetCodeCommentsForSubmission", submissionID)
+	ret0, _ := ret[0].([]*models.CodeComment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTestsForAssignment indicates an expected call of GetTestsForAssignment.
-func (mr *MockDatabaseMockRecorder) GetTestsForAssignment(assignmentID interface{}) *gomock.Call {
+// GetCodeCommentsForSubmission indicates an expected call of GetCodeCommentsForSubmission.
+func (mr *MockDatabaseMockRecorder) GetCodeCommentsForSubmission(submissionID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetTestsForAssignment), assignmentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeCommentsForSubmission", reflect.TypeOf((*MockDatabase)(nil).GetCodeCommentsForSubmission), submissionID)
 }
 
-// GetUnitByID mocks base method.
-func (m *MockDatabase) GetUnitByID(id string, fetchClasses bool) (*models.Unit, error) {
+// GetCodeMetrics mocks base method.
+func (m *MockDatabase) GetCodeMetrics(submissionVersionID uint) ([]*models.CodeMetrics, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUnitByID", id, fetchClasses)
-	ret0, _ := ret[0].(*models.Unit)
+	ret := m.ctrl.Call(m, "GetCodeMetrics", submissionVersionID)
+	ret0, _ := ret[0].([]*models.CodeMetrics)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUnitByID indicates an expected call of GetUnitByID.
-func (mr *MockDatabaseMockRecorder) GetUnitByID(id, fetchClasses interface{}) *gomock.Call {
+// GetCodeMetrics indicates an expected call of GetCodeMetrics.
+func (mr *MockDatabaseMockRecorder) GetCodeMetrics(submissionVersionID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitByID", reflect.TypeOf((*MockDatabase)(nil).GetUnitByID), id, fetchClasses)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeMetrics", reflect.TypeOf((*MockDatabase)(nil).GetCodeMetrics), submissionVersionID)
 }
 
-// GetUnitByName mocks base method.
-func (m *MockDatabase) GetUnitByName(name string) (*models.Unit, error) {
+// GetExtension mocks base method.
+func (m *MockDatabase) GetExtension(id string) (*models.Extension, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUnitByName", name)
-	ret0, _ := ret[0].(*models.Unit)
+	ret := m.ctrl.Call(m, "GetExtension", id)
+	ret0, _ := ret[0].(*models.Extension)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUnitByName indicates an expected call of GetUnitByName.
-func (mr *MockDatabaseMockRecorder) GetUnitByName(name interface{}) *gomock.Call {
+// GetExtension indicates an expected call of GetExtension.
+func (mr *MockDatabaseMockRecorder) GetExtension(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitByName", reflect.TypeOf((*MockDatabase)(nil).GetUnitByName), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExtension", reflect.TypeOf((*MockDatabase)(nil).GetExtension), id)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockDatabase) GetUserByEmail(email string) (*models.User, error) {
+// GetExtensionForStudent mocks base method.
+func (m *MockDatabase) GetExtensionForStudent(studentID, assignmentID uint) (*models.Extension, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", email)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "GetExtensionForStudent", studentID, assignmentID)
+	ret0, _ := ret[0].(*models.Extension)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockDatabaseMockRecorder) GetUserByEmail(email interface{}) *gomock.Call {
+// GetExtensionForStudent indicates an expected call of GetExtensionForStudent.
+func (mr *MockDatabaseMockRecorder) GetExtensionForStudent(studentID, assignmentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockDatabase)(nil).GetUserByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExtensionForStudent", reflect.TypeOf((*MockDatabase)(nil).GetExtensionForStudent), studentID, assignmentID)
 }
 
-// ResetDB mocks base method.
-func (m *MockDatabase) ResetDB() (db.Database, error) {
+// GetExtensionsForAssignment mocks base method.
+func (m *MockDatabase) GetExtensionsForAssignment(assignmentID uint) ([]*models.Extension, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResetDB")
-	ret0, _ := ret[0].(db.Database)
+	ret := m.ctrl.Call(m, "GetExtensionsForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.Extension)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ResetDB indicates an expected call of ResetDB.
-func (mr *MockDatabaseMockRecorder) ResetDB() *gomock.Call {
+// GetExtensionsForAssignment indicates an expected call of GetExtensionsForAssignment.
+func (mr *MockDatabaseMockRecorder) GetExtensionsForAssignment(assignmentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDB", reflect.TypeOf((*MockDatabase)(nil).ResetDB))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExtensionsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetExtensionsForAssignment), assignmentID)
+}
+
+// GetGroup mocks base method.
+func (m *MockDatabase) GetGroup(id string) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", id)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup.
+func (mr *MockDatabaseMockRecorder) GetGroup(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockDatabase)(nil).GetGroup), id)
+}
+
+// GetGroupForStudent mocks base method.
+func (m *MockDatabase) GetGroupForStudent(studentID, classID uint) (*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupForStudent", studentID, classID)
+	ret0, _ := ret[0].(*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupForStudent indicates an expected call of GetGroupForStudent.
+func (mr *MockDatabaseMockRecorder) GetGroupForStudent(studentID, classID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupForStudent", reflect.TypeOf((*MockDatabase)(nil).GetGroupForStudent), studentID, classID)
+}
+
+// GetGroupMembers mocks base method.
+func (m *MockDatabase) GetGroupMembers(groupID uint) ([]*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupMembers", groupID)
+	ret0, _ := ret[0].([]*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupMembers indicates an expected call of GetGroupMembers.
+func (mr *MockDatabaseMockRecorder) GetGroupMembers(groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMembers", reflect.TypeOf((*MockDatabase)(nil).GetGroupMembers), groupID)
+}
+
+// GetGroupsForClass mocks base method.
+func (m *MockDatabase) GetGroupsForClass(classID uint) ([]*models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsForClass", classID)
+	ret0, _ := ret[0].([]*models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsForClass indicates an expected call of GetGroupsForClass.
+func (mr *MockDatabaseMockRecorder) GetGroupsForClass(classID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsForClass", reflect.TypeOf((*MockDatabase)(nil).GetGroupsForClass), classID)
+}
+
+// GetLintFindings mocks base method.
+func (m *MockDatabase) GetLintFindings(submissionVersionID uint) ([]*models.LintFinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLintFindings", submissionVersionID)
+	ret0, _ := ret[0].([]*models.LintFinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLintFindings indicates an expected call of GetLintFindings.
+func (mr *MockDatabaseMockRecorder) GetLintFindings(submissionVersionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLintFindings", reflect.TypeOf((*MockDatabase)(nil).GetLintFindings), submissionVersionID)
+}
+
+// GetLintFindingsForAssignment mocks base method.
+func (m *MockDatabase) GetLintFindingsForAssignment(assignmentID uint) ([]*models.LintFinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLintFindingsForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.LintFinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLintFindingsForAssignment indicates an expected call of GetLintFindingsForAssignment.
+func (mr *MockDatabaseMockRecorder) GetLintFindingsForAssignment(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLintFindingsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetLintFindingsForAssignment), assignmentID)
+}
+
+// GetLintRules mocks base method.
+func (m *MockDatabase) GetLintRules(assignmentID uint) ([]*models.LintRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLintRules", assignmentID)
+	ret0, _ := ret[0].([]*models.LintRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLintRules indicates an expected call of GetLintRules.
+func (mr *MockDatabaseMockRecorder) GetLintRules(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLintRules", reflect.TypeOf((*MockDatabase)(nil).GetLintRules), assignmentID)
+}
+
+// GetMarkingAllocation mocks base method.
+func (m *MockDatabase) GetMarkingAllocation(submissionID uint) (*models.MarkingAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarkingAllocation", submissionID)
+	ret0, _ := ret[0].(*models.MarkingAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarkingAllocation indicates an expected call of GetMarkingAllocation.
+func (mr *MockDatabaseMockRecorder) GetMarkingAllocation(submissionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarkingAllocation", reflect.TypeOf((*MockDatabase)(nil).GetMarkingAllocation), submissionID)
+}
+
+// GetMarkingAllocationsForAssignment mocks base method.
+func (m *MockDatabase) GetMarkingAllocationsForAssignment(assignmentID uint) ([]*models.MarkingAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarkingAllocationsForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.MarkingAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarkingAllocationsForAssignment indicates an expected call of GetMarkingAllocationsForAssignment.
+func (mr *MockDatabaseMockRecorder) GetMarkingAllocationsForAssignment(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarkingAllocationsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetMarkingAllocationsForAssignment), assignmentID)
+}
+
+// GetMarkingAllocationsForMarker mocks base method.
+func (m *MockDatabase) GetMarkingAllocationsForMarker(marker string) ([]*models.MarkingAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarkingAllocationsForMarker", marker)
+	ret0, _ := ret[0].([]*models.MarkingAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarkingAllocationsForMarker indicates an expected call of GetMarkingAllocationsForMarker.
+func (mr *MockDatabaseMockRecorder) GetMarkingAllocationsForMarker(marker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarkingAllocationsForMarker", reflect.TypeOf((*MockDatabase)(nil).GetMarkingAllocationsForMarker), marker)
+}
+
+// GetMemberAdjustment mocks base method.
+func (m *MockDatabase) GetMemberAdjustment(submissionID, studentID uint) (*models.MemberAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberAdjustment", submissionID, studentID)
+	ret0, _ := ret[0].(*models.MemberAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberAdjustment indicates an expected call of GetMemberAdjustment.
+func (mr *MockDatabaseMockRecorder) GetMemberAdjustment(submissionID, studentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberAdjustment", reflect.TypeOf((*MockDatabase)(nil).GetMemberAdjustment), submissionID, studentID)
+}
+
+// GetMemberAdjustments mocks base method.
+func (m *MockDatabase) GetMemberAdjustments(submissionID uint) ([]*models.MemberAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberAdjustments", submissionID)
+	ret0, _ := ret[0].([]*models.MemberAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberAdjustments indicates an expected call of GetMemberAdjustments.
+func (mr *MockDatabaseMockRecorder) GetMemberAdjustments(submissionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberAdjustments", reflect.TypeOf((*MockDatabase)(nil).GetMemberAdjustments), submissionID)
+}
+
+// GetModerationSample mocks base method.
+func (m *MockDatabase) GetModerationSample(submissionID uint) (*models.ModerationSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationSample", submissionID)
+	ret0, _ := ret[0].(*models.ModerationSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationSample indicates an expected call of GetModerationSample.
+func (mr *MockDatabaseMockRecorder) GetModerationSample(submissionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationSample", reflect.TypeOf((*MockDatabase)(nil).GetModerationSample), submissionID)
+}
+
+// GetModerationSamples mocks base method.
+func (m *MockDatabase) GetModerationSamples(assignmentID uint) ([]*models.ModerationSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationSamples", assignmentID)
+	ret0, _ := ret[0].([]*models.ModerationSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationSamples indicates an expected call of GetModerationSamples.
+func (mr *MockDatabaseMockRecorder) GetModerationSamples(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationSamples", reflect.TypeOf((*MockDatabase)(nil).GetModerationSamples), assignmentID)
+}
+
+// GetOffering mocks base method.
+func (m *MockDatabase) GetOffering(id string) (*models.Offering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOffering", id)
+	ret0, _ := ret[0].(*models.Offering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOffering indicates an expected call of GetOffering.
+func (mr *MockDatabaseMockRecorder) GetOffering(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOffering", reflect.TypeOf((*MockDatabase)(nil).GetOffering), id)
+}
+
+// GetOfferingForUnit mocks base method.
+func (m *MockDatabase) GetOfferingForUnit(unitID, termID uint) (*models.Offering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOfferingForUnit", unitID, termID)
+	ret0, _ := ret[0].(*models.Offering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOfferingForUnit indicates an expected call of GetOfferingForUnit.
+func (mr *MockDatabaseMockRecorder) GetOfferingForUnit(unitID, termID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOfferingForUnit", reflect.TypeOf((*MockDatabase)(nil).GetOfferingForUnit), unitID, termID)
+}
+
+// GetOfferingsForTerm mocks base method.
+func (m *MockDatabase) GetOfferingsForTerm(termID uint) ([]*models.Offering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOfferingsForTerm", termID)
+	ret0, _ := ret[0].([]*models.Offering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOfferingsForTerm indicates an expected call of GetOfferingsForTerm.
+func (mr *MockDatabaseMockRecorder) GetOfferingsForTerm(termID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOfferingsForTerm", reflect.TypeOf((*MockDatabase)(nil).GetOfferingsForTerm), termID)
+}
+
+// GetOfferingsForUnit mocks base method.
+func (m *MockDatabase) GetOfferingsForUnit(unitID uint) ([]*models.Offering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOfferingsForUnit", unitID)
+	ret0, _ := ret[0].([]*models.Offering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOfferingsForUnit indicates an expected call of GetOfferingsForUnit.
+func (mr *MockDatabaseMockRecorder) GetOfferingsForUnit(unitID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOfferingsForUnit", reflect.TypeOf((*MockDatabase)(nil).GetOfferingsForUnit), unitID)
+}
+
+// GetResult mocks base method.
+func (m *MockDatabase) GetResult(id string) (*models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResult", id)
+	ret0, _ := ret[0].(*models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResult indicates an expected call of GetResult.
+func (mr *MockDatabaseMockRecorder) GetResult(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResult", reflect.TypeOf((*MockDatabase)(nil).GetResult), id)
+}
+
+// GetResultOverrides mocks base method.
+func (m *MockDatabase) GetResultOverrides(resultID uint) ([]*models.ResultOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResultOverrides", resultID)
+	ret0, _ := ret[0].([]*models.ResultOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResultOverrides indicates an expected call of GetResultOverrides.
+func (mr *MockDatabaseMockRecorder) GetResultOverrides(resultID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultOverrides", reflect.TypeOf((*MockDatabase)(nil).GetResultOverrides), resultID)
+}
+
+// GetResultsForAssignment mocks base method.
+func (m *MockDatabase) GetResultsForAssignment(assignmentID uint) ([]*models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResultsForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResultsForAssignment indicates an expected call of GetResultsForAssignment.
+func (mr *MockDatabaseMockRecorder) GetResultsForAssignment(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetResultsForAssignment), assignmentID)
+}
+
+// GetResultsForSubmission mocks base method.
+func (m *MockDatabase) GetResultsForSubmission(submissionID uint) ([]*models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResultsForSubmission", submissionID)
+	ret0, _ := ret[0].([]*models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResultsForSubmission indicates an expected call of GetResultsForSubmission.
+func (mr *MockDatabaseMockRecorder) GetResultsForSubmission(submissionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultsForSubmission", reflect.TypeOf((*MockDatabase)(nil).GetResultsForSubmission), submissionID)
+}
+
+// GetRubric mocks base method.
+func (m *MockDatabase) GetRubric(assignmentID uint) ([]*models.RubricCriterion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRubric", assignmentID)
+	ret0, _ := ret[0].([]*models.RubricCriterion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRubric indicates an expected call of GetRubric.
+func (mr *MockDatabaseMockRecorder) GetRubric(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRubric", reflect.TypeOf((*MockDatabase)(nil).GetRubric), assignmentID)
+}
+
+// GetRubricMarks mocks base method.
+func (m *MockDatabase) GetRubricMarks(submissionID uint) ([]*models.RubricMark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRubricMarks", submissionID)
+	ret0, _ := ret[0].([]*models.RubricMark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRubricMarks indicates an expected call of GetRubricMarks.
+func (mr *MockDatabaseMockRecorder) GetRubricMarks(submissionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRubricMarks", reflect.TypeOf((*MockDatabase)(nil).GetRubricMarks), submissionID)
+}
+
+// GetRubricMarksForAssignment mocks base method.
+func (m *MockDatabase) GetRubricMarksForAssignment(assignmentID uint) ([]*models.RubricMark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRubricMarksForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.RubricMark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRubricMarksForAssignment indicates an expected call of GetRubricMarksForAssignment.
+func (mr *MockDatabaseMockRecorder) GetRubricMarksForAssignment(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRubricMarksForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetRubricMarksForAssignment), assignmentID)
+}
+
+// GetStarterFiles mocks base method.
+func (m *MockDatabase) GetStarterFiles(assignmentID uint) ([]*models.StarterFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStarterFiles", assignmentID)
+	ret0, _ := ret[0].([]*models.StarterFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStarterFiles indicates an expected call of GetStarterFiles.
+func (mr *MockDatabaseMockRecorder) GetStarterFiles(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStarterFiles", reflect.TypeOf((*MockDatabase)(nil).GetStarterFiles), assignmentID)
+}
+
+// GetStudent mocks base method.
+func (m *MockDatabase) GetStudent(id string) (*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudent", id)
+	ret0, _ := ret[0].(*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudent indicates an expected call of GetStudent.
+func (mr *MockDatabaseMockRecorder) GetStudent(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudent", reflect.TypeOf((*MockDatabase)(nil).GetStudent), id)
+}
+
+// GetStudentByNumber mocks base method.
+func (m *MockDatabase) GetStudentByNumber(studentNumber string) (*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentByNumber", studentNumber)
+	ret0, _ := ret[0].(*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentByNumber indicates an expected call of GetStudentByNumber.
+func (mr *MockDatabaseMockRecorder) GetStudentByNumber(studentNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentByNumber", reflect.TypeOf((*MockDatabase)(nil).GetStudentByNumber), studentNumber)
+}
+
+// GetStudentInvite mocks base method.
+func (m *MockDatabase) GetStudentInvite(studentID uint) (*models.StudentInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentInvite", studentID)
+	ret0, _ := ret[0].(*models.StudentInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentInvite indicates an expected call of GetStudentInvite.
+func (mr *MockDatabaseMockRecorder) GetStudentInvite(studentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentInvite", reflect.TypeOf((*MockDatabase)(nil).GetStudentInvite), studentID)
+}
+
+// GetStudentsForClass mocks base method.
+func (m *MockDatabase) GetStudentsForClass(classID uint) ([]*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentsForClass", classID)
+	ret0, _ := ret[0].([]*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentsForClass indicates an expected call of GetStudentsForClass.
+func (mr *MockDatabaseMockRecorder) GetStudentsForClass(classID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentsForClass", reflect.TypeOf((*MockDatabase)(nil).GetStudentsForClass), classID)
+}
+
+// GetStudentsWithoutSubmission mocks base method.
+func (m *MockDatabase) GetStudentsWithoutSubmission(assignmentID string) ([]*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentsWithoutSubmission", assignmentID)
+	ret0, _ := ret[0].([]*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentsWithoutSubmission indicates an expected call of GetStudentsWithoutSubmission.
+func (mr *MockDatabaseMockRecorder) GetStudentsWithoutSubmission(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentsWithoutSubmission", reflect.TypeOf((*MockDatabase)(nil).GetStudentsWithoutSubmission), assignmentID)
+}
+
+// GetSubmission mocks base method.
+func (m *MockDatabase) GetSubmission(id string) (*models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmission", id)
+	ret0, _ := ret[0].(*models.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmission indicates an expected call of GetSubmission.
+func (mr *MockDatabaseMockRecorder) GetSubmission(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmission", reflect.TypeOf((*MockDatabase)(nil).GetSubmission), id)
+}
+
+// GetSubmissionFiles mocks base method.
+func (m *MockDatabase) GetSubmissionFiles(submissionVersionID uint) ([]*models.SubmissionFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionFiles", submissionVersionID)
+	ret0, _ := ret[0].([]*models.SubmissionFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionFiles indicates an expected call of GetSubmissionFiles.
+func (mr *MockDatabaseMockRecorder) GetSubmissionFiles(submissionVersionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionFiles", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionFiles), submissionVersionID)
+}
+
+// GetSubmissionForGroup mocks base method.
+func (m *MockDatabase) GetSubmissionForGroup(assignmentID, groupID uint) (*models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionForGroup", assignmentID, groupID)
+	ret0, _ := ret[0].(*models.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionForGroup indicates an expected call of GetSubmissionForGroup.
+func (mr *MockDatabaseMockRecorder) GetSubmissionForGroup(assignmentID, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionForGroup", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionForGroup), assignmentID, groupID)
+}
+
+// GetSubmissionForStudent mocks base method.
+func (m *MockDatabase) GetSubmissionForStudent(assignmentID uint, studentID string, studentRecordID *uint) (*models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionForStudent", assignmentID, studentID, studentRecordID)
+	ret0, _ := ret[0].(*models.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionForStudent indicates an expected call of GetSubmissionForStudent.
+func (mr *MockDatabaseMockRecorder) GetSubmissionForStudent(assignmentID, studentID, studentRecordID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionForStudent", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionForStudent), assignmentID, studentID, studentRecordID)
+}
+
+// GetSubmissionVersion mocks base method.
+func (m *MockDatabase) GetSubmissionVersion(id string) (*models.SubmissionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionVersion", id)
+	ret0, _ := ret[0].(*models.SubmissionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionVersion indicates an expected call of GetSubmissionVersion.
+func (mr *MockDatabaseMockRecorder) GetSubmissionVersion(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionVersion", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionVersion), id)
+}
+
+// GetSubmissionVersions mocks base method.
+func (m *MockDatabase) GetSubmissionVersions(submissionID uint) ([]*models.SubmissionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionVersions", submissionID)
+	ret0, _ := ret[0].([]*models.SubmissionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionVersions indicates an expected call of GetSubmissionVersions.
+func (mr *MockDatabaseMockRecorder) GetSubmissionVersions(submissionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionVersions", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionVersions), submissionID)
+}
+
+// GetSubmissionVersionsForAssignment mocks base method.
+func (m *MockDatabase) GetSubmissionVersionsForAssignment(assignmentID uint) ([]*models.SubmissionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionVersionsForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.SubmissionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionVersionsForAssignment indicates an expected call of GetSubmissionVersionsForAssignment.
+func (mr *MockDatabaseMockRecorder) GetSubmissionVersionsForAssignment(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionVersionsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionVersionsForAssignment), assignmentID)
+}
+
+// GetSubmissionsForAssignment mocks base method.
+func (m *MockDatabase) GetSubmissionsForAssignment(assignmentID string) ([]*models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionsForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionsForAssignment indicates an expected call of GetSubmissionsForAssignment.
+func (mr *MockDatabaseMockRecorder) GetSubmissionsForAssignment(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionsForAssignment), assignmentID)
+}
+
+// GetSubmissionsForStudent mocks base method.
+func (m *MockDatabase) GetSubmissionsForStudent(studentID uint) ([]*models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmissionsForStudent", studentID)
+	ret0, _ := ret[0].([]*models.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmissionsForStudent indicates an expected call of GetSubmissionsForStudent.
+func (mr *MockDatabaseMockRecorder) GetSubmissionsForStudent(studentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmissionsForStudent", reflect.TypeOf((*MockDatabase)(nil).GetSubmissionsForStudent), studentID)
+}
+
+// GetTerm mocks base method.
+func (m *MockDatabase) GetTerm(id string) (*models.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTerm", id)
+	ret0, _ := ret[0].(*models.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTerm indicates an expected call of GetTerm.
+func (mr *MockDatabaseMockRecorder) GetTerm(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTerm", reflect.TypeOf((*MockDatabase)(nil).GetTerm), id)
+}
+
+// GetTermAt mocks base method.
+func (m *MockDatabase) GetTermAt(t time.Time) (*models.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTermAt", t)
+	ret0, _ := ret[0].(*models.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTermAt indicates an expected call of GetTermAt.
+func (mr *MockDatabaseMockRecorder) GetTermAt(t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTermAt", reflect.TypeOf((*MockDatabase)(nil).GetTermAt), t)
+}
+
+// GetTermByName mocks base method.
+func (m *MockDatabase) GetTermByName(name string) (*models.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTermByName", name)
+	ret0, _ := ret[0].(*models.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTermByName indicates an expected call of GetTermByName.
+func (mr *MockDatabaseMockRecorder) GetTermByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTermByName", reflect.TypeOf((*MockDatabase)(nil).GetTermByName), name)
+}
+
+// GetTerms mocks base method.
+func (m *MockDatabase) GetTerms() ([]*models.Term, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTerms")
+	ret0, _ := ret[0].([]*models.Term)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTerms indicates an expected call of GetTerms.
+func (mr *MockDatabaseMockRecorder) GetTerms() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTerms", reflect.TypeOf((*MockDatabase)(nil).GetTerms))
+}
+
+// GetTest mocks base method.
+func (m *MockDatabase) GetTest(id string) (*models.Test, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTest", id)
+	ret0, _ := ret[0].(*models.Test)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTest indicates an expected call of GetTest.
+func (mr *MockDatabaseMockRecorder) GetTest(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTest", reflect.TypeOf((*MockDatabase)(nil).GetTest), id)
+}
+
+// GetTestOutcomesForAssignment mocks base method.
+func (m *MockDatabase) GetTestOutcomesForAssignment(assignmentID uint) ([]*models.TestOutcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestOutcomesForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.TestOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestOutcomesForAssignment indicates an expected call of GetTestOutcomesForAssignment.
+func (mr *MockDatabaseMockRecorder) GetTestOutcomesForAssignment(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestOutcomesForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetTestOutcomesForAssignment), assignmentID)
+}
+
+// GetTestOutcomesForVersion mocks base method.
+func (m *MockDatabase) GetTestOutcomesForVersion(submissionVersionID uint) ([]*models.TestOutcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestOutcomesForVersion", submissionVersionID)
+	ret0, _ := ret[0].([]*models.TestOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestOutcomesForVersion indicates an expected call of GetTestOutcomesForVersion.
+func (mr *MockDatabaseMockRecorder) GetTestOutcomesForVersion(submissionVersionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestOutcomesForVersion", reflect.TypeOf((*MockDatabase)(nil).GetTestOutcomesForVersion), submissionVersionID)
+}
+
+// GetTestsForAssignment mocks base method.
+func (m *MockDatabase) GetTestsForAssignment(assignmentID string) ([]*models.Test, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestsForAssignment", assignmentID)
+	ret0, _ := ret[0].([]*models.Test)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestsForAssignment indicates an expected call of GetTestsForAssignment.
+func (mr *MockDatabaseMockRecorder) GetTestsForAssignment(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestsForAssignment", reflect.TypeOf((*MockDatabase)(nil).GetTestsForAssignment), assignmentID)
+}
+
+// GetUnitByID mocks base method.
+func (m *MockDatabase) GetUnitByID(id string, fetchClasses bool) (*models.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnitByID", id, fetchClasses)
+	ret0, _ := ret[0].(*models.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnitByID indicates an expected call of GetUnitByID.
+func (mr *MockDatabaseMockRecorder) GetUnitByID(id, fetchClasses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitByID", reflect.TypeOf((*MockDatabase)(nil).GetUnitByID), id, fetchClasses)
+}
+
+// GetUnitByName mocks base method.
+func (m *MockDatabase) GetUnitByName(name string) (*models.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnitByName", name)
+	ret0, _ := ret[0].(*models.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnitByName indicates an expected call of GetUnitByName.
+func (mr *MockDatabaseMockRecorder) GetUnitByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitByName", reflect.TypeOf((*MockDatabase)(nil).GetUnitByName), name)
+}
+
+// GetUnitsForTerm mocks base method.
+func (m *MockDatabase) GetUnitsForTerm(termID uint, from int) ([]*models.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnitsForTerm", termID, from)
+	ret0, _ := ret[0].([]*models.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnitsForTerm indicates an expected call of GetUnitsForTerm.
+func (mr *MockDatabaseMockRecorder) GetUnitsForTerm(termID, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitsForTerm", reflect.TypeOf((*MockDatabase)(nil).GetUnitsForTerm), termID, from)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockDatabase) GetUserByEmail(email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockDatabaseMockRecorder) GetUserByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockDatabase)(nil).GetUserByEmail), email)
+}
+
+// GrantExtension mocks base method.
+func (m *MockDatabase) GrantExtension(extension models.Extension) (*models.Extension, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantExtension", extension)
+	ret0, _ := ret[0].(*models.Extension)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantExtension indicates an expected call of GrantExtension.
+func (mr *MockDatabaseMockRecorder) GrantExtension(extension interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantExtension", reflect.TypeOf((*MockDatabase)(nil).GrantExtension), extension)
+}
+
+// HasRubricMarks mocks base method.
+func (m *MockDatabase) HasRubricMarks(assignmentID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRubricMarks", assignmentID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasRubricMarks indicates an expected call of HasRubricMarks.
+func (mr *MockDatabaseMockRecorder) HasRubricMarks(assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRubricMarks", reflect.TypeOf((*MockDatabase)(nil).HasRubricMarks), assignmentID)
+}
+
+// LockUser mocks base method.
+func (m *MockDatabase) LockUser(userID uint, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUser", userID, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockDatabaseMockRecorder) LockUser(userID, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockDatabase)(nil).LockUser), userID, lockedUntil)
+}
+
+// OverrideResult mocks base method.
+func (m *MockDatabase) OverrideResult(resultID uint, score float64, reason, overriddenBy string) (*models.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OverrideResult", resultID, score, reason, overriddenBy)
+	ret0, _ := ret[0].(*models.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OverrideResult indicates an expected call of OverrideResult.
+func (mr *MockDatabaseMockRecorder) OverrideResult(resultID, score, reason, overriddenBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverrideResult", reflect.TypeOf((*MockDatabase)(nil).OverrideResult), resultID, score, reason, overriddenBy)
+}
+
+// RecordLoginFailure mocks base method.
+func (m *MockDatabase) RecordLoginFailure(userID uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginFailure", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordLoginFailure indicates an expected call of RecordLoginFailure.
+func (mr *MockDatabaseMockRecorder) RecordLoginFailure(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailure", reflect.TypeOf((*MockDatabase)(nil).RecordLoginFailure), userID)
+}
+
+// ResetDB mocks base method.
+func (m *MockDatabase) ResetDB() (db.Database, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetDB")
+	ret0, _ := ret[0].(db.Database)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetDB indicates an expected call of ResetDB.
+func (mr *MockDatabaseMockRecorder) ResetDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDB", reflect.TypeOf((*MockDatabase)(nil).ResetDB))
+}
+
+// ResolveModeration mocks base method.
+func (m *MockDatabase) ResolveModeration(submissionID uint, agreedMark float64, resolvedBy, note string) (*models.ModerationSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveModeration", submissionID, agreedMark, resolvedBy, note)
+	ret0, _ := ret[0].(*models.ModerationSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveModeration indicates an expected call of ResolveModeration.
+func (mr *MockDatabaseMockRecorder) ResolveModeration(submissionID, agreedMark, resolvedBy, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveModeration", reflect.TypeOf((*MockDatabase)(nil).ResolveModeration), submissionID, agreedMark, resolvedBy, note)
+}
+
+// RevokeExtension mocks base method.
+func (m *MockDatabase) RevokeExtension(studentID, assignmentID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeExtension", studentID, assignmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeExtension indicates an expected call of RevokeExtension.
+func (mr *MockDatabaseMockRecorder) RevokeExtension(studentID, assignmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeExtension", reflect.TypeOf((*MockDatabase)(nil).RevokeExtension), studentID, assignmentID)
+}
+
+// SaveCodeMetrics mocks base method.
+func (m *MockDatabase) SaveCodeMetrics(submissionVersionID uint, metrics []models.CodeMetrics) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCodeMetrics", submissionVersionID, metrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCodeMetrics indicates an expected call of SaveCodeMetrics.
+func (mr *MockDatabaseMockRecorder) SaveCodeMetrics(submissionVersionID, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCodeMetrics", reflect.TypeOf((*MockDatabase)(nil).SaveCodeMetrics), submissionVersionID, metrics)
+}
+
+// SaveLintFindings mocks base method.
+func (m *MockDatabase) SaveLintFindings(submissionVersionID uint, findings []models.LintFinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLintFindings", submissionVersionID, findings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLintFindings indicates an expected call of SaveLintFindings.
+func (mr *MockDatabaseMockRecorder) SaveLintFindings(submissionVersionID, findings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLintFindings", reflect.TypeOf((*MockDatabase)(nil).SaveLintFindings), submissionVersionID, findings)
+}
+
+// SaveRubricMarks mocks base method.
+func (m *MockDatabase) SaveRubricMarks(marks []models.RubricMark) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRubricMarks", marks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRubricMarks indicates an expected call of SaveRubricMarks.
+func (mr *MockDatabaseMockRecorder) SaveRubricMarks(marks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRubricMarks", reflect.TypeOf((*MockDatabase)(nil).SaveRubricMarks), marks)
+}
+
+// SetGroupMembers mocks base method.
+func (m *MockDatabase) SetGroupMembers(groupID, classID uint, studentIDs []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGroupMembers", groupID, classID, studentIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGroupMembers indicates an expected call of SetGroupMembers.
+func (mr *MockDatabaseMockRecorder) SetGroupMembers(groupID, classID, studentIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGroupMembers", reflect.TypeOf((*MockDatabase)(nil).SetGroupMembers), groupID, classID, studentIDs)
+}
+
+// SetLintRules mocks base method.
+func (m *MockDatabase) SetLintRules(assignmentID uint, rules []models.LintRule) ([]*models.LintRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLintRules", assignmentID, rules)
+	ret0, _ := ret[0].([]*models.LintRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLintRules indicates an expected call of SetLintRules.
+func (mr *MockDatabaseMockRecorder) SetLintRules(assignmentID, rules interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLintRules", reflect.TypeOf((*MockDatabase)(nil).SetLintRules), assignmentID, rules)
+}
+
+// SetModerationSample mocks base method.
+func (m *MockDatabase) SetModerationSample(assignmentID uint, threshold float64, samples []models.ModerationSample) ([]*models.ModerationSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetModerationSample", assignmentID, threshold, samples)
+	ret0, _ := ret[0].([]*models.ModerationSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetModerationSample indicates an expected call of SetModerationSample.
+func (mr *MockDatabaseMockRecorder) SetModerationSample(assignmentID, threshold, samples interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetModerationSample", reflect.TypeOf((*MockDatabase)(nil).SetModerationSample), assignmentID, threshold, samples)
+}
+
+// SetRubric mocks base method.
+func (m *MockDatabase) SetRubric(assignmentID uint, criteria []models.RubricCriterion) ([]*models.RubricCriterion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRubric", assignmentID, criteria)
+	ret0, _ := ret[0].([]*models.RubricCriterion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRubric indicates an expected call of SetRubric.
+func (mr *MockDatabaseMockRecorder) SetRubric(assignmentID, criteria interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRubric", reflect.TypeOf((*MockDatabase)(nil).SetRubric), assignmentID, criteria)
+}
+
+// SetStarterFiles mocks base method.
+func (m *MockDatabase) SetStarterFiles(assignmentID uint, files []models.StarterFile) ([]*models.StarterFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStarterFiles", assignmentID, files)
+	ret0, _ := ret[0].([]*models.StarterFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStarterFiles indicates an expected call of SetStarterFiles.
+func (mr *MockDatabaseMockRecorder) SetStarterFiles(assignmentID, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStarterFiles", reflect.TypeOf((*MockDatabase)(nil).SetStarterFiles), assignmentID, files)
+}
+
+// SetStudentInvite mocks base method.
+func (m *MockDatabase) SetStudentInvite(studentID uint, codeHash string, expiresAt time.Time) (*models.StudentInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStudentInvite", studentID, codeHash, expiresAt)
+	ret0, _ := ret[0].(*models.StudentInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStudentInvite indicates an expected call of SetStudentInvite.
+func (mr *MockDatabaseMockRecorder) SetStudentInvite(studentID, codeHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStudentInvite", reflect.TypeOf((*MockDatabase)(nil).SetStudentInvite), studentID, codeHash, expiresAt)
+}
+
+// SetSubmissionGroup mocks base method.
+func (m *MockDatabase) SetSubmissionGroup(submissionID uint, groupID *uint) (*models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubmissionGroup", submissionID, groupID)
+	ret0, _ := ret[0].(*models.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSubmissionGroup indicates an expected call of SetSubmissionGroup.
+func (mr *MockDatabaseMockRecorder) SetSubmissionGroup(submissionID, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubmissionGroup", reflect.TypeOf((*MockDatabase)(nil).SetSubmissionGroup), submissionID, groupID)
+}
+
+// SubmitVersion mocks base method.
+func (m *MockDatabase) SubmitVersion(assignmentID uint, studentID string, studentRecordID *uint, files []models.SubmissionFile) (*models.Submission, *models.SubmissionVersion, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitVersion", assignmentID, studentID, studentRecordID, files)
+	ret0, _ := ret[0].(*models.Submission)
+	ret1, _ := ret[1].(*models.SubmissionVersion)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// SubmitVersion indicates an expected call of SubmitVersion.
+func (mr *MockDatabaseMockRecorder) SubmitVersion(assignmentID, studentID, studentRecordID, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVersion", reflect.TypeOf((*MockDatabase)(nil).SubmitVersion), assignmentID, studentID, studentRecordID, files)
+}
+
+// Transaction mocks base method.
+func (m *MockDatabase) Transaction(fn func(db.Database) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockDatabaseMockRecorder) Transaction(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockDatabase)(nil).Transaction), fn)
+}
+
+// UnenrolStudent mocks base method.
+func (m *MockDatabase) UnenrolStudent(studentID, classID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnenrolStudent", studentID, classID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnenrolStudent indicates an expected call of UnenrolStudent.
+func (mr *MockDatabaseMockRecorder) UnenrolStudent(studentID, classID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnenrolStudent", reflect.TypeOf((*MockDatabase)(nil).UnenrolStudent), studentID, classID)
+}
+
+// UpdateAttemptPolicy mocks base method.
+func (m *MockDatabase) UpdateAttemptPolicy(assignmentID uint, policy models.AttemptPolicy) (*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAttemptPolicy", assignmentID, policy)
+	ret0, _ := ret[0].(*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAttemptPolicy indicates an expected call of UpdateAttemptPolicy.
+func (mr *MockDatabaseMockRecorder) UpdateAttemptPolicy(assignmentID, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttemptPolicy", reflect.TypeOf((*MockDatabase)(nil).UpdateAttemptPolicy), assignmentID, policy)
+}
+
+// UpdateCodeComment mocks base method.
+func (m *MockDatabase) UpdateCodeComment(id uint, body string, resolved bool) (*models.CodeComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCodeComment", id, body, resolved)
+	ret0, _ := ret[0].(*models.CodeComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCodeComment indicates an expected call of UpdateCodeComment.
+func (mr *MockDatabaseMockRecorder) UpdateCodeComment(id, body, resolved interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCodeComment", reflect.TypeOf((*MockDatabase)(nil).UpdateCodeComment), id, body, resolved)
+}
+
+// UpdateFeedbackRelease mocks base method.
+func (m *MockDatabase) UpdateFeedbackRelease(assignmentID uint, release models.FeedbackRelease) (*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFeedbackRelease", assignmentID, release)
+	ret0, _ := ret[0].(*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFeedbackRelease indicates an expected call of UpdateFeedbackRelease.
+func (mr *MockDatabaseMockRecorder) UpdateFeedbackRelease(assignmentID, release interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFeedbackRelease", reflect.TypeOf((*MockDatabase)(nil).UpdateFeedbackRelease), assignmentID, release)
+}
+
+// UpdateGroupWork mocks base method.
+func (m *MockDatabase) UpdateGroupWork(assignmentID uint, groupWork bool) (*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupWork", assignmentID, groupWork)
+	ret0, _ := ret[0].(*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroupWork indicates an expected call of UpdateGroupWork.
+func (mr *MockDatabaseMockRecorder) UpdateGroupWork(assignmentID, groupWork interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupWork", reflect.TypeOf((*MockDatabase)(nil).UpdateGroupWork), assignmentID, groupWork)
+}
+
+// UpdateLatePolicy mocks base method.
+func (m *MockDatabase) UpdateLatePolicy(assignmentID uint, policy models.LatePolicy) (*models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLatePolicy", assignmentID, policy)
+	ret0, _ := ret[0].(*models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLatePolicy indicates an expected call of UpdateLatePolicy.
+func (mr *MockDatabaseMockRecorder) UpdateLatePolicy(assignmentID, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLatePolicy", reflect.TypeOf((*MockDatabase)(nil).UpdateLatePolicy), assignmentID, policy)
+}
+
+// UpdateLoginFailures mocks base method.
+func (m *MockDatabase) UpdateLoginFailures(userID uint, failedAttempts int, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoginFailures", userID, failedAttempts, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLoginFailures indicates an expected call of UpdateLoginFailures.
+func (mr *MockDatabaseMockRecorder) UpdateLoginFailures(userID, failedAttempts, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoginFailures", reflect.TypeOf((*MockDatabase)(nil).UpdateLoginFailures), userID, failedAttempts, lockedUntil)
+}
+
+// UpdateMarkingStatus mocks base method.
+func (m *MockDatabase) UpdateMarkingStatus(submissionID uint, status models.MarkingStatus) (*models.MarkingAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMarkingStatus", submissionID, status)
+	ret0, _ := ret[0].(*models.MarkingAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMarkingStatus indicates an expected call of UpdateMarkingStatus.
+func (mr *MockDatabaseMockRecorder) UpdateMarkingStatus(submissionID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMarkingStatus", reflect.TypeOf((*MockDatabase)(nil).UpdateMarkingStatus), submissionID, status)
+}
+
+// UpdateStudent mocks base method.
+func (m *MockDatabase) UpdateStudent(id uint, name, email string) (*models.Student, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStudent", id, name, email)
+	ret0, _ := ret[0].(*models.Student)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStudent indicates an expected call of UpdateStudent.
+func (mr *MockDatabaseMockRecorder) UpdateStudent(id, name, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStudent", reflect.TypeOf((*MockDatabase)(nil).UpdateStudent), id, name, email)
+}
+
+// UpdateTestScoring mocks base method.
+func (m *MockDatabase) UpdateTestScoring(testID uint, maxPoints, weight float64) (*models.Test, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTestScoring", testID, maxPoints, weight)
+	ret0, _ := ret[0].(*models.Test)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTestScoring indicates an expected call of UpdateTestScoring.
+func (mr *MockDatabaseMockRecorder) UpdateTestScoring(testID, maxPoints, weight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTestScoring", reflect.TypeOf((*MockDatabase)(nil).UpdateTestScoring), testID, maxPoints, weight)
 }
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+type auditCtxKey struct{}
+
+// auditEntry is attached to the context of every mutation so resolvers can describe
+// the entity they acted on when the mutation's result doesn't identify it.
+type auditEntry struct {
+	entityType string
+	entityID   string
+	before     interface{}
+}
+
+// recordAuditEntity sets the entity a mutation acted on.
+func recordAuditEntity(ctx context.Context, entityType, entityID string) {
+	entry, ok := ctx.Value(auditCtxKey{}).(*auditEntry)
+	if !ok {
+		return
+	}
+
+	entry.entityType = entityType
+	entry.entityID = entityID
+}
+
+// recordAuditBefore sets the entity a mutation acted on along with its state before
+// the mutation changed it.
+func recordAuditBefore(ctx context.Context, entityType, entityID string, before interface{}) {
+	entry, ok := ctx.Value(auditCtxKey{}).(*auditEntry)
+	if !ok {
+		return
+	}
+
+	entry.entityType = entityType
+	entry.entityID = entityID
+	entry.before = before
+}
+
+// AuditMiddleware records an AuditEvent for every top level mutation. Failed mutations
+// are recorded with their error so attempts such as failed logins and denied changes
+// can be reviewed.
+func (r *Resolver) AuditMiddleware(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || fc.Object != "Mutation" {
+		return next(ctx)
+	}
+
+	entry := &auditEntry{}
+	res, err := next(context.WithValue(ctx, auditCtxKey{}, entry))
+
+	user := r.ExtractUser(ctx)
+	event := models.AuditEvent{
+		Actor:      "anonymous",
+		Action:     fc.Field.Name,
+		EntityType: entry.entityType,
+		EntityID:   entry.entityID,
+		RequestIP:  r.ExtractIP(ctx),
+	}
+
+	// The email argument of an unauthenticated mutation such as login is whatever the
+	// caller typed, so it's kept apart from the actor rather than attributed to them.
+	if user != nil {
+		event.Actor = user.Email
+	} else if email, ok := fc.Args["email"].(string); ok {
+		event.AttemptedEmail = email
+	}
+
+	if entry.before != nil {
+		event.Before = marshalAuditState(entry.before)
+	}
+
+	// Only record object results, scalar results are either uninteresting booleans or
+	// tokens that must not be stored. A failed mutation has no result.
+	if err != nil {
+		event.Error = err.Error()
+	} else if entityType, entityID, ok := auditObject(res); ok {
+		if event.EntityType == "" {
+			event.EntityType, event.EntityID = entityType, entityID
+		}
+		event.After = marshalAuditState(res)
+	}
+
+	if _, auditErr := r.DB.CreateAuditEvent(event); auditErr != nil {
+		// The mutation has already happened, so don't report it as failed.
+		log.Printf("error recording audit event for %s: %v", event.Action, auditErr)
+	}
+
+	return res, err
+}
+
+// auditObject returns the GraphQL type name and ID of a mutation result, if it is an
+// object with an ID.
+func auditObject(res interface{}) (string, string, bool) {
+	v := reflect.ValueOf(res)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return "", "", false
+	}
+
+	id := v.Elem().FieldByName("ID")
+	if !id.IsValid() || id.Kind() != reflect.String {
+		return "", "", false
+	}
+
+	return v.Elem().Type().Name(), id.String(), true
+}
+
+func marshalAuditState(state interface{}) string {
+	b, err := json.Marshal(state)
+	if err != nil {
+		log.Printf("error encoding audit state: %v", err)
+		return ""
+	}
+
+	return string(b)
+}
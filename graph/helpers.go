@@ -1,10 +1,32 @@
 package graph
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/COMP4050/square-team-5/api/graph/model"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/diff"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/export"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/grading"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/metrics"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/similarity"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/submissions"
+)
+
+var (
+	// Login failures all share these errors so that callers can't tell whether an
+	// email is registered.
+	errInvalidCredentials   = errors.New("incorrect email or password")
+	errTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+
+	errNotAuthenticated = errors.New("user not authenticated")
+	errNotAuthorised    = errors.New("only staff can do this")
+	errInvalidInvite    = errors.New("invalid student number or invite code")
 )
 
 func getOffset(from *int) int {
@@ -19,6 +41,22 @@ func getOffset(from *int) int {
 	return *from
 }
 
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
 func getAssignment(dbClient db.Database, id string) (*models.Assignment, error) {
 	assignment, err := dbClient.GetAssignment(id)
 	if err != nil {
@@ -55,6 +93,30 @@ func getUnit(dbClient db.Database, id string) (*models.Unit, error) {
 	return unit, nil
 }
 
+func getTerm(dbClient db.Database, id string) (*models.Term, error) {
+	term, err := dbClient.GetTerm(id)
+	if err != nil {
+		return nil, err
+	}
+	if term == nil {
+		return nil, fmt.Errorf("term not found")
+	}
+
+	return term, nil
+}
+
+func getOffering(dbClient db.Database, id string) (*models.Offering, error) {
+	offering, err := dbClient.GetOffering(id)
+	if err != nil {
+		return nil, err
+	}
+	if offering == nil {
+		return nil, fmt.Errorf("offering not found")
+	}
+
+	return offering, nil
+}
+
 func getSubmission(dbClient db.Database, id string) (*models.Submission, error) {
 	submission, err := dbClient.GetSubmission(id)
 	if err != nil {
@@ -67,6 +129,304 @@ func getSubmission(dbClient db.Database, id string) (*models.Submission, error)
 	return submission, nil
 }
 
+func getSubmissionVersion(dbClient db.Database, id string) (*models.SubmissionVersion, error) {
+	version, err := dbClient.GetSubmissionVersion(id)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, fmt.Errorf("submission version not found")
+	}
+
+	return version, nil
+}
+
+// getCountedVersion returns the version of a submission that counts under its
+// assignment's attempt policy, along with that version's result if it has one.
+func getCountedVersion(dbClient db.Database, submission *models.Submission) (*models.SubmissionVersion, *models.Result, error) {
+	assignment, err := getAssignment(dbClient, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return grading.LoadCountedVersion(dbClient, assignment, submission)
+}
+
+// getLateness assesses a submission against its assignment's late policy and the
+// student's extension, using the time the version was submitted if given.
+func getLateness(dbClient db.Database, submission *models.Submission, version *models.SubmissionVersion) (grading.Lateness, error) {
+	assignment, err := getAssignment(dbClient, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return grading.Lateness{}, err
+	}
+
+	return grading.LoadLateness(dbClient, assignment, submission, version)
+}
+
+// getResultLateness assesses the submission a result was recorded for.
+func getResultLateness(dbClient db.Database, result *model.Result) (grading.Lateness, error) {
+	submission, err := getSubmission(dbClient, result.SubmissionID)
+	if err != nil {
+		return grading.Lateness{}, err
+	}
+
+	var version *models.SubmissionVersion
+	if result.SubmissionVersionID != nil {
+		version, err = getSubmissionVersion(dbClient, *result.SubmissionVersionID)
+		if err != nil {
+			return grading.Lateness{}, err
+		}
+	}
+
+	return getLateness(dbClient, submission, version)
+}
+
+// requireStaff returns the user making the request, failing unless they are signed in
+// as an admin or tutor.
+func (r *Resolver) requireStaff(ctx context.Context) (*models.User, error) {
+	user := r.ExtractUser(ctx)
+	if user == nil {
+		return nil, errNotAuthenticated
+	}
+	if !user.IsStaff() {
+		return nil, errNotAuthorised
+	}
+
+	return user, nil
+}
+
+// requireStaffOrStudent returns the user making the request, failing unless they are
+// an admin or tutor, or the student with the given ID.
+func (r *Resolver) requireStaffOrStudent(ctx context.Context, studentID uint) (*models.User, error) {
+	user := r.ExtractUser(ctx)
+	if user == nil {
+		return nil, errNotAuthenticated
+	}
+	if !user.IsStaff() && (user.StudentID == nil || *user.StudentID != studentID) {
+		return nil, errNotAuthorised
+	}
+
+	return user, nil
+}
+
+// isStaff reports whether the user making the request is an admin or tutor.
+func (r *Resolver) isStaff(ctx context.Context) bool {
+	user := r.ExtractUser(ctx)
+
+	return user != nil && user.IsStaff()
+}
+
+// feedbackVisible reports whether the user making the request can see the marks and
+// feedback on a submission. Admins always can. Tutors can unless they're second
+// marking the submission and haven't entered their mark yet, so they aren't swayed by
+// the first marker. Anyone else can once the assignment's feedback is released.
+func (r *Resolver) feedbackVisible(ctx context.Context, submission *models.Submission) (bool, error) {
+	user := r.ExtractUser(ctx)
+	if user != nil && user.Role == models.UserRoleAdmin {
+		return true, nil
+	}
+
+	if user != nil && user.IsStaff() {
+		sample, err := r.DB.GetModerationSample(submission.ID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return false, fmt.Errorf("error getting moderation sample: %w", err)
+		}
+		return sample == nil || !sample.BlindTo(user), nil
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return false, err
+	}
+
+	return assignment.Feedback.Released(time.Now()), nil
+}
+
+// submissionFeedbackVisible is feedbackVisible for a submission given by ID, which is
+// only looked up for users who aren't admins.
+func (r *Resolver) submissionFeedbackVisible(ctx context.Context, submissionID uint) (bool, error) {
+	if user := r.ExtractUser(ctx); user != nil && user.Role == models.UserRoleAdmin {
+		return true, nil
+	}
+
+	submission, err := getSubmission(r.DB, fmt.Sprintf("%d", submissionID))
+	if err != nil {
+		return false, err
+	}
+
+	return r.feedbackVisible(ctx, submission)
+}
+
+func getExtension(dbClient db.Database, id string) (*models.Extension, error) {
+	extension, err := dbClient.GetExtension(id)
+	if err != nil {
+		return nil, err
+	}
+	if extension == nil {
+		return nil, fmt.Errorf("extension not found")
+	}
+
+	return extension, nil
+}
+
+func getCodeComment(dbClient db.Database, id string) (*models.CodeComment, error) {
+	comment, err := dbClient.GetCodeComment(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil {
+		return nil, fmt.Errorf("comment not found")
+	}
+
+	return comment, nil
+}
+
+// getMarker checks that submissions can be allocated to the user with the email, i.e.
+// that they have a staff account.
+func getMarker(dbClient db.Database, email string) (*models.User, error) {
+	user, err := dbClient.GetUserByEmail(email)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting marker: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("marker %s not found", email)
+	}
+	if !user.IsStaff() {
+		return nil, fmt.Errorf("%s can't mark submissions", email)
+	}
+
+	return user, nil
+}
+
+// getMarkers checks each of the emails with getMarker, returning them without
+// duplicates.
+func getMarkers(dbClient db.Database, emails []string) ([]string, error) {
+	markers := []string{}
+	seen := map[string]bool{}
+	for _, email := range emails {
+		if seen[email] {
+			continue
+		}
+		if _, err := getMarker(dbClient, email); err != nil {
+			return nil, err
+		}
+
+		seen[email] = true
+		markers = append(markers, email)
+	}
+
+	return markers, nil
+}
+
+func getGroup(dbClient db.Database, id string) (*models.Group, error) {
+	group, err := dbClient.GetGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group not found")
+	}
+
+	return group, nil
+}
+
+// getGroupMembers checks that the students can be members of the group, i.e. that
+// they're enrolled in its class and not in another of the class's groups.
+func getGroupMembers(dbClient db.Database, group *models.Group, studentIDs []string) ([]uint, error) {
+	enrolled, err := dbClient.GetStudentsForClass(group.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting students: %w", err)
+	}
+
+	students := map[string]*models.Student{}
+	for _, student := range enrolled {
+		students[fmt.Sprintf("%d", student.ID)] = student
+	}
+
+	members := []uint{}
+	seen := map[uint]bool{}
+	for _, studentID := range studentIDs {
+		student, ok := students[studentID]
+		if !ok {
+			return nil, fmt.Errorf("student %s is not enrolled in the class", studentID)
+		}
+		if seen[student.ID] {
+			continue
+		}
+
+		other, err := dbClient.GetGroupForStudent(student.ID, group.ClassID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return nil, fmt.Errorf("error getting group: %w", err)
+		}
+		if other != nil && other.ID != group.ID {
+			return nil, fmt.Errorf("%s is already in group %s", student.StudentNumber, other.Name)
+		}
+
+		seen[student.ID] = true
+		members = append(members, student.ID)
+	}
+
+	return members, nil
+}
+
+// analyseImport analyses the versions created by a submission import.
+func analyseImport(dbClient db.Database, assignmentID uint, report *submissions.Report) error {
+	settings, err := metrics.LoadSettings(dbClient, assignmentID)
+	if err != nil {
+		return err
+	}
+
+	for _, folder := range report.Folders {
+		if folder.Version == nil {
+			continue
+		}
+
+		err = metrics.AnalyseVersion(dbClient, folder.Version, settings)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkEnrolled checks that the student is enrolled in the class.
+func checkEnrolled(dbClient db.Database, classID uint, student *models.Student) error {
+	enrolled, err := dbClient.GetStudentsForClass(classID)
+	if err != nil {
+		return fmt.Errorf("error getting students: %w", err)
+	}
+
+	for _, other := range enrolled {
+		if other.ID == student.ID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("student %s is not enrolled in the class", student.StudentNumber)
+}
+
+// checkGroupUnsubmitted checks that the group hasn't made a submission for any of its
+// class's assignments.
+func checkGroupUnsubmitted(dbClient db.Database, group *models.Group) error {
+	assignments, err := dbClient.GetAssignmentsForClass(group.ClassID)
+	if err != nil {
+		return fmt.Errorf("error getting assignments: %w", err)
+	}
+
+	for _, assignment := range assignments {
+		submission, err := dbClient.GetSubmissionForGroup(assignment.ID, group.ID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("error getting submission: %w", err)
+		}
+		if submission != nil {
+			return fmt.Errorf("group %s has submitted %s", group.Name, assignment.Name)
+		}
+	}
+
+	return nil
+}
+
 func getTest(dbClient db.Database, id string) (*models.Test, error) {
 	test, err := dbClient.GetTest(id)
 	if err != nil {
@@ -78,3 +438,424 @@ func getTest(dbClient db.Database, id string) (*models.Test, error) {
 
 	return test, nil
 }
+
+func getStudent(dbClient db.Database, id string) (*models.Student, error) {
+	student, err := dbClient.GetStudent(id)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, fmt.Errorf("student not found")
+	}
+
+	return student, nil
+}
+
+func toGQLStudent(student *models.Student) *model.Student {
+	return &model.Student{
+		ID:            fmt.Sprintf("%d", student.ID),
+		StudentNumber: student.StudentNumber,
+		Name:          student.Name,
+		Email:         student.Email,
+	}
+}
+
+func toGQLTest(test *models.Test) *model.Test {
+	return &model.Test{
+		ID:        fmt.Sprintf("%d", test.ID),
+		Name:      test.Name,
+		MaxPoints: test.MaxPoints,
+		Weight:    test.Weight,
+	}
+}
+
+func toGQLScore(score grading.Score) *model.ScoreBreakdown {
+	gqlScore := &model.ScoreBreakdown{
+		Total:      score.Total,
+		MaxScore:   score.Max,
+		Percentage: score.Percentage(),
+		Tests:      []*model.TestScore{},
+	}
+	for _, testScore := range score.Tests {
+		gqlScore.Tests = append(gqlScore.Tests, &model.TestScore{
+			Test:              toGQLTest(testScore.Test),
+			Points:            testScore.Points,
+			WeightedPoints:    testScore.Weighted,
+			WeightedMaxPoints: testScore.WeightedMax,
+		})
+	}
+
+	return gqlScore
+}
+
+func toGQLRubricCriterion(criterion *models.RubricCriterion) *model.RubricCriterion {
+	gqlCriterion := &model.RubricCriterion{
+		ID:          fmt.Sprintf("%d", criterion.ID),
+		Name:        criterion.Name,
+		Description: criterion.Description,
+		MaxPoints:   criterion.MaxPoints(),
+		Levels:      []*model.RubricLevel{},
+	}
+	for _, level := range criterion.Levels {
+		gqlCriterion.Levels = append(gqlCriterion.Levels, &model.RubricLevel{
+			ID:          fmt.Sprintf("%d", level.ID),
+			Name:        level.Name,
+			Description: level.Description,
+			Points:      level.Points,
+		})
+	}
+
+	return gqlCriterion
+}
+
+func toGQLSubmissionVersion(version *models.SubmissionVersion) *model.SubmissionVersion {
+	return &model.SubmissionVersion{
+		ID:          fmt.Sprintf("%d", version.ID),
+		Number:      version.Number,
+		SubmittedAt: int(version.CreatedAt.Unix()),
+	}
+}
+
+func toGQLResult(result *models.Result) *model.Result {
+	gqlResult := &model.Result{
+		ID:            fmt.Sprintf("%d", result.ID),
+		Score:         result.Score,
+		OriginalScore: result.Score,
+		Overridden:    result.OriginalScore != nil,
+		Date:          result.CreatedAt.Format("02/01/2006"),
+		SubmissionID:  fmt.Sprintf("%d", result.SubmissionID),
+	}
+	if result.OriginalScore != nil {
+		gqlResult.OriginalScore = *result.OriginalScore
+	}
+	if result.SubmissionVersionID != nil {
+		versionID := fmt.Sprintf("%d", *result.SubmissionVersionID)
+		gqlResult.SubmissionVersionID = &versionID
+	}
+
+	return gqlResult
+}
+
+func toGQLLateness(lateness grading.Lateness) *model.Lateness {
+	return &model.Lateness{
+		DueDate:    int(lateness.DueDate.Unix()),
+		Extended:   lateness.Extended,
+		Late:       lateness.Late,
+		LateBy:     int(lateness.Duration.Seconds()),
+		DaysLate:   lateness.DaysLate,
+		PastCutoff: lateness.PastCutoff,
+		Penalty:    lateness.Penalty,
+	}
+}
+
+func toGQLExtension(extension *models.Extension) *model.Extension {
+	return &model.Extension{
+		ID:         fmt.Sprintf("%d", extension.ID),
+		DueDate:    int(extension.DueDate.Unix()),
+		Reason:     extension.Reason,
+		ApprovedBy: extension.ApprovedBy,
+		GrantedAt:  int(extension.UpdatedAt.Unix()),
+	}
+}
+
+func toGQLLatePolicy(policy models.LatePolicy) *model.LatePolicy {
+	gqlPolicy := &model.LatePolicy{
+		GracePeriod:   int(policy.GracePeriod.Seconds()),
+		PenaltyPerDay: policy.PenaltyPerDay,
+		MaxPenalty:    policy.MaxPenalty,
+	}
+	if policy.Cutoff != nil {
+		cutoff := int(policy.Cutoff.Unix())
+		gqlPolicy.Cutoff = &cutoff
+	}
+
+	return gqlPolicy
+}
+
+func toGQLFeedbackRelease(release models.FeedbackRelease) *model.FeedbackRelease {
+	gqlRelease := &model.FeedbackRelease{
+		Status:   model.FeedbackStatus(release.Status),
+		Released: release.Released(time.Now()),
+	}
+	if release.ReleaseAt != nil {
+		releaseAt := int(release.ReleaseAt.Unix())
+		gqlRelease.ReleaseAt = &releaseAt
+	}
+
+	return gqlRelease
+}
+
+func toGQLSubmissionFiles(files []*models.SubmissionFile) []*model.SubmissionFile {
+	gqlFiles := []*model.SubmissionFile{}
+	for _, file := range files {
+		gqlFiles = append(gqlFiles, &model.SubmissionFile{
+			ID:      fmt.Sprintf("%d", file.ID),
+			Path:    file.Path,
+			Size:    len(file.Content),
+			Content: string(file.Content),
+		})
+	}
+
+	return gqlFiles
+}
+
+func toGQLStarterFiles(files []*models.StarterFile) []*model.StarterFile {
+	gqlFiles := []*model.StarterFile{}
+	for _, file := range files {
+		gqlFiles = append(gqlFiles, &model.StarterFile{
+			ID:      fmt.Sprintf("%d", file.ID),
+			Path:    file.Path,
+			Size:    len(file.Content),
+			Content: string(file.Content),
+		})
+	}
+
+	return gqlFiles
+}
+
+func toGQLFileDiffs(fileDiffs []diff.FileDiff) []*model.FileDiff {
+	gqlDiffs := []*model.FileDiff{}
+	for _, fileDiff := range fileDiffs {
+		gqlDiffs = append(gqlDiffs, &model.FileDiff{
+			Path:      fileDiff.Path,
+			Status:    model.FileDiffStatus(fileDiff.Status),
+			Additions: fileDiff.Additions,
+			Deletions: fileDiff.Deletions,
+			Patch:     fileDiff.Patch,
+		})
+	}
+
+	return gqlDiffs
+}
+
+func toGQLGradeExport(gradebook *export.Gradebook, format model.GradeExportFormat) (*model.GradeExport, error) {
+	var buf bytes.Buffer
+	err := export.Write(&buf, gradebook, export.Format(format))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GradeExport{
+		Filename:    export.Filename(gradebook, export.Format(format)),
+		ContentType: export.ContentType,
+		Content:     buf.String(),
+	}, nil
+}
+
+func toGQLAssignmentStatistics(stats *models.AssignmentStatistics) *model.AssignmentStatistics {
+	gqlStats := &model.AssignmentStatistics{
+		Submissions:       stats.Submissions,
+		Missing:           stats.Missing,
+		Graded:            stats.Graded,
+		Mean:              stats.Mean,
+		Median:            stats.Median,
+		StandardDeviation: stats.StdDev,
+		Min:               stats.Min,
+		Max:               stats.Max,
+		Histogram:         []*model.HistogramBucket{},
+		Tests:             []*model.TestStatistics{},
+	}
+
+	for _, bucket := range stats.Histogram {
+		gqlStats.Histogram = append(gqlStats.Histogram, &model.HistogramBucket{
+			Lower: bucket.Lower,
+			Upper: bucket.Upper,
+			Count: bucket.Count,
+		})
+	}
+
+	for _, test := range stats.Tests {
+		gqlStats.Tests = append(gqlStats.Tests, &model.TestStatistics{
+			Test:       toGQLTest(test.Test),
+			Attempts:   test.Attempts,
+			Passed:     test.Passed,
+			PassRate:   test.PassRate(),
+			MeanPoints: test.MeanPoints,
+		})
+	}
+
+	return gqlStats
+}
+
+// toGQLSimilarityPair converts a pair of similar submissions, looking up each
+// submission's student identifier in names.
+func toGQLSimilarityPair(pair similarity.Pair, names map[uint]string) *model.SimilarityPair {
+	gqlPair := &model.SimilarityPair{
+		SubmissionA: &model.Submission{ID: fmt.Sprintf("%d", pair.A), StudentID: names[pair.A]},
+		SubmissionB: &model.Submission{ID: fmt.Sprintf("%d", pair.B), StudentID: names[pair.B]},
+		Similarity:  pair.Similarity(),
+		SimilarityA: pair.SimilarityA,
+		SimilarityB: pair.SimilarityB,
+		Matches:     []*model.SimilarityMatch{},
+	}
+
+	for _, match := range pair.Matches {
+		gqlPair.Matches = append(gqlPair.Matches, &model.SimilarityMatch{
+			FileA:      match.A.Path,
+			StartLineA: match.A.StartLine,
+			EndLineA:   match.A.EndLine,
+			FileB:      match.B.Path,
+			StartLineB: match.B.StartLine,
+			EndLineB:   match.B.EndLine,
+		})
+	}
+
+	return gqlPair
+}
+
+func toGQLCodeMetrics(files []*models.CodeMetrics) *model.CodeMetrics {
+	summary := metrics.Summarise(files)
+	gqlMetrics := &model.CodeMetrics{
+		Lines:             summary.Lines,
+		CodeLines:         summary.CodeLines,
+		CommentLines:      summary.CommentLines,
+		Functions:         summary.Functions,
+		Classes:           summary.Classes,
+		MaxComplexity:     summary.MaxComplexity,
+		AverageComplexity: summary.AverageComplexity,
+		MagicNumbers:      summary.MagicNumbers,
+		NamingIssues:      summary.NamingIssues,
+		HasSetup:          summary.HasSetup,
+		HasDraw:           summary.HasDraw,
+		Files:             []*model.FileMetrics{},
+	}
+
+	for _, file := range files {
+		gqlFile := &model.FileMetrics{
+			Path:         file.Path,
+			Starter:      file.Starter,
+			Lines:        file.Lines,
+			CodeLines:    file.CodeLines,
+			CommentLines: file.CommentLines,
+			BlankLines:   file.BlankLines,
+			Functions:    []*model.FunctionMetrics{},
+			Classes:      append([]string{}, file.Classes...),
+			HasSetup:     file.HasSetup,
+			HasDraw:      file.HasDraw,
+			MagicNumbers: []*model.MagicNumber{},
+			NamingIssues: []*model.NamingIssue{},
+		}
+		for _, function := range file.Functions {
+			gqlFile.Functions = append(gqlFile.Functions, &model.FunctionMetrics{
+				Name:       function.Name,
+				Class:      optionalString(function.Class),
+				StartLine:  function.StartLine,
+				EndLine:    function.EndLine,
+				Complexity: function.Complexity,
+			})
+		}
+		for _, number := range file.MagicNumbers {
+			gqlFile.MagicNumbers = append(gqlFile.MagicNumbers, &model.MagicNumber{Line: number.Line, Value: number.Value})
+		}
+		for _, issue := range file.NamingIssues {
+			gqlFile.NamingIssues = append(gqlFile.NamingIssues, &model.NamingIssue{
+				Line:     issue.Line,
+				Name:     issue.Name,
+				Kind:     issue.Kind,
+				Expected: issue.Expected,
+			})
+		}
+		gqlMetrics.Files = append(gqlMetrics.Files, gqlFile)
+	}
+
+	return gqlMetrics
+}
+
+func toGQLLintRule(rule *models.LintRule) *model.LintRule {
+	gqlRule := &model.LintRule{
+		ID:        fmt.Sprintf("%d", rule.ID),
+		Kind:      model.LintRuleKind(rule.Kind),
+		Severity:  model.LintSeverity(rule.Severity),
+		Names:     append([]string{}, rule.Names...),
+		Message:   optionalString(rule.Message),
+		Deduction: rule.Deduction,
+	}
+	if rule.Kind == models.LintMaxFunctionLength || rule.Kind == models.LintMaxComplexity {
+		limit := rule.Limit
+		gqlRule.Limit = &limit
+	}
+	if rule.MaxDeduction > 0 {
+		maxDeduction := rule.MaxDeduction
+		gqlRule.MaxDeduction = &maxDeduction
+	}
+
+	return gqlRule
+}
+
+func toGQLCodeComment(comment *models.CodeComment, version *models.SubmissionVersion) *model.CodeComment {
+	return &model.CodeComment{
+		ID:        fmt.Sprintf("%d", comment.ID),
+		Version:   toGQLSubmissionVersion(version),
+		Path:      comment.Path,
+		StartLine: comment.StartLine,
+		EndLine:   comment.EndLine,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		Resolved:  comment.Resolved,
+		CreatedAt: int(comment.CreatedAt.Unix()),
+		UpdatedAt: int(comment.UpdatedAt.Unix()),
+	}
+}
+
+func toGQLMarkingAllocation(allocation *models.MarkingAllocation) *model.MarkingAllocation {
+	return &model.MarkingAllocation{
+		ID:         fmt.Sprintf("%d", allocation.ID),
+		Submission: &model.Submission{ID: fmt.Sprintf("%d", allocation.SubmissionID), StudentID: allocation.Submission.StudentID},
+		Marker:     allocation.Marker,
+		Status:     model.MarkingStatus(allocation.Status),
+	}
+}
+
+func toGQLModerationSample(sample *models.ModerationSample) *model.ModerationSample {
+	gqlSample := &model.ModerationSample{
+		ID:           fmt.Sprintf("%d", sample.ID),
+		Submission:   &model.Submission{ID: fmt.Sprintf("%d", sample.SubmissionID), StudentID: sample.Submission.StudentID},
+		SecondMarker: sample.SecondMarker,
+		Status:       model.ModerationStatus(sample.Status),
+		FirstMark:    sample.FirstMark,
+		SecondMark:   sample.SecondMark,
+		AgreedMark:   sample.AgreedMark,
+	}
+	if sample.Status == models.ModerationStatusResolved {
+		gqlSample.ResolvedBy = &sample.ResolvedBy
+		gqlSample.ResolutionNote = &sample.ResolutionNote
+	}
+
+	return gqlSample
+}
+
+func toGQLModeration(threshold float64, samples []*models.ModerationSample) *model.Moderation {
+	gqlModeration := &model.Moderation{Threshold: threshold, Samples: []*model.ModerationSample{}}
+	for _, sample := range samples {
+		gqlModeration.Samples = append(gqlModeration.Samples, toGQLModerationSample(sample))
+	}
+
+	return gqlModeration
+}
+
+func toGQLTerm(term *models.Term) *model.Term {
+	return &model.Term{
+		ID:        fmt.Sprintf("%d", term.ID),
+		Name:      term.Name,
+		StartDate: int(term.StartDate.Unix()),
+		EndDate:   int(term.EndDate.Unix()),
+	}
+}
+
+func toGQLOffering(offering *models.Offering) *model.Offering {
+	return &model.Offering{ID: fmt.Sprintf("%d", offering.ID)}
+}
+
+func toGQLGroup(group *models.Group) *model.Group {
+	return &model.Group{ID: fmt.Sprintf("%d", group.ID), Name: group.Name}
+}
+
+func toGQLMemberGrade(memberGrade grading.MemberGrade) *model.MemberGrade {
+	return &model.MemberGrade{
+		Student:    toGQLStudent(memberGrade.Student),
+		Adjustment: memberGrade.Grade.Adjustment,
+		Reason:     optionalString(memberGrade.Reason),
+		Total:      memberGrade.Grade.Total(),
+		Final:      memberGrade.Grade.Final(),
+	}
+}
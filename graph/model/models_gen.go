@@ -2,21 +2,330 @@
 
 package model
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type AllocateMarkers struct {
+	AssignmentID string             `json:"assignmentID"`
+	Strategy     AllocationStrategy `json:"strategy"`
+	Markers      []string           `json:"markers"`
+	ClassMarkers []*ClassMarker     `json:"classMarkers"`
+}
+
+type AllocationReport struct {
+	Allocations []*MarkingAllocation `json:"allocations"`
+	Unallocated []*Submission        `json:"unallocated"`
+}
+
 type Assignment struct {
-	ID          string        `json:"id"`
-	Class       *Class        `json:"class"`
-	Unit        *Unit         `json:"unit"`
-	Name        string        `json:"name"`
-	DueDate     int           `json:"dueDate"`
-	Tests       []*Test       `json:"tests"`
-	Submissions []*Submission `json:"submissions"`
+	ID              string                `json:"id"`
+	Class           *Class                `json:"class"`
+	Unit            *Unit                 `json:"unit"`
+	Name            string                `json:"name"`
+	DueDate         int                   `json:"dueDate"`
+	Tests           []*Test               `json:"tests"`
+	Submissions     []*Submission         `json:"submissions"`
+	MissingStudents []*Student            `json:"missingStudents"`
+	AttemptPolicy   AttemptPolicy         `json:"attemptPolicy"`
+	LatePolicy      *LatePolicy           `json:"latePolicy"`
+	FeedbackRelease *FeedbackRelease      `json:"feedbackRelease"`
+	Extensions      []*Extension          `json:"extensions"`
+	MaxScore        float64               `json:"maxScore"`
+	Rubric          []*RubricCriterion    `json:"rubric"`
+	Statistics      *AssignmentStatistics `json:"statistics"`
+	StarterFiles    []*StarterFile        `json:"starterFiles"`
+	Similarity      []*SimilarityPair     `json:"similarity"`
+	LintRules       []*LintRule           `json:"lintRules"`
+	Moderation      *Moderation           `json:"moderation"`
+	GroupWork       bool                  `json:"groupWork"`
+}
+
+type AssignmentStatistics struct {
+	Submissions       int                `json:"submissions"`
+	Missing           int                `json:"missing"`
+	Graded            int                `json:"graded"`
+	Mean              *float64           `json:"mean"`
+	Median            *float64           `json:"median"`
+	StandardDeviation *float64           `json:"standardDeviation"`
+	Min               *float64           `json:"min"`
+	Max               *float64           `json:"max"`
+	Histogram         []*HistogramBucket `json:"histogram"`
+	Tests             []*TestStatistics  `json:"tests"`
+}
+
+type AuditEvent struct {
+	ID             string  `json:"id"`
+	Actor          string  `json:"actor"`
+	AttemptedEmail *string `json:"attemptedEmail"`
+	Action         string  `json:"action"`
+	EntityType     string  `json:"entityType"`
+	EntityID       string  `json:"entityID"`
+	Before         *string `json:"before"`
+	After          *string `json:"after"`
+	IP             string  `json:"ip"`
+	Timestamp      int     `json:"timestamp"`
+	Error          *string `json:"error"`
+}
+
+type AuditLogFilter struct {
+	Actor          *string `json:"actor"`
+	AttemptedEmail *string `json:"attemptedEmail"`
+	Action         *string `json:"action"`
+	EntityType     *string `json:"entityType"`
+	EntityID       *string `json:"entityID"`
+	Since          *int    `json:"since"`
+	Until          *int    `json:"until"`
 }
 
 type Class struct {
 	ID          string        `json:"id"`
 	Name        string        `json:"name"`
 	Unit        *Unit         `json:"unit"`
+	Offering    *Offering     `json:"offering"`
 	Assignments []*Assignment `json:"assignments"`
+	Students    []*Student    `json:"students"`
+	Groups      []*Group      `json:"groups"`
+}
+
+type ClassMarker struct {
+	ClassID string `json:"classID"`
+	Marker  string `json:"marker"`
+}
+
+type CloneOffering struct {
+	OfferingID string `json:"offeringID"`
+	TermID     string `json:"termID"`
+	Offset     *int   `json:"offset"`
+}
+
+type CodeComment struct {
+	ID        string             `json:"id"`
+	Version   *SubmissionVersion `json:"version"`
+	Path      string             `json:"path"`
+	StartLine int                `json:"startLine"`
+	EndLine   int                `json:"endLine"`
+	Author    string             `json:"author"`
+	Body      string             `json:"body"`
+	Resolved  bool               `json:"resolved"`
+	CreatedAt int                `json:"createdAt"`
+	UpdatedAt int                `json:"updatedAt"`
+}
+
+type CodeMetrics struct {
+	Lines             int            `json:"lines"`
+	CodeLines         int            `json:"codeLines"`
+	CommentLines      int            `json:"commentLines"`
+	Functions         int            `json:"functions"`
+	Classes           int            `json:"classes"`
+	MaxComplexity     int            `json:"maxComplexity"`
+	AverageComplexity float64        `json:"averageComplexity"`
+	MagicNumbers      int            `json:"magicNumbers"`
+	NamingIssues      int            `json:"namingIssues"`
+	HasSetup          bool           `json:"hasSetup"`
+	HasDraw           bool           `json:"hasDraw"`
+	Files             []*FileMetrics `json:"files"`
+}
+
+type Extension struct {
+	ID         string      `json:"id"`
+	Student    *Student    `json:"student"`
+	Assignment *Assignment `json:"assignment"`
+	DueDate    int         `json:"dueDate"`
+	Reason     string      `json:"reason"`
+	ApprovedBy string      `json:"approvedBy"`
+	GrantedAt  int         `json:"grantedAt"`
+}
+
+type FeedbackRelease struct {
+	Status    FeedbackStatus `json:"status"`
+	ReleaseAt *int           `json:"releaseAt"`
+	Released  bool           `json:"released"`
+}
+
+type FileDiff struct {
+	Path      string         `json:"path"`
+	Status    FileDiffStatus `json:"status"`
+	Additions int            `json:"additions"`
+	Deletions int            `json:"deletions"`
+	Patch     string         `json:"patch"`
+}
+
+type FileMetrics struct {
+	Path         string             `json:"path"`
+	Starter      bool               `json:"starter"`
+	Lines        int                `json:"lines"`
+	CodeLines    int                `json:"codeLines"`
+	CommentLines int                `json:"commentLines"`
+	BlankLines   int                `json:"blankLines"`
+	Functions    []*FunctionMetrics `json:"functions"`
+	Classes      []string           `json:"classes"`
+	HasSetup     bool               `json:"hasSetup"`
+	HasDraw      bool               `json:"hasDraw"`
+	MagicNumbers []*MagicNumber     `json:"magicNumbers"`
+	NamingIssues []*NamingIssue     `json:"namingIssues"`
+}
+
+type FunctionMetrics struct {
+	Name       string  `json:"name"`
+	Class      *string `json:"class"`
+	StartLine  int     `json:"startLine"`
+	EndLine    int     `json:"endLine"`
+	Complexity int     `json:"complexity"`
+}
+
+type Grade struct {
+	Automated    float64 `json:"automated"`
+	AutomatedMax float64 `json:"automatedMax"`
+	Rubric       float64 `json:"rubric"`
+	RubricMax    float64 `json:"rubricMax"`
+	Total        float64 `json:"total"`
+	MaxScore     float64 `json:"maxScore"`
+	Deductions   float64 `json:"deductions"`
+	LatePenalty  float64 `json:"latePenalty"`
+	Final        float64 `json:"final"`
+	Percentage   float64 `json:"percentage"`
+	Complete     bool    `json:"complete"`
+}
+
+type GradeExport struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+type Group struct {
+	ID      string     `json:"id"`
+	Name    string     `json:"name"`
+	Class   *Class     `json:"class"`
+	Members []*Student `json:"members"`
+}
+
+type HistogramBucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+type LatePolicy struct {
+	GracePeriod   int     `json:"gracePeriod"`
+	PenaltyPerDay float64 `json:"penaltyPerDay"`
+	MaxPenalty    float64 `json:"maxPenalty"`
+	Cutoff        *int    `json:"cutoff"`
+}
+
+type LatePolicyInput struct {
+	GracePeriod   int     `json:"gracePeriod"`
+	PenaltyPerDay float64 `json:"penaltyPerDay"`
+	MaxPenalty    float64 `json:"maxPenalty"`
+	Cutoff        *int    `json:"cutoff"`
+}
+
+type Lateness struct {
+	DueDate    int     `json:"dueDate"`
+	Extended   bool    `json:"extended"`
+	Late       bool    `json:"late"`
+	LateBy     int     `json:"lateBy"`
+	DaysLate   int     `json:"daysLate"`
+	PastCutoff bool    `json:"pastCutoff"`
+	Penalty    float64 `json:"penalty"`
+}
+
+type LintFinding struct {
+	Rule     *LintRule    `json:"rule"`
+	File     *string      `json:"file"`
+	Line     *int         `json:"line"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+}
+
+type LintRule struct {
+	ID           string       `json:"id"`
+	Kind         LintRuleKind `json:"kind"`
+	Severity     LintSeverity `json:"severity"`
+	Limit        *int         `json:"limit"`
+	Names        []string     `json:"names"`
+	Message      *string      `json:"message"`
+	Deduction    float64      `json:"deduction"`
+	MaxDeduction *float64     `json:"maxDeduction"`
+}
+
+type LintRuleInput struct {
+	Kind         LintRuleKind  `json:"kind"`
+	Severity     *LintSeverity `json:"severity"`
+	Limit        *int          `json:"limit"`
+	Names        []string      `json:"names"`
+	Message      *string       `json:"message"`
+	Deduction    *float64      `json:"deduction"`
+	MaxDeduction *float64      `json:"maxDeduction"`
+}
+
+type MagicNumber struct {
+	Line  int    `json:"line"`
+	Value string `json:"value"`
+}
+
+type MarkingAllocation struct {
+	ID         string        `json:"id"`
+	Submission *Submission   `json:"submission"`
+	Marker     string        `json:"marker"`
+	Status     MarkingStatus `json:"status"`
+}
+
+type MarkingProgress struct {
+	Pending    int `json:"pending"`
+	InProgress int `json:"inProgress"`
+	Done       int `json:"done"`
+	Total      int `json:"total"`
+}
+
+type MarkingQueue struct {
+	Pending    []*Submission    `json:"pending"`
+	InProgress []*Submission    `json:"inProgress"`
+	Done       []*Submission    `json:"done"`
+	Progress   *MarkingProgress `json:"progress"`
+}
+
+type MemberGrade struct {
+	Student    *Student `json:"student"`
+	Adjustment float64  `json:"adjustment"`
+	Reason     *string  `json:"reason"`
+	Total      float64  `json:"total"`
+	Final      float64  `json:"final"`
+}
+
+type Moderation struct {
+	Threshold float64             `json:"threshold"`
+	Samples   []*ModerationSample `json:"samples"`
+}
+
+type ModerationSample struct {
+	ID             string           `json:"id"`
+	Submission     *Submission      `json:"submission"`
+	SecondMarker   string           `json:"secondMarker"`
+	Status         ModerationStatus `json:"status"`
+	FirstMark      *float64         `json:"firstMark"`
+	SecondMark     *float64         `json:"secondMark"`
+	AgreedMark     *float64         `json:"agreedMark"`
+	ResolvedBy     *string          `json:"resolvedBy"`
+	ResolutionNote *string          `json:"resolutionNote"`
+}
+
+type ModerationSampling struct {
+	AssignmentID  string   `json:"assignmentID"`
+	Size          *int     `json:"size"`
+	Percentage    *float64 `json:"percentage"`
+	SecondMarkers []string `json:"secondMarkers"`
+	Threshold     float64  `json:"threshold"`
+}
+
+type NamingIssue struct {
+	Line     int    `json:"line"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Expected string `json:"expected"`
 }
 
 type NewAssignment struct {
@@ -26,8 +335,41 @@ type NewAssignment struct {
 }
 
 type NewClass struct {
-	Name   string `json:"name"`
+	Name       string  `json:"name"`
+	UnitID     string  `json:"unitID"`
+	OfferingID *string `json:"offeringID"`
+}
+
+type NewCodeComment struct {
+	VersionID string `json:"versionID"`
+	Path      string `json:"path"`
+	StartLine int    `json:"startLine"`
+	EndLine   *int   `json:"endLine"`
+	Body      string `json:"body"`
+}
+
+type NewExtension struct {
+	StudentID    string `json:"studentID"`
+	AssignmentID string `json:"assignmentID"`
+	DueDate      int    `json:"dueDate"`
+	Reason       string `json:"reason"`
+}
+
+type NewGroup struct {
+	ClassID    string   `json:"classID"`
+	Name       string   `json:"name"`
+	StudentIDs []string `json:"studentIDs"`
+}
+
+type NewOffering struct {
 	UnitID string `json:"unitID"`
+	TermID string `json:"termID"`
+}
+
+type NewStudent struct {
+	StudentNumber string `json:"studentNumber"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
 }
 
 type NewSubmission struct {
@@ -35,41 +377,798 @@ type NewSubmission struct {
 	AssignmentID string `json:"assignmentID"`
 }
 
+type NewTerm struct {
+	Name      string `json:"name"`
+	StartDate int    `json:"startDate"`
+	EndDate   int    `json:"endDate"`
+}
+
 type NewTest struct {
-	Name         string `json:"name"`
-	AssignmentID string `json:"assignmentID"`
+	Name         string   `json:"name"`
+	AssignmentID string   `json:"assignmentID"`
+	MaxPoints    *float64 `json:"maxPoints"`
+	Weight       *float64 `json:"weight"`
 }
 
 type NewUnit struct {
 	Name string `json:"name"`
 }
 
+type Offering struct {
+	ID      string   `json:"id"`
+	Unit    *Unit    `json:"unit"`
+	Term    *Term    `json:"term"`
+	Classes []*Class `json:"classes"`
+}
+
+type OfferingCloneReport struct {
+	Offering       *Offering `json:"offering"`
+	Classes        int       `json:"classes"`
+	Assignments    int       `json:"assignments"`
+	Tests          int       `json:"tests"`
+	RubricCriteria int       `json:"rubricCriteria"`
+	LintRules      int       `json:"lintRules"`
+	StarterFiles   int       `json:"starterFiles"`
+}
+
 type Result struct {
-	ID           string  `json:"id"`
-	Score        float64 `json:"score"`
-	Date         string  `json:"date"`
-	SubmissionID string  `json:"submissionID"`
+	ID                  string            `json:"id"`
+	Score               float64           `json:"score"`
+	OriginalScore       float64           `json:"originalScore"`
+	Overridden          bool              `json:"overridden"`
+	Overrides           []*ResultOverride `json:"overrides"`
+	AdjustedScore       float64           `json:"adjustedScore"`
+	Lateness            *Lateness         `json:"lateness"`
+	Date                string            `json:"date"`
+	SubmissionID        string            `json:"submissionID"`
+	SubmissionVersionID *string           `json:"submissionVersionID"`
+}
+
+type ResultOverride struct {
+	ID            string  `json:"id"`
+	PreviousScore float64 `json:"previousScore"`
+	Score         float64 `json:"score"`
+	Reason        string  `json:"reason"`
+	OverriddenBy  string  `json:"overriddenBy"`
+	OverriddenAt  int     `json:"overriddenAt"`
+}
+
+type RosterImportReport struct {
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Skipped int                `json:"skipped"`
+	Errored int                `json:"errored"`
+	Rows    []*RosterRowReport `json:"rows"`
+}
+
+type RosterRowReport struct {
+	Line          int             `json:"line"`
+	StudentNumber string          `json:"studentNumber"`
+	Status        RosterRowStatus `json:"status"`
+	Message       *string         `json:"message"`
+}
+
+type RubricCriterion struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	MaxPoints   float64        `json:"maxPoints"`
+	Levels      []*RubricLevel `json:"levels"`
+}
+
+type RubricCriterionInput struct {
+	Name        string              `json:"name"`
+	Description *string             `json:"description"`
+	Levels      []*RubricLevelInput `json:"levels"`
+}
+
+type RubricLevel struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Points      float64 `json:"points"`
+}
+
+type RubricLevelInput struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Points      float64 `json:"points"`
+}
+
+type RubricMark struct {
+	Criterion *RubricCriterion `json:"criterion"`
+	Level     *RubricLevel     `json:"level"`
+	Comment   *string          `json:"comment"`
+	MarkedBy  string           `json:"markedBy"`
+	MarkedAt  int              `json:"markedAt"`
+}
+
+type RubricSelection struct {
+	CriterionID string  `json:"criterionID"`
+	LevelID     string  `json:"levelID"`
+	Comment     *string `json:"comment"`
+}
+
+type ScoreBreakdown struct {
+	Total      float64      `json:"total"`
+	MaxScore   float64      `json:"maxScore"`
+	Percentage float64      `json:"percentage"`
+	Tests      []*TestScore `json:"tests"`
+}
+
+type SimilarityMatch struct {
+	FileA      string `json:"fileA"`
+	StartLineA int    `json:"startLineA"`
+	EndLineA   int    `json:"endLineA"`
+	FileB      string `json:"fileB"`
+	StartLineB int    `json:"startLineB"`
+	EndLineB   int    `json:"endLineB"`
+}
+
+type SimilarityPair struct {
+	SubmissionA *Submission        `json:"submissionA"`
+	SubmissionB *Submission        `json:"submissionB"`
+	Similarity  float64            `json:"similarity"`
+	SimilarityA float64            `json:"similarityA"`
+	SimilarityB float64            `json:"similarityB"`
+	Matches     []*SimilarityMatch `json:"matches"`
+}
+
+type StarterFile struct {
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Size    int    `json:"size"`
+	Content string `json:"content"`
+}
+
+type Student struct {
+	ID            string        `json:"id"`
+	StudentNumber string        `json:"studentNumber"`
+	Name          string        `json:"name"`
+	Email         string        `json:"email"`
+	Classes       []*Class      `json:"classes"`
+	Submissions   []*Submission `json:"submissions"`
+}
+
+type StudentInvite struct {
+	Student   *Student `json:"student"`
+	Code      string   `json:"code"`
+	ExpiresAt int      `json:"expiresAt"`
 }
 
 type Submission struct {
-	ID         string      `json:"id"`
-	StudentID  string      `json:"studentID"`
-	Result     *Result     `json:"result"`
-	Unit       *Unit       `json:"unit"`
-	Class      *Class      `json:"class"`
-	Assignment *Assignment `json:"assignment"`
+	ID             string               `json:"id"`
+	StudentID      string               `json:"studentID"`
+	Student        *Student             `json:"student"`
+	Result         *Result              `json:"result"`
+	Unit           *Unit                `json:"unit"`
+	Class          *Class               `json:"class"`
+	Assignment     *Assignment          `json:"assignment"`
+	Files          []*SubmissionFile    `json:"files"`
+	Attempts       int                  `json:"attempts"`
+	Versions       []*SubmissionVersion `json:"versions"`
+	CountedVersion *SubmissionVersion   `json:"countedVersion"`
+	Lateness       *Lateness            `json:"lateness"`
+	Score          *ScoreBreakdown      `json:"score"`
+	RubricMarks    []*RubricMark        `json:"rubricMarks"`
+	Grade          *Grade               `json:"grade"`
+	CodeMetrics    *CodeMetrics         `json:"codeMetrics"`
+	LintFindings   []*LintFinding       `json:"lintFindings"`
+	Comments       []*CodeComment       `json:"comments"`
+	Allocation     *MarkingAllocation   `json:"allocation"`
+	Moderation     *ModerationSample    `json:"moderation"`
+	Group          *Group               `json:"group"`
+	MemberGrades   []*MemberGrade       `json:"memberGrades"`
+}
+
+type SubmissionFile struct {
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Size    int    `json:"size"`
+	Content string `json:"content"`
+}
+
+type SubmissionImportReport struct {
+	Created     int                    `json:"created"`
+	Resubmitted int                    `json:"resubmitted"`
+	Duplicates  int                    `json:"duplicates"`
+	Unmatched   int                    `json:"unmatched"`
+	Errored     int                    `json:"errored"`
+	Rows        []*SubmissionImportRow `json:"rows"`
+}
+
+type SubmissionImportRow struct {
+	Folder        string                 `json:"folder"`
+	StudentNumber string                 `json:"studentNumber"`
+	Status        SubmissionImportStatus `json:"status"`
+	Files         int                    `json:"files"`
+	Submission    *Submission            `json:"submission"`
+	Message       *string                `json:"message"`
+}
+
+type SubmissionVersion struct {
+	ID          string            `json:"id"`
+	Number      int               `json:"number"`
+	SubmittedAt int               `json:"submittedAt"`
+	Files       []*SubmissionFile `json:"files"`
+	Result      *Result           `json:"result"`
+	Counted     bool              `json:"counted"`
+	StarterDiff []*FileDiff       `json:"starterDiff"`
+}
+
+type Term struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	StartDate int         `json:"startDate"`
+	EndDate   int         `json:"endDate"`
+	Offerings []*Offering `json:"offerings"`
 }
 
 type Test struct {
 	ID         string      `json:"id"`
 	Name       string      `json:"name"`
+	MaxPoints  float64     `json:"maxPoints"`
+	Weight     float64     `json:"weight"`
 	Unit       *Unit       `json:"unit"`
 	Class      *Class      `json:"class"`
 	Assignment *Assignment `json:"assignment"`
 }
 
+type TestOutcomeInput struct {
+	TestID string  `json:"testID"`
+	Points float64 `json:"points"`
+}
+
+type TestScore struct {
+	Test              *Test    `json:"test"`
+	Points            *float64 `json:"points"`
+	WeightedPoints    float64  `json:"weightedPoints"`
+	WeightedMaxPoints float64  `json:"weightedMaxPoints"`
+}
+
+type TestStatistics struct {
+	Test       *Test    `json:"test"`
+	Attempts   int      `json:"attempts"`
+	Passed     int      `json:"passed"`
+	PassRate   *float64 `json:"passRate"`
+	MeanPoints *float64 `json:"meanPoints"`
+}
+
 type Unit struct {
-	ID      string   `json:"id"`
-	Name    string   `json:"name"`
-	Classes []*Class `json:"classes"`
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Classes   []*Class    `json:"classes"`
+	Offerings []*Offering `json:"offerings"`
+}
+
+type AllocationStrategy string
+
+const (
+	AllocationStrategyRoundRobin AllocationStrategy = "ROUND_ROBIN"
+	AllocationStrategyByClass    AllocationStrategy = "BY_CLASS"
+)
+
+var AllAllocationStrategy = []AllocationStrategy{
+	AllocationStrategyRoundRobin,
+	AllocationStrategyByClass,
+}
+
+func (e AllocationStrategy) IsValid() bool {
+	switch e {
+	case AllocationStrategyRoundRobin, AllocationStrategyByClass:
+		return true
+	}
+	return false
+}
+
+func (e AllocationStrategy) String() string {
+	return string(e)
+}
+
+func (e *AllocationStrategy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AllocationStrategy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AllocationStrategy", str)
+	}
+	return nil
+}
+
+func (e AllocationStrategy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AttemptPolicy string
+
+const (
+	AttemptPolicyLatest AttemptPolicy = "LATEST"
+	AttemptPolicyBest   AttemptPolicy = "BEST"
+	AttemptPolicyFirst  AttemptPolicy = "FIRST"
+)
+
+var AllAttemptPolicy = []AttemptPolicy{
+	AttemptPolicyLatest,
+	AttemptPolicyBest,
+	AttemptPolicyFirst,
+}
+
+func (e AttemptPolicy) IsValid() bool {
+	switch e {
+	case AttemptPolicyLatest, AttemptPolicyBest, AttemptPolicyFirst:
+		return true
+	}
+	return false
+}
+
+func (e AttemptPolicy) String() string {
+	return string(e)
+}
+
+func (e *AttemptPolicy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AttemptPolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AttemptPolicy", str)
+	}
+	return nil
+}
+
+func (e AttemptPolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FeedbackStatus string
+
+const (
+	FeedbackStatusDraft           FeedbackStatus = "DRAFT"
+	FeedbackStatusUnderModeration FeedbackStatus = "UNDER_MODERATION"
+	FeedbackStatusReleased        FeedbackStatus = "RELEASED"
+)
+
+var AllFeedbackStatus = []FeedbackStatus{
+	FeedbackStatusDraft,
+	FeedbackStatusUnderModeration,
+	FeedbackStatusReleased,
+}
+
+func (e FeedbackStatus) IsValid() bool {
+	switch e {
+	case FeedbackStatusDraft, FeedbackStatusUnderModeration, FeedbackStatusReleased:
+		return true
+	}
+	return false
+}
+
+func (e FeedbackStatus) String() string {
+	return string(e)
+}
+
+func (e *FeedbackStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeedbackStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeedbackStatus", str)
+	}
+	return nil
+}
+
+func (e FeedbackStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FileDiffStatus string
+
+const (
+	FileDiffStatusAdded     FileDiffStatus = "ADDED"
+	FileDiffStatusRemoved   FileDiffStatus = "REMOVED"
+	FileDiffStatusModified  FileDiffStatus = "MODIFIED"
+	FileDiffStatusUnchanged FileDiffStatus = "UNCHANGED"
+)
+
+var AllFileDiffStatus = []FileDiffStatus{
+	FileDiffStatusAdded,
+	FileDiffStatusRemoved,
+	FileDiffStatusModified,
+	FileDiffStatusUnchanged,
+}
+
+func (e FileDiffStatus) IsValid() bool {
+	switch e {
+	case FileDiffStatusAdded, FileDiffStatusRemoved, FileDiffStatusModified, FileDiffStatusUnchanged:
+		return true
+	}
+	return false
+}
+
+func (e FileDiffStatus) String() string {
+	return string(e)
+}
+
+func (e *FileDiffStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FileDiffStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FileDiffStatus", str)
+	}
+	return nil
+}
+
+func (e FileDiffStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type GradeExportFormat string
+
+const (
+	GradeExportFormatCSV        GradeExportFormat = "CSV"
+	GradeExportFormatMoodle     GradeExportFormat = "MOODLE"
+	GradeExportFormatCanvas     GradeExportFormat = "CANVAS"
+	GradeExportFormatBlackboard GradeExportFormat = "BLACKBOARD"
+)
+
+var AllGradeExportFormat = []GradeExportFormat{
+	GradeExportFormatCSV,
+	GradeExportFormatMoodle,
+	GradeExportFormatCanvas,
+	GradeExportFormatBlackboard,
+}
+
+func (e GradeExportFormat) IsValid() bool {
+	switch e {
+	case GradeExportFormatCSV, GradeExportFormatMoodle, GradeExportFormatCanvas, GradeExportFormatBlackboard:
+		return true
+	}
+	return false
+}
+
+func (e GradeExportFormat) String() string {
+	return string(e)
+}
+
+func (e *GradeExportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GradeExportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GradeExportFormat", str)
+	}
+	return nil
+}
+
+func (e GradeExportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type LintRuleKind string
+
+const (
+	LintRuleKindMaxFunctionLength LintRuleKind = "MAX_FUNCTION_LENGTH"
+	LintRuleKindMaxComplexity     LintRuleKind = "MAX_COMPLEXITY"
+	LintRuleKindRequireComments   LintRuleKind = "REQUIRE_COMMENTS"
+	LintRuleKindBannedAPI         LintRuleKind = "BANNED_API"
+	LintRuleKindRequiredClass     LintRuleKind = "REQUIRED_CLASS"
+	LintRuleKindMagicNumbers      LintRuleKind = "MAGIC_NUMBERS"
+	LintRuleKindNamingConventions LintRuleKind = "NAMING_CONVENTIONS"
+)
+
+var AllLintRuleKind = []LintRuleKind{
+	LintRuleKindMaxFunctionLength,
+	LintRuleKindMaxComplexity,
+	LintRuleKindRequireComments,
+	LintRuleKindBannedAPI,
+	LintRuleKindRequiredClass,
+	LintRuleKindMagicNumbers,
+	LintRuleKindNamingConventions,
+}
+
+func (e LintRuleKind) IsValid() bool {
+	switch e {
+	case LintRuleKindMaxFunctionLength, LintRuleKindMaxComplexity, LintRuleKindRequireComments, LintRuleKindBannedAPI, LintRuleKindRequiredClass, LintRuleKindMagicNumbers, LintRuleKindNamingConventions:
+		return true
+	}
+	return false
+}
+
+func (e LintRuleKind) String() string {
+	return string(e)
+}
+
+func (e *LintRuleKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LintRuleKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LintRuleKind", str)
+	}
+	return nil
+}
+
+func (e LintRuleKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type LintSeverity string
+
+const (
+	LintSeverityInfo    LintSeverity = "INFO"
+	LintSeverityWarning LintSeverity = "WARNING"
+	LintSeverityError   LintSeverity = "ERROR"
+)
+
+var AllLintSeverity = []LintSeverity{
+	LintSeverityInfo,
+	LintSeverityWarning,
+	LintSeverityError,
+}
+
+func (e LintSeverity) IsValid() bool {
+	switch e {
+	case LintSeverityInfo, LintSeverityWarning, LintSeverityError:
+		return true
+	}
+	return false
+}
+
+func (e LintSeverity) String() string {
+	return string(e)
+}
+
+func (e *LintSeverity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LintSeverity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LintSeverity", str)
+	}
+	return nil
+}
+
+func (e LintSeverity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MarkingStatus string
+
+const (
+	MarkingStatusPending    MarkingStatus = "PENDING"
+	MarkingStatusInProgress MarkingStatus = "IN_PROGRESS"
+	MarkingStatusDone       MarkingStatus = "DONE"
+)
+
+var AllMarkingStatus = []MarkingStatus{
+	MarkingStatusPending,
+	MarkingStatusInProgress,
+	MarkingStatusDone,
+}
+
+func (e MarkingStatus) IsValid() bool {
+	switch e {
+	case MarkingStatusPending, MarkingStatusInProgress, MarkingStatusDone:
+		return true
+	}
+	return false
+}
+
+func (e MarkingStatus) String() string {
+	return string(e)
+}
+
+func (e *MarkingStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MarkingStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MarkingStatus", str)
+	}
+	return nil
+}
+
+func (e MarkingStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ModerationStatus string
+
+const (
+	ModerationStatusAwaitingSecondMark ModerationStatus = "AWAITING_SECOND_MARK"
+	ModerationStatusAgreed             ModerationStatus = "AGREED"
+	ModerationStatusDiscrepancy        ModerationStatus = "DISCREPANCY"
+	ModerationStatusResolved           ModerationStatus = "RESOLVED"
+)
+
+var AllModerationStatus = []ModerationStatus{
+	ModerationStatusAwaitingSecondMark,
+	ModerationStatusAgreed,
+	ModerationStatusDiscrepancy,
+	ModerationStatusResolved,
+}
+
+func (e ModerationStatus) IsValid() bool {
+	switch e {
+	case ModerationStatusAwaitingSecondMark, ModerationStatusAgreed, ModerationStatusDiscrepancy, ModerationStatusResolved:
+		return true
+	}
+	return false
+}
+
+func (e ModerationStatus) String() string {
+	return string(e)
+}
+
+func (e *ModerationStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModerationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModerationStatus", str)
+	}
+	return nil
+}
+
+func (e ModerationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RosterRowStatus string
+
+const (
+	RosterRowStatusCreated RosterRowStatus = "CREATED"
+	RosterRowStatusUpdated RosterRowStatus = "UPDATED"
+	RosterRowStatusSkipped RosterRowStatus = "SKIPPED"
+	RosterRowStatusErrored RosterRowStatus = "ERRORED"
+)
+
+var AllRosterRowStatus = []RosterRowStatus{
+	RosterRowStatusCreated,
+	RosterRowStatusUpdated,
+	RosterRowStatusSkipped,
+	RosterRowStatusErrored,
+}
+
+func (e RosterRowStatus) IsValid() bool {
+	switch e {
+	case RosterRowStatusCreated, RosterRowStatusUpdated, RosterRowStatusSkipped, RosterRowStatusErrored:
+		return true
+	}
+	return false
+}
+
+func (e RosterRowStatus) String() string {
+	return string(e)
+}
+
+func (e *RosterRowStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RosterRowStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RosterRowStatus", str)
+	}
+	return nil
+}
+
+func (e RosterRowStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type StaffRole string
+
+const (
+	StaffRoleAdmin StaffRole = "ADMIN"
+	StaffRoleTutor StaffRole = "TUTOR"
+)
+
+var AllStaffRole = []StaffRole{
+	StaffRoleAdmin,
+	StaffRoleTutor,
+}
+
+func (e StaffRole) IsValid() bool {
+	switch e {
+	case StaffRoleAdmin, StaffRoleTutor:
+		return true
+	}
+	return false
+}
+
+func (e StaffRole) String() string {
+	return string(e)
+}
+
+func (e *StaffRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StaffRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StaffRole", str)
+	}
+	return nil
+}
+
+func (e StaffRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SubmissionImportStatus string
+
+const (
+	SubmissionImportStatusCreated     SubmissionImportStatus = "CREATED"
+	SubmissionImportStatusResubmitted SubmissionImportStatus = "RESUBMITTED"
+	SubmissionImportStatusDuplicate   SubmissionImportStatus = "DUPLICATE"
+	SubmissionImportStatusUnmatched   SubmissionImportStatus = "UNMATCHED"
+	SubmissionImportStatusErrored     SubmissionImportStatus = "ERRORED"
+)
+
+var AllSubmissionImportStatus = []SubmissionImportStatus{
+	SubmissionImportStatusCreated,
+	SubmissionImportStatusResubmitted,
+	SubmissionImportStatusDuplicate,
+	SubmissionImportStatusUnmatched,
+	SubmissionImportStatusErrored,
+}
+
+func (e SubmissionImportStatus) IsValid() bool {
+	switch e {
+	case SubmissionImportStatusCreated, SubmissionImportStatusResubmitted, SubmissionImportStatusDuplicate, SubmissionImportStatusUnmatched, SubmissionImportStatusErrored:
+		return true
+	}
+	return false
+}
+
+func (e SubmissionImportStatus) String() string {
+	return string(e)
+}
+
+func (e *SubmissionImportStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SubmissionImportStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SubmissionImportStatus", str)
+	}
+	return nil
+}
+
+func (e SubmissionImportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/COMP4050/square-team-5/api/internal/pkg/config"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/web/auth"
 )
 
 // This file will not be regenerated automatically.
@@ -16,4 +17,11 @@ type Resolver struct {
 	Config      config.Config
 	DB          db.Database
 	ExtractUser func(ctx context.Context) *models.User
+	ExtractIP   func(ctx context.Context) string
+
+	// IPThrottle limits failed logins per client IP address.
+	IPThrottle *auth.LoginThrottle
+	// AccountThrottle limits failed logins against emails with no account. Failures
+	// against real accounts are tracked on the user record instead.
+	AccountThrottle *auth.LoginThrottle
 }
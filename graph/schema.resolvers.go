@@ -9,14 +9,28 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/COMP4050/square-team-5/api/graph/generated"
 	"github.com/COMP4050/square-team-5/api/graph/model"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
-	"github.com/golang-jwt/jwt"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/diff"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/export"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/grading"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/marking"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/metrics"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/roster"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/similarity"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/starter"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/submissions"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/web/auth"
 )
 
 // Class is the resolver for the class field.
@@ -63,6 +77,10 @@ func (r *assignmentResolver) Unit(ctx context.Context, obj *model.Assignment) (*
 
 // Tests is the resolver for the tests field.
 func (r *assignmentResolver) Tests(ctx context.Context, obj *model.Assignment) ([]*model.Test, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
 	tests, err := r.DB.GetTestsForAssignment(obj.ID)
 	if err != nil {
 		return nil, err
@@ -74,10 +92,7 @@ func (r *assignmentResolver) Tests(ctx context.Context, obj *model.Assignment) (
 	var gqlTests []*model.Test
 
 	for _, test := range tests {
-		gqlTests = append(gqlTests, &model.Test{
-			ID:   fmt.Sprintf("%d", test.ID),
-			Name: test.Name,
-		})
+		gqlTests = append(gqlTests, toGQLTest(test))
 	}
 
 	return gqlTests, nil
@@ -85,6 +100,10 @@ func (r *assignmentResolver) Tests(ctx context.Context, obj *model.Assignment) (
 
 // Submissions is the resolver for the submissions field.
 func (r *assignmentResolver) Submissions(ctx context.Context, obj *model.Assignment) ([]*model.Submission, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
 	submissions, err := r.DB.GetSubmissionsForAssignment(obj.ID)
 	if err != nil {
 		return nil, err
@@ -111,601 +130,3955 @@ func (r *assignmentResolver) Submissions(ctx context.Context, obj *model.Assignm
 	return gqlSubmissions, nil
 }
 
-// Unit is the resolver for the unit field.
-func (r *classResolver) Unit(ctx context.Context, obj *model.Class) (*model.Unit, error) {
-	class, err := getClass(r.DB, obj.ID)
-	if err != nil {
+// MissingStudents is the resolver for the missingStudents field.
+func (r *assignmentResolver) MissingStudents(ctx context.Context, obj *model.Assignment) ([]*model.Student, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
 		return nil, err
 	}
 
-	unit, err := getUnit(r.DB, fmt.Sprintf("%d", class.UnitID))
+	students, err := r.DB.GetStudentsWithoutSubmission(obj.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.Unit{
-		ID:   fmt.Sprintf("%d", unit.ID),
-		Name: unit.Name,
-	}, nil
+	gqlStudents := []*model.Student{}
+	for _, student := range students {
+		gqlStudents = append(gqlStudents, toGQLStudent(student))
+	}
+
+	return gqlStudents, nil
 }
 
-// Assignments is the resolver for the assignments field.
-func (r *classResolver) Assignments(ctx context.Context, obj *model.Class) ([]*model.Assignment, error) {
-	classID, err := strconv.ParseUint(obj.ID, 10, 64)
+// AttemptPolicy is the resolver for the attemptPolicy field.
+func (r *assignmentResolver) AttemptPolicy(ctx context.Context, obj *model.Assignment) (model.AttemptPolicy, error) {
+	assignment, err := getAssignment(r.DB, obj.ID)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	assignments, err := r.DB.GetAssignmentsForClass(uint(classID))
+
+	// Assignments created before attempt policies existed count the latest attempt.
+	if assignment.AttemptPolicy == "" {
+		return model.AttemptPolicyLatest, nil
+	}
+
+	return model.AttemptPolicy(assignment.AttemptPolicy), nil
+}
+
+// LatePolicy is the resolver for the latePolicy field.
+func (r *assignmentResolver) LatePolicy(ctx context.Context, obj *model.Assignment) (*model.LatePolicy, error) {
+	assignment, err := getAssignment(r.DB, obj.ID)
 	if err != nil {
 		return nil, err
 	}
-	if assignments == nil {
-		return nil, nil
-	}
 
-	var gqlAssignments []*model.Assignment
+	return toGQLLatePolicy(assignment.LatePolicy), nil
+}
 
-	for _, assignment := range assignments {
-		gqlAssignments = append(gqlAssignments, &model.Assignment{
-			ID:          fmt.Sprintf("%d", assignment.ID),
-			Name:        assignment.Name,
-			DueDate:     int(assignment.DueDate.Unix()),
-			Tests:       []*model.Test{},
-			Submissions: []*model.Submission{},
-		})
+// FeedbackRelease is the resolver for the feedbackRelease field.
+func (r *assignmentResolver) FeedbackRelease(ctx context.Context, obj *model.Assignment) (*model.FeedbackRelease, error) {
+	assignment, err := getAssignment(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	return gqlAssignments, nil
+	return toGQLFeedbackRelease(assignment.Feedback), nil
 }
 
-// CreateUnit is the resolver for the createUnit field.
-func (r *mutationResolver) CreateUnit(ctx context.Context, input model.NewUnit) (*model.Unit, error) {
-	user := r.ExtractUser(ctx)
-	if user == nil {
-		return nil, fmt.Errorf("user not authenticated")
+// Extensions is the resolver for the extensions field.
+func (r *assignmentResolver) Extensions(ctx context.Context, obj *model.Assignment) ([]*model.Extension, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
 	}
 
-	// Check if a unit with the same name already exists
-	existingUnit, err := r.DB.GetUnitByName(input.Name)
-	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
-		return nil, fmt.Errorf("error getting unit: %w", err)
-	}
-	if existingUnit != nil {
-		return nil, fmt.Errorf("unit already exists")
+	assignmentID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
 	}
 
-	unit, err := r.DB.CreateUnit(input.Name)
+	extensions, err := r.DB.GetExtensionsForAssignment(uint(assignmentID))
 	if err != nil {
-		return nil, fmt.Errorf("error creating unit: %w", err)
-	}
-	if unit == nil {
-		return nil, fmt.Errorf("error creating unit")
+		return nil, err
 	}
 
-	gqlUnit := &model.Unit{ID: fmt.Sprintf("%d", unit.ID), Name: unit.Name, Classes: []*model.Class{}}
+	gqlExtensions := []*model.Extension{}
+	for _, extension := range extensions {
+		gqlExtensions = append(gqlExtensions, toGQLExtension(extension))
+	}
 
-	return gqlUnit, nil
+	return gqlExtensions, nil
 }
 
-// CreateClass is the resolver for the createClass field.
-func (r *mutationResolver) CreateClass(ctx context.Context, input model.NewClass) (*model.Class, error) {
-	user := r.ExtractUser(ctx)
-	if user == nil {
-		return nil, fmt.Errorf("user not authenticated")
-	}
-
-	unitID, err := strconv.ParseUint(input.UnitID, 10, 64)
+// MaxScore is the resolver for the maxScore field.
+func (r *assignmentResolver) MaxScore(ctx context.Context, obj *model.Assignment) (float64, error) {
+	tests, err := r.DB.GetTestsForAssignment(obj.ID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	// TODO: Don't allow creating a class with the same name for the same unit
+	return grading.Aggregate(tests, nil).Max, nil
+}
 
-	unit, err := getUnit(r.DB, input.UnitID)
+// Rubric is the resolver for the rubric field.
+func (r *assignmentResolver) Rubric(ctx context.Context, obj *model.Assignment) ([]*model.RubricCriterion, error) {
+	assignmentID, err := strconv.ParseUint(obj.ID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("error creating class: %w", err)
-	}
-	if unit == nil {
-		return nil, fmt.Errorf("unit with id: %d does not exist", unitID)
+		return nil, err
 	}
 
-	class, err := r.DB.CreateClass(input.Name, uint(unitID))
+	criteria, err := r.DB.GetRubric(uint(assignmentID))
 	if err != nil {
-		return nil, fmt.Errorf("error creating class: %w", err)
-	}
-	if class == nil {
-		return nil, nil
+		return nil, err
 	}
 
-	gqlClass := &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name}
+	gqlCriteria := []*model.RubricCriterion{}
+	for _, criterion := range criteria {
+		gqlCriteria = append(gqlCriteria, toGQLRubricCriterion(criterion))
+	}
 
-	return gqlClass, nil
+	return gqlCriteria, nil
 }
 
-// CreateAssignment is the resolver for the createAssignment field.
-func (r *mutationResolver) CreateAssignment(ctx context.Context, input model.NewAssignment) (*model.Assignment, error) {
-	user := r.ExtractUser(ctx)
-	if user == nil {
-		return nil, fmt.Errorf("user not authenticated")
+// Statistics is the resolver for the statistics field.
+func (r *assignmentResolver) Statistics(ctx context.Context, obj *model.Assignment, buckets *int) (*model.AssignmentStatistics, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
 	}
 
-	id, err := strconv.ParseUint(input.ClassID, 10, 64)
+	histogramBuckets := 10
+	if buckets != nil {
+		histogramBuckets = *buckets
+	}
+	if histogramBuckets < 1 || histogramBuckets > 100 {
+		return nil, fmt.Errorf("buckets must be between 1 and 100")
+	}
+
+	assignment, err := getAssignment(r.DB, obj.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	assignment, err := r.DB.CreateAssignment(input.Name, input.DueDate, uint(id))
+	stats, err := grading.LoadStatistics(r.DB, assignment, histogramBuckets)
 	if err != nil {
-		return nil, fmt.Errorf("error creating assignment: %w", err)
+		return nil, err
 	}
-	if assignment == nil {
-		return nil, nil
+
+	return toGQLAssignmentStatistics(stats), nil
+}
+
+// StarterFiles is the resolver for the starterFiles field.
+func (r *assignmentResolver) StarterFiles(ctx context.Context, obj *model.Assignment) ([]*model.StarterFile, error) {
+	assignmentID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
 	}
 
-	gqlAssignment := &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name}
+	files, err := r.DB.GetStarterFiles(uint(assignmentID))
+	if err != nil {
+		return nil, err
+	}
 
-	return gqlAssignment, nil
+	return toGQLStarterFiles(files), nil
 }
 
-// CreateTest is the resolver for the createTest field.
-func (r *mutationResolver) CreateTest(ctx context.Context, input model.NewTest) (*model.Test, error) {
-	user := r.ExtractUser(ctx)
-	if user == nil {
-		return nil, fmt.Errorf("user not authenticated")
+// Similarity is the resolver for the similarity field.
+func (r *assignmentResolver) Similarity(ctx context.Context, obj *model.Assignment, minSimilarity *float64, limit *int) ([]*model.SimilarityPair, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
 	}
 
-	id, err := strconv.ParseUint(input.AssignmentID, 10, 64)
+	threshold := 0.3
+	if minSimilarity != nil {
+		threshold = *minSimilarity
+	}
+	maxPairs := 50
+	if limit != nil {
+		maxPairs = *limit
+	}
+	if maxPairs < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+
+	assignment, err := getAssignment(r.DB, obj.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if input.Name == "" {
-		return nil, fmt.Errorf("name is required")
+	documents, err := similarity.LoadDocuments(r.DB, assignment)
+	if err != nil {
+		return nil, err
 	}
 
-	test, err := r.DB.CreateTest(input.Name, uint(id))
+	options := similarity.DefaultOptions
+	options.Baseline, err = similarity.LoadBaseline(r.DB, assignment.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error creating test: %w", err)
+		return nil, err
 	}
-	if test == nil {
-		return nil, nil
+
+	names := map[uint]string{}
+	for _, document := range documents {
+		names[document.ID] = document.Name
 	}
 
-	gqlTest := &model.Test{ID: fmt.Sprintf("%d", test.ID), Name: test.Name}
+	gqlPairs := []*model.SimilarityPair{}
+	for _, pair := range similarity.Compare(documents, options) {
+		if pair.Similarity() < threshold || len(gqlPairs) == maxPairs {
+			break
+		}
+
+		gqlPairs = append(gqlPairs, toGQLSimilarityPair(pair, names))
+	}
 
-	return gqlTest, nil
+	return gqlPairs, nil
 }
 
-// RunTest is the resolver for the runTest field.
-func (r *mutationResolver) RunTest(ctx context.Context, testID string) (bool, error) {
-	user := r.ExtractUser(ctx)
-	if user == nil {
-		return false, fmt.Errorf("user not authenticated")
+// LintRules is the resolver for the lintRules field.
+func (r *assignmentResolver) LintRules(ctx context.Context, obj *model.Assignment) ([]*model.LintRule, error) {
+	assignmentID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
 	}
 
-	test, err := getTest(r.DB, testID)
+	rules, err := r.DB.GetLintRules(uint(assignmentID))
 	if err != nil {
-		return false, fmt.Errorf("error getting test: %w", err)
+		return nil, fmt.Errorf("error getting lint rules: %w", err)
 	}
 
-	// Get assignment of test
-	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", test.AssignmentID))
-	if err != nil {
-		return false, fmt.Errorf("error getting assignment: %w", err)
+	gqlRules := []*model.LintRule{}
+	for _, rule := range rules {
+		gqlRules = append(gqlRules, toGQLLintRule(rule))
 	}
 
-	// Get class of assignment
-	class, err := getClass(r.DB, fmt.Sprintf("%d", assignment.ClassID))
-	if err != nil {
-		return false, fmt.Errorf("error getting class: %w", err)
+	return gqlRules, nil
+}
+
+// Moderation is the resolver for the moderation field.
+func (r *assignmentResolver) Moderation(ctx context.Context, obj *model.Assignment) (*model.Moderation, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
 	}
 
-	// Get unit name of test
-	unit, err := getUnit(r.DB, fmt.Sprintf("%d", class.UnitID))
+	assignment, err := getAssignment(r.DB, obj.ID)
 	if err != nil {
-		return false, fmt.Errorf("error getting unit: %w", err)
+		return nil, err
 	}
 
-	body := map[string]string{
-		"s3KeyTestFile":    fmt.Sprintf("%s/%s/Tests/%s/Test.java", unit.Name, assignment.Name, testID),
-		"s3KeyProjectFile": fmt.Sprintf("%s/%s/Projects/", unit.Name, assignment.Name),
+	samples, err := r.DB.GetModerationSamples(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting moderation samples: %w", err)
 	}
 
-	json, err := json.Marshal(body)
+	return toGQLModeration(assignment.ModerationThreshold, samples), nil
+}
+
+// GroupWork is the resolver for the groupWork field.
+func (r *assignmentResolver) GroupWork(ctx context.Context, obj *model.Assignment) (bool, error) {
+	assignment, err := getAssignment(r.DB, obj.ID)
 	if err != nil {
 		return false, err
 	}
 
-	testExecutorEndpoint := r.Config.TestExecutorEndpoint
-
-	res, err := http.Post(testExecutorEndpoint, "application/json", bytes.NewBuffer(json))
+	return assignment.GroupWork, nil
+}
 
+// Unit is the resolver for the unit field.
+func (r *classResolver) Unit(ctx context.Context, obj *model.Class) (*model.Unit, error) {
+	class, err := getClass(r.DB, obj.ID)
 	if err != nil {
-		return false, fmt.Errorf("error running test: %w", err)
+		return nil, err
 	}
 
-	if res.StatusCode != 200 {
-		return false, fmt.Errorf("error running test: %w", err)
+	unit, err := getUnit(r.DB, fmt.Sprintf("%d", class.UnitID))
+	if err != nil {
+		return nil, err
 	}
 
-	return true, nil
+	return &model.Unit{
+		ID:   fmt.Sprintf("%d", unit.ID),
+		Name: unit.Name,
+	}, nil
 }
 
-// CreateSubmission is the resolver for the createSubmission field.
-func (r *mutationResolver) CreateSubmission(ctx context.Context, input model.NewSubmission) (*model.Submission, error) {
-	user := r.ExtractUser(ctx)
-	if user == nil {
-		return nil, fmt.Errorf("user not authenticated")
+// Offering is the resolver for the offering field.
+func (r *classResolver) Offering(ctx context.Context, obj *model.Class) (*model.Offering, error) {
+	class, err := getClass(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if class.OfferingID == nil {
+		return nil, nil
 	}
 
-	assignmentID, err := strconv.ParseUint(input.AssignmentID, 10, 64)
+	return &model.Offering{ID: fmt.Sprintf("%d", *class.OfferingID)}, nil
+}
+
+// Assignments is the resolver for the assignments field.
+func (r *classResolver) Assignments(ctx context.Context, obj *model.Class) ([]*model.Assignment, error) {
+	classID, err := strconv.ParseUint(obj.ID, 10, 64)
 	if err != nil {
 		return nil, err
 	}
-
-	submission, err := r.DB.CreateSubmission(input.StudentID, uint(assignmentID))
+	assignments, err := r.DB.GetAssignmentsForClass(uint(classID))
 	if err != nil {
-		return nil, fmt.Errorf("error creating submission: %w", err)
+		return nil, err
 	}
-	if submission == nil {
+	if assignments == nil {
 		return nil, nil
 	}
 
-	gqlSubmission := &model.Submission{ID: fmt.Sprintf("%d", submission.ID), StudentID: submission.StudentID}
+	var gqlAssignments []*model.Assignment
 
-	return gqlSubmission, nil
+	for _, assignment := range assignments {
+		gqlAssignments = append(gqlAssignments, &model.Assignment{
+			ID:          fmt.Sprintf("%d", assignment.ID),
+			Name:        assignment.Name,
+			DueDate:     int(assignment.DueDate.Unix()),
+			Tests:       []*model.Test{},
+			Submissions: []*model.Submission{},
+		})
+	}
+
+	return gqlAssignments, nil
 }
 
-// Register is the resolver for the register field.
-func (r *mutationResolver) Register(ctx context.Context, email string, password string) (string, error) {
-	if email == "" || password == "" {
-		return "", fmt.Errorf("email or password must not be empty")
+// Students is the resolver for the students field.
+func (r *classResolver) Students(ctx context.Context, obj *model.Class) ([]*model.Student, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
 	}
 
-	user, err := r.DB.GetUserByEmail(email)
-	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
-		return "", fmt.Errorf("error getting user: %w", err)
+	classID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
 	}
-	if user != nil {
-		return "", fmt.Errorf("user already exists")
+
+	students, err := r.DB.GetStudentsForClass(uint(classID))
+	if err != nil {
+		return nil, err
 	}
 
-	passwordHash, err := models.HashPassword(password)
+	gqlStudents := []*model.Student{}
+	for _, student := range students {
+		gqlStudents = append(gqlStudents, toGQLStudent(student))
+	}
+
+	return gqlStudents, nil
+}
+
+// Groups is the resolver for the groups field.
+func (r *classResolver) Groups(ctx context.Context, obj *model.Class) ([]*model.Group, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	classID, err := strconv.ParseUint(obj.ID, 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("error hashing password: %w", err)
+		return nil, err
 	}
 
-	user, err = r.DB.CreateUser(email, passwordHash, models.UserRoleAdmin)
+	groups, err := r.DB.GetGroupsForClass(uint(classID))
 	if err != nil {
-		return "", fmt.Errorf("error creating user: %w", err)
+		return nil, err
 	}
-	if user == nil {
-		return "", fmt.Errorf("error creating user")
+
+	gqlGroups := []*model.Group{}
+	for _, group := range groups {
+		gqlGroups = append(gqlGroups, toGQLGroup(group))
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub": user.Email,
-	})
+	return gqlGroups, nil
+}
 
-	tokenString, err := token.SignedString([]byte(r.Config.JWTSecret))
+// Student is the resolver for the student field.
+func (r *extensionResolver) Student(ctx context.Context, obj *model.Extension) (*model.Student, error) {
+	extension, err := getExtension(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := getStudent(r.DB, fmt.Sprintf("%d", extension.StudentID))
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLStudent(student), nil
+}
+
+// Assignment is the resolver for the assignment field.
+func (r *extensionResolver) Assignment(ctx context.Context, obj *model.Extension) (*model.Assignment, error) {
+	extension, err := getExtension(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", extension.AssignmentID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name, DueDate: int(assignment.DueDate.Unix())}, nil
+}
+
+// Class is the resolver for the class field.
+func (r *groupResolver) Class(ctx context.Context, obj *model.Group) (*model.Class, error) {
+	group, err := getGroup(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	class, err := getClass(r.DB, fmt.Sprintf("%d", group.ClassID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name}, nil
+}
+
+// Members is the resolver for the members field.
+func (r *groupResolver) Members(ctx context.Context, obj *model.Group) ([]*model.Student, error) {
+	user := r.ExtractUser(ctx)
+	if user == nil {
+		return nil, errNotAuthenticated
+	}
+
+	groupID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	students, err := r.DB.GetGroupMembers(uint(groupID))
+	if err != nil {
+		return nil, err
+	}
+
+	gqlStudents := []*model.Student{}
+	if user.IsStaff() {
+		for _, student := range students {
+			gqlStudents = append(gqlStudents, toGQLStudent(student))
+		}
+
+		return gqlStudents, nil
+	}
+
+	// Students can see who else is in their own group, but only by name.
+	member := false
+	for _, student := range students {
+		member = member || (user.StudentID != nil && *user.StudentID == student.ID)
+	}
+	if !member {
+		return nil, errNotAuthorised
+	}
+
+	for _, student := range students {
+		if student.ID == *user.StudentID {
+			gqlStudents = append(gqlStudents, toGQLStudent(student))
+			continue
+		}
+		gqlStudents = append(gqlStudents, &model.Student{ID: fmt.Sprintf("%d", student.ID), Name: student.Name})
+	}
+
+	return gqlStudents, nil
+}
+
+// CreateUnit is the resolver for the createUnit field.
+func (r *mutationResolver) CreateUnit(ctx context.Context, input model.NewUnit) (*model.Unit, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if a unit with the same name already exists
+	existingUnit, err := r.DB.GetUnitByName(input.Name)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting unit: %w", err)
+	}
+	if existingUnit != nil {
+		return nil, fmt.Errorf("unit already exists")
+	}
+
+	unit, err := r.DB.CreateUnit(input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error creating unit: %w", err)
+	}
+	if unit == nil {
+		return nil, fmt.Errorf("error creating unit")
+	}
+
+	gqlUnit := &model.Unit{ID: fmt.Sprintf("%d", unit.ID), Name: unit.Name, Classes: []*model.Class{}}
+
+	return gqlUnit, nil
+}
+
+// CreateTerm is the resolver for the createTerm field.
+func (r *mutationResolver) CreateTerm(ctx context.Context, input model.NewTerm) (*model.Term, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if input.EndDate <= input.StartDate {
+		return nil, fmt.Errorf("term must end after it starts")
+	}
+
+	existingTerm, err := r.DB.GetTermByName(name)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting term: %w", err)
+	}
+	if existingTerm != nil {
+		return nil, fmt.Errorf("term already exists")
+	}
+
+	term, err := r.DB.CreateTerm(name, time.Unix(int64(input.StartDate), 0), time.Unix(int64(input.EndDate), 0))
+	if err != nil {
+		return nil, fmt.Errorf("error creating term: %w", err)
+	}
+
+	return toGQLTerm(term), nil
+}
+
+// CreateOffering is the resolver for the createOffering field.
+func (r *mutationResolver) CreateOffering(ctx context.Context, input model.NewOffering) (*model.Offering, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unit, err := getUnit(r.DB, input.UnitID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting unit: %w", err)
+	}
+
+	term, err := getTerm(r.DB, input.TermID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting term: %w", err)
+	}
+
+	existingOffering, err := r.DB.GetOfferingForUnit(unit.ID, term.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting offering: %w", err)
+	}
+	if existingOffering != nil {
+		return nil, fmt.Errorf("%s is already offered in %s", unit.Name, term.Name)
+	}
+
+	offering, err := r.DB.CreateOffering(unit.ID, term.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating offering: %w", err)
+	}
+
+	return toGQLOffering(offering), nil
+}
+
+// CloneOffering is the resolver for the cloneOffering field.
+func (r *mutationResolver) CloneOffering(ctx context.Context, input model.CloneOffering) (*model.OfferingCloneReport, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	offering, err := getOffering(r.DB, input.OfferingID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting offering: %w", err)
+	}
+
+	term, err := getTerm(r.DB, input.TermID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting term: %w", err)
+	}
+
+	existingOffering, err := r.DB.GetOfferingForUnit(offering.UnitID, term.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting offering: %w", err)
+	}
+	if existingOffering != nil {
+		return nil, fmt.Errorf("%s is already offered in %s", offering.Unit.Name, term.Name)
+	}
+
+	offset := term.StartDate.Sub(offering.Term.StartDate)
+	if input.Offset != nil {
+		offset = time.Duration(*input.Offset) * time.Second
+	}
+
+	clone, err := r.DB.CloneOffering(offering.ID, term.ID, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error cloning offering: %w", err)
+	}
+
+	return &model.OfferingCloneReport{
+		Offering:       toGQLOffering(clone.Offering),
+		Classes:        clone.Classes,
+		Assignments:    clone.Assignments,
+		Tests:          clone.Tests,
+		RubricCriteria: clone.RubricCriteria,
+		LintRules:      clone.LintRules,
+		StarterFiles:   clone.StarterFiles,
+	}, nil
+}
+
+// CreateClass is the resolver for the createClass field.
+func (r *mutationResolver) CreateClass(ctx context.Context, input model.NewClass) (*model.Class, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unitID, err := strconv.ParseUint(input.UnitID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: Don't allow creating a class with the same name for the same unit
+
+	unit, err := getUnit(r.DB, input.UnitID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating class: %w", err)
+	}
+	if unit == nil {
+		return nil, fmt.Errorf("unit with id: %d does not exist", unitID)
+	}
+
+	var offeringID *uint
+	if input.OfferingID != nil {
+		offering, err := getOffering(r.DB, *input.OfferingID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting offering: %w", err)
+		}
+		if offering.UnitID != unit.ID {
+			return nil, fmt.Errorf("offering is not of unit %s", unit.Name)
+		}
+		offeringID = &offering.ID
+	}
+
+	class, err := r.DB.CreateClass(input.Name, uint(unitID), offeringID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating class: %w", err)
+	}
+	if class == nil {
+		return nil, nil
+	}
+
+	gqlClass := &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name}
+
+	return gqlClass, nil
+}
+
+// CreateAssignment is the resolver for the createAssignment field.
+func (r *mutationResolver) CreateAssignment(ctx context.Context, input model.NewAssignment) (*model.Assignment, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseUint(input.ClassID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := r.DB.CreateAssignment(input.Name, input.DueDate, uint(id))
+	if err != nil {
+		return nil, fmt.Errorf("error creating assignment: %w", err)
+	}
+	if assignment == nil {
+		return nil, nil
+	}
+
+	gqlAssignment := &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name}
+
+	return gqlAssignment, nil
+}
+
+// CreateTest is the resolver for the createTest field.
+func (r *mutationResolver) CreateTest(ctx context.Context, input model.NewTest) (*model.Test, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseUint(input.AssignmentID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	maxPoints, weight := 1.0, 1.0
+	if input.MaxPoints != nil {
+		maxPoints = *input.MaxPoints
+	}
+	if input.Weight != nil {
+		weight = *input.Weight
+	}
+	if maxPoints <= 0 || weight <= 0 {
+		return nil, fmt.Errorf("max points and weight must be positive")
+	}
+
+	test, err := r.DB.CreateTest(input.Name, uint(id), maxPoints, weight)
+	if err != nil {
+		return nil, fmt.Errorf("error creating test: %w", err)
+	}
+	if test == nil {
+		return nil, nil
+	}
+
+	return toGQLTest(test), nil
+}
+
+// RunTest is the resolver for the runTest field.
+func (r *mutationResolver) RunTest(ctx context.Context, testID string) (bool, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	test, err := getTest(r.DB, testID)
+	if err != nil {
+		return false, fmt.Errorf("error getting test: %w", err)
+	}
+
+	// Get assignment of test
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", test.AssignmentID))
+	if err != nil {
+		return false, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	body := map[string]string{
+		"s3KeyTestFile":    test.SourceKey(),
+		"s3KeyProjectFile": assignment.ProjectsKey(),
+	}
+
+	json, err := json.Marshal(body)
+	if err != nil {
+		return false, err
+	}
+
+	testExecutorEndpoint := r.Config.TestExecutorEndpoint
+
+	res, err := http.Post(testExecutorEndpoint, "application/json", bytes.NewBuffer(json))
+
+	if err != nil {
+		return false, fmt.Errorf("error running test: %w", err)
+	}
+
+	if res.StatusCode != 200 {
+		return false, fmt.Errorf("error running test: %w", err)
+	}
+
+	recordAuditEntity(ctx, "Test", testID)
+
+	return true, nil
+}
+
+// UpdateTestScoring is the resolver for the updateTestScoring field.
+func (r *mutationResolver) UpdateTestScoring(ctx context.Context, testID string, maxPoints float64, weight float64) (*model.Test, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxPoints <= 0 || weight <= 0 {
+		return nil, fmt.Errorf("max points and weight must be positive")
+	}
+
+	test, err := getTest(r.DB, testID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting test: %w", err)
+	}
+
+	recordAuditBefore(ctx, "Test", testID, test)
+
+	test, err = r.DB.UpdateTestScoring(test.ID, maxPoints, weight)
+	if err != nil {
+		return nil, fmt.Errorf("error updating test: %w", err)
+	}
+
+	return toGQLTest(test), nil
+}
+
+// RecordTestResults is the resolver for the recordTestResults field.
+func (r *mutationResolver) RecordTestResults(ctx context.Context, versionID string, outcomes []*model.TestOutcomeInput) (*model.Result, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := getSubmissionVersion(r.DB, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission version: %w", err)
+	}
+
+	submission, err := getSubmission(r.DB, fmt.Sprintf("%d", version.SubmissionID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+
+	tests, err := r.DB.GetTestsForAssignment(fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting tests: %w", err)
+	}
+
+	assignmentTests := map[string]bool{}
+	for _, test := range tests {
+		assignmentTests[fmt.Sprintf("%d", test.ID)] = true
+	}
+
+	testOutcomes := make([]models.TestOutcome, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		if !assignmentTests[outcome.TestID] {
+			return nil, fmt.Errorf("test %s does not belong to the submission's assignment", outcome.TestID)
+		}
+		if outcome.Points < 0 {
+			return nil, fmt.Errorf("points must not be negative")
+		}
+
+		testID, err := strconv.ParseUint(outcome.TestID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		testOutcomes = append(testOutcomes, models.TestOutcome{Points: outcome.Points, TestID: uint(testID), SubmissionVersionID: version.ID})
+	}
+
+	err = r.DB.CreateTestOutcomes(testOutcomes)
+	if err != nil {
+		return nil, fmt.Errorf("error recording test outcomes: %w", err)
+	}
+
+	// Aggregate every outcome for the version, so tests run separately add up.
+	allOutcomes, err := r.DB.GetTestOutcomesForVersion(version.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting test outcomes: %w", err)
+	}
+
+	result, err := r.DB.CreateResult(grading.Aggregate(tests, allOutcomes).Total, submission.ID, version.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating result: %w", err)
+	}
+
+	return toGQLResult(result), nil
+}
+
+// OverrideResult is the resolver for the overrideResult field.
+func (r *mutationResolver) OverrideResult(ctx context.Context, resultID string, score float64, reason string) (*model.Result, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+	if score < 0 {
+		return nil, fmt.Errorf("score must not be negative")
+	}
+
+	result, err := r.DB.GetResult(resultID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting result: %w", err)
+	}
+
+	submission, err := getSubmission(r.DB, fmt.Sprintf("%d", result.SubmissionID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+
+	// Assignments without weighted tests have no maximum to check against.
+	tests, err := r.DB.GetTestsForAssignment(fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting tests: %w", err)
+	}
+	if maxScore := grading.Aggregate(tests, nil).Max; maxScore > 0 && score > maxScore {
+		return nil, fmt.Errorf("score must not be more than the maximum score of %g", maxScore)
+	}
+
+	recordAuditBefore(ctx, "Result", resultID, result)
+
+	result, err = r.DB.OverrideResult(result.ID, score, reason, user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("error overriding result: %w", err)
+	}
+
+	return toGQLResult(result), nil
+}
+
+// CreateSubmission is the resolver for the createSubmission field.
+func (r *mutationResolver) CreateSubmission(ctx context.Context, input model.NewSubmission) (*model.Submission, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignmentID, err := strconv.ParseUint(input.AssignmentID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	// Link the submission to a known student where the identifier starts with their
+	// student number, e.g. "s0001_Alice_Penguin".
+	var studentRecordID *uint
+	student, err := r.DB.GetStudentByNumber(models.ParseStudentNumber(input.StudentID))
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting student: %w", err)
+	}
+	if student != nil {
+		studentRecordID = &student.ID
+	}
+
+	// Resubmissions add a version to the student's existing submission.
+	submission, _, _, err := r.DB.SubmitVersion(uint(assignmentID), input.StudentID, studentRecordID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating submission: %w", err)
+	}
+
+	gqlSubmission := &model.Submission{ID: fmt.Sprintf("%d", submission.ID), StudentID: submission.StudentID}
+
+	return gqlSubmission, nil
+}
+
+// CreateStudent is the resolver for the createStudent field.
+func (r *mutationResolver) CreateStudent(ctx context.Context, input model.NewStudent) (*model.Student, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.StudentNumber == "" {
+		return nil, fmt.Errorf("student number is required")
+	}
+
+	existingStudent, err := r.DB.GetStudentByNumber(input.StudentNumber)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting student: %w", err)
+	}
+	if existingStudent != nil {
+		return nil, fmt.Errorf("student already exists")
+	}
+
+	student, err := r.DB.CreateStudent(input.StudentNumber, input.Name, input.Email)
+	if err != nil {
+		return nil, fmt.Errorf("error creating student: %w", err)
+	}
+	if student == nil {
+		return nil, fmt.Errorf("error creating student")
+	}
+
+	return toGQLStudent(student), nil
+}
+
+// EnrolStudent is the resolver for the enrolStudent field.
+func (r *mutationResolver) EnrolStudent(ctx context.Context, studentID string, classID string) (*model.Student, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := getStudent(r.DB, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting student: %w", err)
+	}
+
+	class, err := getClass(r.DB, classID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting class: %w", err)
+	}
+
+	err = r.DB.EnrolStudent(student.ID, class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error enrolling student: %w", err)
+	}
+
+	return toGQLStudent(student), nil
+}
+
+// UnenrolStudent is the resolver for the unenrolStudent field.
+func (r *mutationResolver) UnenrolStudent(ctx context.Context, studentID string, classID string) (bool, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	student, err := getStudent(r.DB, studentID)
+	if err != nil {
+		return false, fmt.Errorf("error getting student: %w", err)
+	}
+
+	class, err := getClass(r.DB, classID)
+	if err != nil {
+		return false, fmt.Errorf("error getting class: %w", err)
+	}
+
+	err = r.DB.UnenrolStudent(student.ID, class.ID)
+	if err != nil {
+		return false, fmt.Errorf("error unenrolling student: %w", err)
+	}
+
+	recordAuditEntity(ctx, "Student", studentID)
+
+	return true, nil
+}
+
+// InviteStudent is the resolver for the inviteStudent field.
+func (r *mutationResolver) InviteStudent(ctx context.Context, studentID string) (*model.StudentInvite, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := getStudent(r.DB, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting student: %w", err)
+	}
+	if student.Email == "" {
+		return nil, fmt.Errorf("%s has no email to sign in with", student.StudentNumber)
+	}
+
+	code, codeHash, err := models.NewInviteCode()
+	if err != nil {
+		return nil, fmt.Errorf("error generating invite code: %w", err)
+	}
+
+	invite, err := r.DB.SetStudentInvite(student.ID, codeHash, time.Now().Add(models.InviteLifetime))
+	if err != nil {
+		return nil, fmt.Errorf("error saving invite: %w", err)
+	}
+
+	// The result has no ID, so the code isn't stored in the audit log.
+	recordAuditEntity(ctx, "Student", studentID)
+
+	return &model.StudentInvite{
+		Student:   toGQLStudent(student),
+		Code:      code,
+		ExpiresAt: int(invite.ExpiresAt.Unix()),
+	}, nil
+}
+
+// CreateGroup is the resolver for the createGroup field.
+func (r *mutationResolver) CreateGroup(ctx context.Context, input model.NewGroup) (*model.Group, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	class, err := getClass(r.DB, input.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting class: %w", err)
+	}
+
+	groups, err := r.DB.GetGroupsForClass(class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting groups: %w", err)
+	}
+	for _, group := range groups {
+		if strings.EqualFold(group.Name, name) {
+			return nil, fmt.Errorf("class already has a group called %s", group.Name)
+		}
+	}
+
+	members, err := getGroupMembers(r.DB, &models.Group{ClassID: class.ID}, input.StudentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := r.DB.CreateGroup(name, class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating group: %w", err)
+	}
+
+	if len(members) > 0 {
+		err = r.DB.SetGroupMembers(group.ID, class.ID, members)
+		if err != nil {
+			return nil, fmt.Errorf("error setting group members: %w", err)
+		}
+	}
+
+	return toGQLGroup(group), nil
+}
+
+// SetGroupMembers is the resolver for the setGroupMembers field.
+func (r *mutationResolver) SetGroupMembers(ctx context.Context, groupID string, studentIDs []string) (*model.Group, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := getGroup(r.DB, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting group: %w", err)
+	}
+
+	members, err := getGroupMembers(r.DB, group, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Members share the grade of the group's submissions, so they can't change after
+	// the group has submitted.
+	err = checkGroupUnsubmitted(r.DB, group)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAuditEntity(ctx, "Group", groupID)
+
+	err = r.DB.SetGroupMembers(group.ID, group.ClassID, members)
+	if err != nil {
+		return nil, fmt.Errorf("error setting group members: %w", err)
+	}
+
+	return toGQLGroup(group), nil
+}
+
+// DeleteGroup is the resolver for the deleteGroup field.
+func (r *mutationResolver) DeleteGroup(ctx context.Context, id string) (bool, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	group, err := getGroup(r.DB, id)
+	if err != nil {
+		return false, fmt.Errorf("error getting group: %w", err)
+	}
+
+	err = checkGroupUnsubmitted(r.DB, group)
+	if err != nil {
+		return false, err
+	}
+
+	recordAuditBefore(ctx, "Group", id, group)
+
+	err = r.DB.DeleteGroup(group.ID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting group: %w", err)
+	}
+
+	return true, nil
+}
+
+// ImportRoster is the resolver for the importRoster field.
+func (r *mutationResolver) ImportRoster(ctx context.Context, classID string, file graphql.Upload) (*model.RosterImportReport, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	class, err := getClass(r.DB, classID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting class: %w", err)
+	}
+
+	report, err := roster.Import(r.DB, class.ID, file.File)
+	if err != nil {
+		return nil, fmt.Errorf("error importing roster: %w", err)
+	}
+
+	gqlReport := &model.RosterImportReport{
+		Created: report.Count(roster.RowStatusCreated),
+		Updated: report.Count(roster.RowStatusUpdated),
+		Skipped: report.Count(roster.RowStatusSkipped),
+		Errored: report.Count(roster.RowStatusErrored),
+		Rows:    []*model.RosterRowReport{},
+	}
+	for _, row := range report.Rows {
+		gqlReport.Rows = append(gqlReport.Rows, &model.RosterRowReport{
+			Line:          row.Line,
+			StudentNumber: row.StudentNumber,
+			Status:        model.RosterRowStatus(row.Status),
+			Message:       optionalString(row.Message),
+		})
+	}
+
+	recordAuditEntity(ctx, "Class", classID)
+
+	return gqlReport, nil
+}
+
+// ImportSubmissions is the resolver for the importSubmissions field.
+func (r *mutationResolver) ImportSubmissions(ctx context.Context, assignmentID string, file graphql.Upload) (*model.SubmissionImportReport, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	report, err := submissions.Import(r.DB, assignment, file.File)
+	if err != nil {
+		return nil, fmt.Errorf("error importing submissions: %w", err)
+	}
+
+	// The files are already stored if analysis fails, so the report is returned along
+	// with the error and analysis can be retried with analyseSubmissions.
+	err = analyseImport(r.DB, assignment.ID, report)
+	if err != nil {
+		graphql.AddError(ctx, fmt.Errorf("error analysing submissions: %w", err))
+	}
+
+	gqlReport := &model.SubmissionImportReport{
+		Created:     report.Count(submissions.StatusCreated),
+		Resubmitted: report.Count(submissions.StatusResubmitted),
+		Duplicates:  report.Count(submissions.StatusDuplicate),
+		Unmatched:   report.Count(submissions.StatusUnmatched),
+		Errored:     report.Count(submissions.StatusErrored),
+		Rows:        []*model.SubmissionImportRow{},
+	}
+	for _, folder := range report.Folders {
+		row := &model.SubmissionImportRow{
+			Folder:        folder.Folder,
+			StudentNumber: folder.StudentNumber,
+			Status:        model.SubmissionImportStatus(folder.Status),
+			Files:         folder.Files,
+			Message:       optionalString(folder.Message),
+		}
+		if folder.Submission != nil {
+			row.Submission = &model.Submission{ID: fmt.Sprintf("%d", folder.Submission.ID), StudentID: folder.Submission.StudentID}
+		}
+		gqlReport.Rows = append(gqlReport.Rows, row)
+	}
+
+	recordAuditEntity(ctx, "Assignment", assignmentID)
+
+	return gqlReport, nil
+}
+
+// UploadStarterCode is the resolver for the uploadStarterCode field.
+func (r *mutationResolver) UploadStarterCode(ctx context.Context, assignmentID string, files []*graphql.Upload) (*model.Assignment, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	var starterFiles []models.StarterFile
+	paths := map[string]bool{}
+	for _, file := range files {
+		parsed, err := starter.Parse(file.Filename, file.File)
+		if err != nil {
+			return nil, fmt.Errorf("error reading starter code: %w", err)
+		}
+
+		for _, starterFile := range parsed {
+			if paths[starterFile.Path] {
+				return nil, fmt.Errorf("duplicate starter file %s", starterFile.Path)
+			}
+			paths[starterFile.Path] = true
+		}
+		starterFiles = append(starterFiles, parsed...)
+	}
+
+	recordAuditEntity(ctx, "Assignment", assignmentID)
+
+	_, err = r.DB.SetStarterFiles(assignment.ID, starterFiles)
+	if err != nil {
+		return nil, fmt.Errorf("error saving starter code: %w", err)
+	}
+
+	return &model.Assignment{
+		ID:      fmt.Sprintf("%d", assignment.ID),
+		Name:    assignment.Name,
+		DueDate: int(assignment.DueDate.Unix()),
+	}, nil
+}
+
+// AnalyseSubmissions is the resolver for the analyseSubmissions field.
+func (r *mutationResolver) AnalyseSubmissions(ctx context.Context, assignmentID string) (int, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	analysed, err := metrics.AnalyseAssignment(r.DB, assignment.ID)
+	if err != nil {
+		return 0, fmt.Errorf("error analysing submissions: %w", err)
+	}
+
+	return analysed, nil
+}
+
+// SetLintRules is the resolver for the setLintRules field.
+func (r *mutationResolver) SetLintRules(ctx context.Context, assignmentID string, rules []*model.LintRuleInput) (*model.Assignment, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	lintRules := make([]models.LintRule, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.Kind.IsValid() {
+			return nil, fmt.Errorf("invalid lint rule kind %s", rule.Kind)
+		}
+
+		lintRule := models.LintRule{
+			Kind:     models.LintRuleKind(rule.Kind),
+			Severity: models.LintSeverityWarning,
+			Message:  strings.TrimSpace(stringValue(rule.Message)),
+		}
+		if rule.Severity != nil {
+			if !rule.Severity.IsValid() {
+				return nil, fmt.Errorf("invalid lint severity %s", *rule.Severity)
+			}
+			lintRule.Severity = models.LintSeverity(*rule.Severity)
+		}
+
+		switch lintRule.Kind {
+		case models.LintMaxFunctionLength, models.LintMaxComplexity:
+			if rule.Limit == nil || *rule.Limit < 1 {
+				return nil, fmt.Errorf("%s rules must have a limit of at least 1", rule.Kind)
+			}
+			lintRule.Limit = *rule.Limit
+		case models.LintBannedAPI, models.LintRequiredClass:
+			for _, name := range rule.Names {
+				if name = strings.TrimSpace(name); name != "" {
+					lintRule.Names = append(lintRule.Names, name)
+				}
+			}
+			if len(lintRule.Names) == 0 {
+				return nil, fmt.Errorf("%s rules must have at least one name", rule.Kind)
+			}
+		}
+
+		if rule.Deduction != nil {
+			lintRule.Deduction = *rule.Deduction
+		}
+		if rule.MaxDeduction != nil {
+			lintRule.MaxDeduction = *rule.MaxDeduction
+		}
+		if lintRule.Deduction < 0 || lintRule.MaxDeduction < 0 {
+			return nil, fmt.Errorf("deductions must not be negative")
+		}
+
+		lintRules = append(lintRules, lintRule)
+	}
+
+	_, err = r.DB.SetLintRules(assignment.ID, lintRules)
+	if err != nil {
+		return nil, fmt.Errorf("error setting lint rules: %w", err)
+	}
+
+	recordAuditEntity(ctx, "Assignment", assignmentID)
+
+	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name, DueDate: int(assignment.DueDate.Unix())}, nil
+}
+
+// UpdateAttemptPolicy is the resolver for the updateAttemptPolicy field.
+func (r *mutationResolver) UpdateAttemptPolicy(ctx context.Context, assignmentID string, policy model.AttemptPolicy) (*model.Assignment, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.IsValid() {
+		return nil, fmt.Errorf("invalid attempt policy %s", policy)
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	recordAuditBefore(ctx, "Assignment", assignmentID, assignment)
+
+	assignment, err = r.DB.UpdateAttemptPolicy(assignment.ID, models.AttemptPolicy(policy))
+	if err != nil {
+		return nil, fmt.Errorf("error updating attempt policy: %w", err)
+	}
+
+	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name, DueDate: int(assignment.DueDate.Unix())}, nil
+}
+
+// UpdateLatePolicy is the resolver for the updateLatePolicy field.
+func (r *mutationResolver) UpdateLatePolicy(ctx context.Context, assignmentID string, policy model.LatePolicyInput) (*model.Assignment, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.GracePeriod < 0 {
+		return nil, fmt.Errorf("grace period must not be negative")
+	}
+	if policy.PenaltyPerDay < 0 || policy.PenaltyPerDay > 100 || policy.MaxPenalty < 0 || policy.MaxPenalty > 100 {
+		return nil, fmt.Errorf("penalties must be between 0 and 100 percent")
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	latePolicy := models.LatePolicy{
+		GracePeriod:   time.Duration(policy.GracePeriod) * time.Second,
+		PenaltyPerDay: policy.PenaltyPerDay,
+		MaxPenalty:    policy.MaxPenalty,
+	}
+	if policy.Cutoff != nil {
+		cutoff := time.Unix(int64(*policy.Cutoff), 0)
+		if cutoff.Before(assignment.DueDate) {
+			return nil, fmt.Errorf("cutoff must not be before the due date")
+		}
+		latePolicy.Cutoff = &cutoff
+	}
+
+	recordAuditBefore(ctx, "Assignment", assignmentID, assignment)
+
+	assignment, err = r.DB.UpdateLatePolicy(assignment.ID, latePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("error updating late policy: %w", err)
+	}
+
+	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name, DueDate: int(assignment.DueDate.Unix())}, nil
+}
+
+// UpdateFeedbackRelease is the resolver for the updateFeedbackRelease field.
+func (r *mutationResolver) UpdateFeedbackRelease(ctx context.Context, assignmentID string, status model.FeedbackStatus, releaseAt *int) (*model.Assignment, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid feedback status %s", status)
+	}
+	if releaseAt != nil && status != model.FeedbackStatusReleased {
+		return nil, fmt.Errorf("a release time can only be set when releasing feedback")
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	// Feedback can't be released until moderation has agreed on every sampled mark.
+	if status == model.FeedbackStatusReleased {
+		samples, err := r.DB.GetModerationSamples(assignment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting moderation samples: %w", err)
+		}
+		for _, sample := range samples {
+			if sample.Status == models.ModerationStatusAwaitingSecondMark || sample.Status == models.ModerationStatusDiscrepancy {
+				return nil, fmt.Errorf("feedback can't be released until moderation is finished")
+			}
+		}
+	}
+
+	release := models.FeedbackRelease{Status: models.FeedbackStatus(status)}
+	if releaseAt != nil {
+		at := time.Unix(int64(*releaseAt), 0)
+		release.ReleaseAt = &at
+	}
+
+	recordAuditBefore(ctx, "Assignment", assignmentID, assignment)
+
+	assignment, err = r.DB.UpdateFeedbackRelease(assignment.ID, release)
+	if err != nil {
+		return nil, fmt.Errorf("error updating feedback release: %w", err)
+	}
+
+	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name, DueDate: int(assignment.DueDate.Unix())}, nil
+}
+
+// UpdateGroupWork is the resolver for the updateGroupWork field.
+func (r *mutationResolver) UpdateGroupWork(ctx context.Context, assignmentID string, groupWork bool) (*model.Assignment, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	recordAuditBefore(ctx, "Assignment", assignmentID, assignment)
+
+	assignment, err = r.DB.UpdateGroupWork(assignment.ID, groupWork)
+	if err != nil {
+		return nil, fmt.Errorf("error updating group work: %w", err)
+	}
+
+	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name, DueDate: int(assignment.DueDate.Unix())}, nil
+}
+
+// SetSubmissionGroup is the resolver for the setSubmissionGroup field.
+func (r *mutationResolver) SetSubmissionGroup(ctx context.Context, submissionID string, groupID *string) (*model.Submission, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := getSubmission(r.DB, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+
+	var newGroupID *uint
+	if groupID != nil {
+		assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+		if err != nil {
+			return nil, fmt.Errorf("error getting assignment: %w", err)
+		}
+		if !assignment.GroupWork {
+			return nil, fmt.Errorf("assignment is not group work")
+		}
+
+		group, err := getGroup(r.DB, *groupID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting group: %w", err)
+		}
+		if group.ClassID != assignment.ClassID {
+			return nil, fmt.Errorf("group is not in the assignment's class")
+		}
+
+		// A group makes a single submission, which its members resubmit to.
+		existing, err := r.DB.GetSubmissionForGroup(assignment.ID, group.ID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return nil, fmt.Errorf("error getting submission: %w", err)
+		}
+		if existing != nil && existing.ID != submission.ID {
+			return nil, fmt.Errorf("group %s already has a submission for the assignment", group.Name)
+		}
+
+		newGroupID = &group.ID
+	}
+
+	recordAuditBefore(ctx, "Submission", submissionID, submission)
+
+	submission, err = r.DB.SetSubmissionGroup(submission.ID, newGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("error setting submission group: %w", err)
+	}
+
+	return &model.Submission{ID: fmt.Sprintf("%d", submission.ID), StudentID: submission.StudentID}, nil
+}
+
+// AdjustMemberGrade is the resolver for the adjustMemberGrade field.
+func (r *mutationResolver) AdjustMemberGrade(ctx context.Context, submissionID string, studentID string, marks float64, reason string) (*model.MemberGrade, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if marks != 0 && strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	submission, err := getSubmission(r.DB, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+	if submission.GroupID == nil {
+		return nil, fmt.Errorf("submission was not made by a group")
+	}
+
+	student, err := getStudent(r.DB, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting student: %w", err)
+	}
+
+	members, err := r.DB.GetGroupMembers(*submission.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting group members: %w", err)
+	}
+
+	isMember := false
+	for _, member := range members {
+		isMember = isMember || member.ID == student.ID
+	}
+	if !isMember {
+		return nil, fmt.Errorf("student is not a member of the group")
+	}
+
+	existing, err := r.DB.GetMemberAdjustment(submission.ID, student.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting adjustment: %w", err)
+	}
+	if existing != nil {
+		recordAuditBefore(ctx, "MemberAdjustment", fmt.Sprintf("%d", existing.ID), existing)
+	}
+
+	adjustment, err := r.DB.AdjustMemberGrade(models.MemberAdjustment{
+		SubmissionID: submission.ID,
+		StudentID:    student.ID,
+		Marks:        marks,
+		Reason:       strings.TrimSpace(reason),
+		AdjustedBy:   user.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adjusting grade: %w", err)
+	}
+	if existing == nil {
+		recordAuditEntity(ctx, "MemberAdjustment", fmt.Sprintf("%d", adjustment.ID))
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	grade, err := grading.LoadGrade(r.DB, assignment, submission)
+	if err != nil {
+		return nil, err
+	}
+	grade.Adjustment = adjustment.Marks
+
+	return toGQLMemberGrade(grading.MemberGrade{Student: student, Grade: grade, Reason: adjustment.Reason}), nil
+}
+
+// SetRubric is the resolver for the setRubric field.
+func (r *mutationResolver) SetRubric(ctx context.Context, assignmentID string, criteria []*model.RubricCriterionInput) (*model.Assignment, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	rubricCriteria := make([]models.RubricCriterion, 0, len(criteria))
+	for _, criterion := range criteria {
+		if strings.TrimSpace(criterion.Name) == "" {
+			return nil, fmt.Errorf("criterion name is required")
+		}
+		if len(criterion.Levels) == 0 {
+			return nil, fmt.Errorf("criterion %s must have at least one level", criterion.Name)
+		}
+
+		rubricCriterion := models.RubricCriterion{
+			Name:        strings.TrimSpace(criterion.Name),
+			Description: strings.TrimSpace(stringValue(criterion.Description)),
+		}
+		for _, level := range criterion.Levels {
+			if strings.TrimSpace(level.Name) == "" {
+				return nil, fmt.Errorf("level name is required")
+			}
+			if level.Points < 0 {
+				return nil, fmt.Errorf("level points must not be negative")
+			}
+
+			rubricCriterion.Levels = append(rubricCriterion.Levels, models.RubricLevel{
+				Name:        strings.TrimSpace(level.Name),
+				Description: strings.TrimSpace(stringValue(level.Description)),
+				Points:      level.Points,
+			})
+		}
+
+		rubricCriteria = append(rubricCriteria, rubricCriterion)
+	}
+
+	// Changing the rubric would invalidate marks that have already been given.
+	marked, err := r.DB.HasRubricMarks(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking rubric marks: %w", err)
+	}
+	if marked {
+		return nil, fmt.Errorf("rubric can't be changed once submissions have been marked")
+	}
+
+	existing, err := r.DB.GetRubric(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rubric: %w", err)
+	}
+
+	recordAuditBefore(ctx, "Assignment", assignmentID, existing)
+
+	_, err = r.DB.SetRubric(assignment.ID, rubricCriteria)
+	if err != nil {
+		return nil, fmt.Errorf("error setting rubric: %w", err)
+	}
+
+	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name, DueDate: int(assignment.DueDate.Unix())}, nil
+}
+
+// MarkSubmission is the resolver for the markSubmission field.
+func (r *mutationResolver) MarkSubmission(ctx context.Context, submissionID string, selections []*model.RubricSelection) (*model.Submission, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := getSubmission(r.DB, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+
+	criteria, err := r.DB.GetRubric(submission.AssignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rubric: %w", err)
+	}
+
+	rubric := map[string]*models.RubricCriterion{}
+	for _, criterion := range criteria {
+		rubric[fmt.Sprintf("%d", criterion.ID)] = criterion
+	}
+
+	marks := make([]models.RubricMark, 0, len(selections))
+	seen := map[string]bool{}
+	for _, selection := range selections {
+		criterion, ok := rubric[selection.CriterionID]
+		if !ok {
+			return nil, fmt.Errorf("criterion %s is not part of the assignment's rubric", selection.CriterionID)
+		}
+		if seen[selection.CriterionID] {
+			return nil, fmt.Errorf("criterion %s is selected more than once", selection.CriterionID)
+		}
+		seen[selection.CriterionID] = true
+
+		var levelID uint
+		for _, level := range criterion.Levels {
+			if fmt.Sprintf("%d", level.ID) == selection.LevelID {
+				levelID = level.ID
+			}
+		}
+		if levelID == 0 {
+			return nil, fmt.Errorf("level %s is not a level of criterion %s", selection.LevelID, selection.CriterionID)
+		}
+
+		marks = append(marks, models.RubricMark{
+			SubmissionID: submission.ID,
+			CriterionID:  criterion.ID,
+			LevelID:      levelID,
+			Comment:      strings.TrimSpace(stringValue(selection.Comment)),
+			MarkedBy:     user.Email,
+		})
+	}
+
+	existing, err := r.DB.GetRubricMarks(submission.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rubric marks: %w", err)
+	}
+
+	recordAuditBefore(ctx, "Submission", submissionID, existing)
+
+	err = r.DB.SaveRubricMarks(marks)
+	if err != nil {
+		return nil, fmt.Errorf("error saving rubric marks: %w", err)
+	}
+
+	return &model.Submission{ID: fmt.Sprintf("%d", submission.ID), StudentID: submission.StudentID}, nil
+}
+
+// CreateCodeComment is the resolver for the createCodeComment field.
+func (r *mutationResolver) CreateCodeComment(ctx context.Context, input model.NewCodeComment) (*model.CodeComment, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := getSubmissionVersion(r.DB, input.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission version: %w", err)
+	}
+
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, fmt.Errorf("comment body is required")
+	}
+
+	endLine := input.StartLine
+	if input.EndLine != nil {
+		endLine = *input.EndLine
+	}
+	if input.StartLine < 1 || endLine < input.StartLine {
+		return nil, fmt.Errorf("invalid line range %d-%d", input.StartLine, endLine)
+	}
+
+	files, err := r.DB.GetSubmissionFiles(version.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission files: %w", err)
+	}
+
+	var file *models.SubmissionFile
+	for _, submissionFile := range files {
+		if submissionFile.Path == input.Path {
+			file = submissionFile
+			break
+		}
+	}
+	if file == nil {
+		return nil, fmt.Errorf("file %s is not part of version %d", input.Path, version.Number)
+	}
+	if lines := len(diff.SplitLines(string(file.Content))); endLine > lines {
+		return nil, fmt.Errorf("line %d is past the end of %s, which has %d lines", endLine, input.Path, lines)
+	}
+
+	comment, err := r.DB.CreateCodeComment(models.CodeComment{
+		Path:                input.Path,
+		StartLine:           input.StartLine,
+		EndLine:             endLine,
+		Author:              user.Email,
+		Body:                body,
+		SubmissionID:        version.SubmissionID,
+		SubmissionVersionID: version.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating comment: %w", err)
+	}
+
+	return toGQLCodeComment(comment, version), nil
+}
+
+// UpdateCodeComment is the resolver for the updateCodeComment field.
+func (r *mutationResolver) UpdateCodeComment(ctx context.Context, id string, body *string, resolved *bool) (*model.CodeComment, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := getCodeComment(r.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting comment: %w", err)
+	}
+
+	newBody, newResolved := existing.Body, existing.Resolved
+	if body != nil {
+		if existing.Author != user.Email {
+			return nil, fmt.Errorf("only the author of a comment can edit it")
+		}
+
+		newBody = strings.TrimSpace(*body)
+		if newBody == "" {
+			return nil, fmt.Errorf("comment body is required")
+		}
+	}
+	if resolved != nil {
+		newResolved = *resolved
+	}
+
+	recordAuditBefore(ctx, "CodeComment", id, existing)
+
+	comment, err := r.DB.UpdateCodeComment(existing.ID, newBody, newResolved)
+	if err != nil {
+		return nil, fmt.Errorf("error updating comment: %w", err)
+	}
+
+	version, err := getSubmissionVersion(r.DB, fmt.Sprintf("%d", comment.SubmissionVersionID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission version: %w", err)
+	}
+
+	return toGQLCodeComment(comment, version), nil
+}
+
+// DeleteCodeComment is the resolver for the deleteCodeComment field.
+func (r *mutationResolver) DeleteCodeComment(ctx context.Context, id string) (bool, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	comment, err := getCodeComment(r.DB, id)
+	if err != nil {
+		return false, fmt.Errorf("error getting comment: %w", err)
+	}
+	if comment.Author != user.Email {
+		return false, fmt.Errorf("only the author of a comment can delete it")
+	}
+
+	recordAuditBefore(ctx, "CodeComment", id, comment)
+
+	err = r.DB.DeleteCodeComment(comment.ID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting comment: %w", err)
+	}
+
+	return true, nil
+}
+
+// GrantExtension is the resolver for the grantExtension field.
+func (r *mutationResolver) GrantExtension(ctx context.Context, input model.NewExtension) (*model.Extension, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(input.Reason) == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	student, err := getStudent(r.DB, input.StudentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting student: %w", err)
+	}
+
+	assignment, err := getAssignment(r.DB, input.AssignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	dueDate := time.Unix(int64(input.DueDate), 0)
+	if !dueDate.After(assignment.DueDate) {
+		return nil, fmt.Errorf("extended due date must be after the assignment's due date")
+	}
+
+	err = checkEnrolled(r.DB, assignment.ClassID, student)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := r.DB.GetExtensionForStudent(student.ID, assignment.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error getting extension: %w", err)
+	}
+	if existing != nil {
+		recordAuditBefore(ctx, "Extension", fmt.Sprintf("%d", existing.ID), existing)
+	}
+
+	extension, err := r.DB.GrantExtension(models.Extension{
+		StudentID:    student.ID,
+		AssignmentID: assignment.ID,
+		DueDate:      dueDate,
+		Reason:       strings.TrimSpace(input.Reason),
+		ApprovedBy:   user.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error granting extension: %w", err)
+	}
+
+	return toGQLExtension(extension), nil
+}
+
+// RevokeExtension is the resolver for the revokeExtension field.
+func (r *mutationResolver) RevokeExtension(ctx context.Context, studentID string, assignmentID string) (bool, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	student, err := getStudent(r.DB, studentID)
+	if err != nil {
+		return false, fmt.Errorf("error getting student: %w", err)
+	}
+
+	assignment, err := getAssignment(r.DB, assignmentID)
+	if err != nil {
+		return false, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	extension, err := r.DB.GetExtensionForStudent(student.ID, assignment.ID)
+	if err != nil {
+		return false, fmt.Errorf("error getting extension: %w", err)
+	}
+
+	recordAuditBefore(ctx, "Extension", fmt.Sprintf("%d", extension.ID), extension)
+
+	err = r.DB.RevokeExtension(student.ID, assignment.ID)
+	if err != nil {
+		return false, fmt.Errorf("error revoking extension: %w", err)
+	}
+
+	return true, nil
+}
+
+// ExportAssignmentGrades is the resolver for the exportAssignmentGrades field.
+func (r *mutationResolver) ExportAssignmentGrades(ctx context.Context, assignmentID string, format model.GradeExportFormat) (*model.GradeExport, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gradebook, err := export.AssignmentGradebook(r.DB, assignmentID, user)
+	if err != nil {
+		return nil, fmt.Errorf("error building gradebook: %w", err)
+	}
+
+	recordAuditEntity(ctx, "Assignment", assignmentID)
+
+	return toGQLGradeExport(gradebook, format)
+}
+
+// ExportClassGrades is the resolver for the exportClassGrades field.
+func (r *mutationResolver) ExportClassGrades(ctx context.Context, classID string, format model.GradeExportFormat) (*model.GradeExport, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gradebook, err := export.ClassGradebook(r.DB, classID, user)
+	if err != nil {
+		return nil, fmt.Errorf("error building gradebook: %w", err)
+	}
+
+	recordAuditEntity(ctx, "Class", classID)
+
+	return toGQLGradeExport(gradebook, format)
+}
+
+// AllocateSubmissions is the resolver for the allocateSubmissions field.
+func (r *mutationResolver) AllocateSubmissions(ctx context.Context, submissionIDs []string, marker string) ([]*model.MarkingAllocation, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(submissionIDs) == 0 {
+		return nil, fmt.Errorf("no submissions to allocate")
+	}
+
+	if _, err := getMarker(r.DB, marker); err != nil {
+		return nil, err
+	}
+
+	allocations := []models.MarkingAllocation{}
+	for _, submissionID := range submissionIDs {
+		submission, err := getSubmission(r.DB, submissionID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting submission: %w", err)
+		}
+
+		allocations = append(allocations, models.MarkingAllocation{
+			SubmissionID: submission.ID,
+			Submission:   *submission,
+			AssignmentID: submission.AssignmentID,
+			Marker:       marker,
+		})
+	}
+
+	saved, err := r.DB.AllocateSubmissions(allocations)
+	if err != nil {
+		return nil, fmt.Errorf("error allocating submissions: %w", err)
+	}
+
+	gqlAllocations := []*model.MarkingAllocation{}
+	for _, allocation := range saved {
+		gqlAllocations = append(gqlAllocations, toGQLMarkingAllocation(allocation))
+	}
+
+	return gqlAllocations, nil
+}
+
+// AllocateMarkers is the resolver for the allocateMarkers field.
+func (r *mutationResolver) AllocateMarkers(ctx context.Context, input model.AllocateMarkers) (*model.AllocationReport, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, input.AssignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	submissions, err := r.DB.GetSubmissionsForAssignment(input.AssignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submissions: %w", err)
+	}
+
+	existing, err := r.DB.GetMarkingAllocationsForAssignment(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting allocations: %w", err)
+	}
+
+	var allocations []models.MarkingAllocation
+	unallocated := []*models.Submission{}
+	switch input.Strategy {
+	case model.AllocationStrategyRoundRobin:
+		if len(input.Markers) == 0 {
+			return nil, fmt.Errorf("markers are required to allocate round robin")
+		}
+
+		markers, err := getMarkers(r.DB, input.Markers)
+		if err != nil {
+			return nil, err
+		}
+
+		allocations = marking.RoundRobin(submissions, markers, existing)
+	case model.AllocationStrategyByClass:
+		if len(input.ClassMarkers) == 0 {
+			return nil, fmt.Errorf("class markers are required to allocate by class")
+		}
+
+		studentMarkers := map[uint]string{}
+		for _, classMarker := range input.ClassMarkers {
+			if _, err := getMarker(r.DB, classMarker.Marker); err != nil {
+				return nil, err
+			}
+
+			class, err := getClass(r.DB, classMarker.ClassID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting class: %w", err)
+			}
+
+			students, err := r.DB.GetStudentsForClass(class.ID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting students: %w", err)
+			}
+
+			for _, student := range students {
+				if _, ok := studentMarkers[student.ID]; !ok {
+					studentMarkers[student.ID] = classMarker.Marker
+				}
+			}
+		}
+
+		allocations, unallocated = marking.ByClass(submissions, studentMarkers, existing)
+	default:
+		return nil, fmt.Errorf("unknown allocation strategy %s", input.Strategy)
+	}
+
+	saved, err := r.DB.AllocateSubmissions(allocations)
+	if err != nil {
+		return nil, fmt.Errorf("error allocating submissions: %w", err)
+	}
+
+	recordAuditEntity(ctx, "Assignment", input.AssignmentID)
+
+	report := &model.AllocationReport{Allocations: []*model.MarkingAllocation{}, Unallocated: []*model.Submission{}}
+	for _, allocation := range saved {
+		report.Allocations = append(report.Allocations, toGQLMarkingAllocation(allocation))
+	}
+	for _, submission := range unallocated {
+		report.Unallocated = append(report.Unallocated, &model.Submission{ID: fmt.Sprintf("%d", submission.ID), StudentID: submission.StudentID})
+	}
+
+	return report, nil
+}
+
+// UpdateMarkingStatus is the resolver for the updateMarkingStatus field.
+func (r *mutationResolver) UpdateMarkingStatus(ctx context.Context, submissionID string, status model.MarkingStatus) (*model.MarkingAllocation, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := getSubmission(r.DB, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+
+	allocation, err := r.DB.GetMarkingAllocation(submission.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, fmt.Errorf("submission has not been allocated a marker")
+		}
+		return nil, fmt.Errorf("error getting allocation: %w", err)
+	}
+
+	// Admins can update any submission, e.g. to reopen one marked in error.
+	if allocation.Marker != user.Email && user.Role != models.UserRoleAdmin {
+		return nil, fmt.Errorf("submission is allocated to another marker")
+	}
+
+	allocation, err = r.DB.UpdateMarkingStatus(submission.ID, models.MarkingStatus(status))
+	if err != nil {
+		return nil, fmt.Errorf("error updating marking status: %w", err)
+	}
+
+	return toGQLMarkingAllocation(allocation), nil
+}
+
+// SampleForModeration is the resolver for the sampleForModeration field.
+func (r *mutationResolver) SampleForModeration(ctx context.Context, input model.ModerationSampling) (*model.Moderation, error) {
+	_, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if (input.Size == nil) == (input.Percentage == nil) {
+		return nil, fmt.Errorf("give either a sample size or a percentage")
+	}
+	if input.Size != nil && *input.Size <= 0 {
+		return nil, fmt.Errorf("sample size must be positive")
+	}
+	if input.Percentage != nil && (*input.Percentage <= 0 || *input.Percentage > 100) {
+		return nil, fmt.Errorf("percentage must be more than 0 and at most 100")
+	}
+	if input.Threshold < 0 {
+		return nil, fmt.Errorf("threshold must not be negative")
+	}
+	if len(input.SecondMarkers) == 0 {
+		return nil, fmt.Errorf("second markers are required")
+	}
+
+	assignment, err := getAssignment(r.DB, input.AssignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	existing, err := r.DB.GetModerationSamples(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting moderation samples: %w", err)
+	}
+	for _, sample := range existing {
+		if sample.Status != models.ModerationStatusAwaitingSecondMark {
+			return nil, fmt.Errorf("the sample can't be replaced once second marks have been entered")
+		}
+	}
+
+	markers, err := getMarkers(r.DB, input.SecondMarkers)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := r.DB.GetSubmissionsForAssignment(input.AssignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submissions: %w", err)
+	}
+
+	allocations, err := r.DB.GetMarkingAllocationsForAssignment(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting allocations: %w", err)
+	}
+
+	size := 0
+	if input.Size != nil {
+		size = *input.Size
+	} else {
+		size = int(math.Ceil(*input.Percentage / 100 * float64(len(submissions))))
+	}
+
+	sample := marking.Sample(submissions, size, rand.New(rand.NewSource(time.Now().UnixNano())))
+	samples, err := marking.AssignSecondMarkers(sample, markers, allocations)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := r.DB.SetModerationSample(assignment.ID, input.Threshold, samples)
+	if err != nil {
+		return nil, fmt.Errorf("error saving moderation sample: %w", err)
+	}
+
+	recordAuditEntity(ctx, "Assignment", input.AssignmentID)
+
+	return toGQLModeration(input.Threshold, saved), nil
+}
+
+// EnterSecondMark is the resolver for the enterSecondMark field.
+func (r *mutationResolver) EnterSecondMark(ctx context.Context, submissionID string, mark float64) (*model.ModerationSample, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := getSubmission(r.DB, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+
+	sample, err := r.DB.GetModerationSample(submission.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, fmt.Errorf("submission was not sampled for moderation")
+		}
+		return nil, fmt.Errorf("error getting moderation sample: %w", err)
+	}
+	if sample.SecondMarker != user.Email {
+		return nil, fmt.Errorf("submission is being second marked by another marker")
+	}
+	if sample.Status == models.ModerationStatusResolved {
+		return nil, fmt.Errorf("moderation of the submission has already been resolved")
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	// The first mark is taken before any late penalty, which isn't a matter of judgement.
+	grade, err := grading.LoadGrade(r.DB, assignment, submission)
+	if err != nil {
+		return nil, err
+	}
+	if mark < 0 || mark > grade.Max() {
+		return nil, fmt.Errorf("mark must be between 0 and %g", grade.Max())
+	}
+
+	status := marking.Compare(grade.Total(), mark, assignment.ModerationThreshold)
+	sample, err = r.DB.EnterSecondMark(submission.ID, grade.Total(), mark, status)
+	if err != nil {
+		return nil, fmt.Errorf("error entering second mark: %w", err)
+	}
+
+	return toGQLModerationSample(sample), nil
+}
+
+// ResolveModeration is the resolver for the resolveModeration field.
+func (r *mutationResolver) ResolveModeration(ctx context.Context, submissionID string, agreedMark float64, note string) (*model.ModerationSample, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != models.UserRoleAdmin {
+		return nil, fmt.Errorf("you must be an admin to resolve moderation")
+	}
+
+	if strings.TrimSpace(note) == "" {
+		return nil, fmt.Errorf("note is required")
+	}
+
+	submission, err := getSubmission(r.DB, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+
+	sample, err := r.DB.GetModerationSample(submission.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, fmt.Errorf("submission was not sampled for moderation")
+		}
+		return nil, fmt.Errorf("error getting moderation sample: %w", err)
+	}
+	if sample.Status != models.ModerationStatusDiscrepancy && sample.Status != models.ModerationStatusResolved {
+		return nil, fmt.Errorf("only discrepancies between marks can be resolved")
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	maxGrade, err := grading.LoadMaxGrade(r.DB, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if agreedMark < 0 || agreedMark > maxGrade {
+		return nil, fmt.Errorf("agreed mark must be between 0 and %g", maxGrade)
+	}
+
+	recordAuditBefore(ctx, "ModerationSample", fmt.Sprintf("%d", sample.ID), sample)
+
+	sample, err = r.DB.ResolveModeration(submission.ID, agreedMark, user.Email, strings.TrimSpace(note))
+	if err != nil {
+		return nil, fmt.Errorf("error resolving moderation: %w", err)
+	}
+
+	return toGQLModerationSample(sample), nil
+}
+
+// Login is the resolver for the login field.
+func (r *mutationResolver) Login(ctx context.Context, email string, password string) (string, error) {
+	if email == "" || password == "" {
+		return "", fmt.Errorf("email or password must not be empty")
+	}
+
+	ip := r.ExtractIP(ctx)
+	if r.IPThrottle.RetryAfter(ip) > 0 || r.AccountThrottle.RetryAfter(email) > 0 {
+		return "", errTooManyLoginAttempts
+	}
+
+	user, err := r.DB.GetUserByEmail(email)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return "", fmt.Errorf("error getting user: %w", err)
+	}
+	if user == nil {
+		// Spend the same time hashing as we would for a real account so response
+		// times don't reveal which emails are registered.
+		models.CheckDummyPassword(password)
+
+		r.IPThrottle.RecordFailure(ip)
+		r.AccountThrottle.RecordFailure(email)
+
+		return "", errInvalidCredentials
+	}
+
+	now := time.Now()
+	if user.IsLocked(now) {
+		return "", errTooManyLoginAttempts
+	}
+
+	err = user.CheckPassword(password)
+	if err != nil {
+		failedAttempts, err := r.DB.RecordLoginFailure(user.ID)
+		if err != nil {
+			return "", fmt.Errorf("error updating user: %w", err)
+		}
+
+		err = r.DB.LockUser(user.ID, now.Add(auth.AccountPolicy.Backoff(failedAttempts)))
+		if err != nil {
+			return "", fmt.Errorf("error updating user: %w", err)
+		}
+
+		r.IPThrottle.RecordFailure(ip)
+
+		return "", errInvalidCredentials
+	}
+
+	if user.FailedLoginAttempts > 0 || !user.LockedUntil.IsZero() {
+		err = r.DB.UpdateLoginFailures(user.ID, 0, time.Time{})
+		if err != nil {
+			return "", fmt.Errorf("error updating user: %w", err)
+		}
+	}
+
+	r.IPThrottle.Reset(ip)
+
+	recordAuditEntity(ctx, "User", fmt.Sprintf("%d", user.ID))
+
+	tokenString, err := auth.NewToken(user, r.Config.JWTSecret)
+	if err != nil {
+		return "", fmt.Errorf("error signing token: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// RegisterStudent is the resolver for the registerStudent field.
+func (r *mutationResolver) RegisterStudent(ctx context.Context, studentNumber string, inviteCode string, password string) (string, error) {
+	if studentNumber == "" || inviteCode == "" || password == "" {
+		return "", fmt.Errorf("student number, invite code or password must not be empty")
+	}
+
+	// The same error is returned whether or not the student number exists or has an
+	// invite, so the mutation can't be used to look up students.
+	student, err := r.DB.GetStudentByNumber(studentNumber)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return "", fmt.Errorf("error getting student: %w", err)
+	}
+	if student == nil {
+		return "", errInvalidInvite
+	}
+
+	invite, err := r.DB.GetStudentInvite(student.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return "", fmt.Errorf("error getting invite: %w", err)
+	}
+	if invite == nil || !invite.Check(inviteCode, time.Now()) {
+		return "", errInvalidInvite
+	}
+
+	user, err := r.DB.GetUserByEmail(student.Email)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return "", fmt.Errorf("error getting user: %w", err)
+	}
+	if user != nil {
+		return "", fmt.Errorf("user already exists")
+	}
+
+	passwordHash, err := models.HashPassword(password)
+	if err != nil {
+		return "", fmt.Errorf("error hashing password: %w", err)
+	}
+
+	user, err = r.DB.CreateStudentUser(student.Email, passwordHash, student.ID)
+	if err != nil {
+		return "", fmt.Errorf("error creating user: %w", err)
+	}
+	if user == nil {
+		return "", fmt.Errorf("error creating user")
+	}
+
+	recordAuditEntity(ctx, "User", fmt.Sprintf("%d", user.ID))
+
+	tokenString, err := auth.NewToken(user, r.Config.JWTSecret)
+	if err != nil {
+		return "", fmt.Errorf("error signing token: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// Register is the resolver for the register field.
+func (r *mutationResolver) Register(ctx context.Context, email string, password string, role *model.StaffRole) (string, error) {
+	admin, err := r.requireStaff(ctx)
+	if err != nil {
+		return "", err
+	}
+	if admin.Role != models.UserRoleAdmin {
+		return "", fmt.Errorf("you must be an admin to register a user")
+	}
+
+	if email == "" || password == "" {
+		return "", fmt.Errorf("email or password must not be empty")
+	}
+
+	userRole := models.UserRoleTutor
+	if role != nil && *role == model.StaffRoleAdmin {
+		userRole = models.UserRoleAdmin
+	}
+
+	user, err := r.DB.GetUserByEmail(email)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return "", fmt.Errorf("error getting user: %w", err)
+	}
+	if user != nil {
+		return "", fmt.Errorf("user already exists")
+	}
+
+	passwordHash, err := models.HashPassword(password)
+	if err != nil {
+		return "", fmt.Errorf("error hashing password: %w", err)
+	}
+
+	user, err = r.DB.CreateUser(email, passwordHash, userRole)
+	if err != nil {
+		return "", fmt.Errorf("error creating user: %w", err)
+	}
+	if user == nil {
+		return "", fmt.Errorf("error creating user")
+	}
+
+	recordAuditEntity(ctx, "User", fmt.Sprintf("%d", user.ID))
+
+	tokenString, err := auth.NewToken(user, r.Config.JWTSecret)
 	if err != nil {
 		return "", fmt.Errorf("error signing token: %w", err)
 	}
 
-	return tokenString, nil
+	return tokenString, nil
+}
+
+// ResetDb is the resolver for the resetDB field.
+func (r *mutationResolver) ResetDb(ctx context.Context) (bool, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return false, err
+	}
+	if user.Role != models.UserRoleAdmin {
+		return false, fmt.Errorf("you must be an admin to reset the database")
+	}
+
+	newDB, err := r.DB.ResetDB()
+	if err != nil {
+		return false, err
+	}
+
+	r.DB = newDB
+
+	return true, nil
+}
+
+// UnlockUser is the resolver for the unlockUser field.
+func (r *mutationResolver) UnlockUser(ctx context.Context, email string) (bool, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return false, err
+	}
+	if user.Role != models.UserRoleAdmin {
+		return false, fmt.Errorf("you must be an admin to unlock a user")
+	}
+
+	lockedUser, err := r.DB.GetUserByEmail(email)
+	if err != nil {
+		return false, fmt.Errorf("error getting user: %w", err)
+	}
+	if lockedUser == nil {
+		return false, fmt.Errorf("user with email: %s does not exist", email)
+	}
+
+	recordAuditBefore(ctx, "User", fmt.Sprintf("%d", lockedUser.ID), map[string]interface{}{
+		"failedLoginAttempts": lockedUser.FailedLoginAttempts,
+		"lockedUntil":         lockedUser.LockedUntil,
+	})
+
+	err = r.DB.UpdateLoginFailures(lockedUser.ID, 0, time.Time{})
+	if err != nil {
+		return false, fmt.Errorf("error unlocking user: %w", err)
+	}
+
+	return true, nil
+}
+
+// Unit is the resolver for the unit field.
+func (r *offeringResolver) Unit(ctx context.Context, obj *model.Offering) (*model.Unit, error) {
+	offering, err := getOffering(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Unit{ID: fmt.Sprintf("%d", offering.Unit.ID), Name: offering.Unit.Name}, nil
+}
+
+// Term is the resolver for the term field.
+func (r *offeringResolver) Term(ctx context.Context, obj *model.Offering) (*model.Term, error) {
+	offering, err := getOffering(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLTerm(&offering.Term), nil
+}
+
+// Classes is the resolver for the classes field.
+func (r *offeringResolver) Classes(ctx context.Context, obj *model.Offering) ([]*model.Class, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	offeringID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	classes, err := r.DB.GetClassesForOffering(uint(offeringID))
+	if err != nil {
+		return nil, err
+	}
+
+	gqlClasses := []*model.Class{}
+	for _, class := range classes {
+		gqlClasses = append(gqlClasses, &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name})
+	}
+
+	return gqlClasses, nil
+}
+
+// Units is the resolver for the units field.
+func (r *queryResolver) Units(ctx context.Context, from *int, termID *string) ([]*model.Unit, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	var units []*models.Unit
+	var err error
+	if termID != nil {
+		var term *models.Term
+		term, err = getTerm(r.DB, *termID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting term: %w", err)
+		}
+		units, err = r.DB.GetUnitsForTerm(term.ID, getOffset(from))
+	} else {
+		units, err = r.DB.GetAllUnits(getOffset(from))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting units: %w", err)
+	}
+	if units == nil {
+		return nil, fmt.Errorf("error getting units")
+	}
+
+	gqlUnits := []*model.Unit{}
+	for _, unit := range units {
+		gqlUnit := &model.Unit{ID: fmt.Sprintf("%d", unit.ID), Name: unit.Name, Classes: []*model.Class{}}
+		gqlUnits = append(gqlUnits, gqlUnit)
+	}
+
+	return gqlUnits, nil
+}
+
+// Unit is the resolver for the unit field.
+func (r *queryResolver) Unit(ctx context.Context, id string) (*model.Unit, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	unit, err := r.DB.GetUnitByID(id, false)
+	if err != nil {
+		return nil, err
+	}
+	if unit == nil {
+		return nil, nil
+	}
+
+	gqlUnit := &model.Unit{ID: id, Name: unit.Name, Classes: []*model.Class{}}
+
+	return gqlUnit, nil
+}
+
+// Terms is the resolver for the terms field.
+func (r *queryResolver) Terms(ctx context.Context) ([]*model.Term, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	terms, err := r.DB.GetTerms()
+	if err != nil {
+		return nil, fmt.Errorf("error getting terms: %w", err)
+	}
+
+	gqlTerms := []*model.Term{}
+	for _, term := range terms {
+		gqlTerms = append(gqlTerms, toGQLTerm(term))
+	}
+
+	return gqlTerms, nil
+}
+
+// Term is the resolver for the term field.
+func (r *queryResolver) Term(ctx context.Context, id string) (*model.Term, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	term, err := getTerm(r.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting term: %w", err)
+	}
+
+	return toGQLTerm(term), nil
+}
+
+// CurrentTerm is the resolver for the currentTerm field.
+func (r *queryResolver) CurrentTerm(ctx context.Context) (*model.Term, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	term, err := r.DB.GetTermAt(time.Now())
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting term: %w", err)
+	}
+
+	return toGQLTerm(term), nil
+}
+
+// Offering is the resolver for the offering field.
+func (r *queryResolver) Offering(ctx context.Context, id string) (*model.Offering, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	offering, err := getOffering(r.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting offering: %w", err)
+	}
+
+	return toGQLOffering(offering), nil
+}
+
+// Classes is the resolver for the classes field.
+func (r *queryResolver) Classes(ctx context.Context, from *int, termID *string) ([]*model.Class, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	var classes []*models.Class
+	var err error
+	if termID != nil {
+		var term *models.Term
+		term, err = getTerm(r.DB, *termID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting term: %w", err)
+		}
+		classes, err = r.DB.GetClassesForTerm(term.ID, getOffset(from))
+	} else {
+		classes, err = r.DB.GetAllClasses(getOffset(from))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting classes: %w", err)
+	}
+	if classes == nil {
+		return nil, nil
+	}
+
+	gqlClasses := []*model.Class{}
+	for _, class := range classes {
+		gqlClass := &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name}
+		gqlClasses = append(gqlClasses, gqlClass)
+	}
+
+	return gqlClasses, nil
+}
+
+// Class is the resolver for the class field.
+func (r *queryResolver) Class(ctx context.Context, id string) (*model.Class, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	class, err := getClass(r.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting class: %w", err)
+	}
+
+	return &model.Class{ID: id, Name: class.Name}, nil
+}
+
+// Assignments is the resolver for the assignments field.
+func (r *queryResolver) Assignments(ctx context.Context, from *int, termID *string) ([]*model.Assignment, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	var assignments []*models.Assignment
+	var err error
+	if termID != nil {
+		var term *models.Term
+		term, err = getTerm(r.DB, *termID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting term: %w", err)
+		}
+		assignments, err = r.DB.GetAssignmentsForTerm(term.ID, getOffset(from))
+	} else {
+		assignments, err = r.DB.GetAllAssignments(getOffset(from))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignments: %w", err)
+	}
+	if assignments == nil {
+		return nil, nil
+	}
+
+	gqlAssignments := []*model.Assignment{}
+	for _, assignment := range assignments {
+		gqlAssignments = append(gqlAssignments, &model.Assignment{
+			ID:          fmt.Sprintf("%d", assignment.ID),
+			Name:        assignment.Name,
+			DueDate:     int(assignment.DueDate.Unix()),
+			Tests:       []*model.Test{},
+			Submissions: []*model.Submission{},
+		})
+	}
+
+	return gqlAssignments, nil
+}
+
+// Assignment is the resolver for the assignment field.
+func (r *queryResolver) Assignment(ctx context.Context, id string) (*model.Assignment, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	return &model.Assignment{ID: id, Name: assignment.Name}, nil
+}
+
+// Tests is the resolver for the tests field.
+func (r *queryResolver) Tests(ctx context.Context, from *int) ([]*model.Test, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	tests, err := r.DB.GetAllTests(getOffset(from))
+	if err != nil {
+		return nil, fmt.Errorf("error getting tests: %w", err)
+	}
+	if tests == nil {
+		return nil, nil
+	}
+
+	gqlTests := []*model.Test{}
+	for _, test := range tests {
+		gqlTests = append(gqlTests, toGQLTest(test))
+	}
+
+	return gqlTests, nil
+}
+
+// Test is the resolver for the test field.
+func (r *queryResolver) Test(ctx context.Context, id string) (*model.Test, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	test, err := getTest(r.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting test: %w", err)
+	}
+
+	return toGQLTest(test), nil
+}
+
+// Submissions is the resolver for the submissions field.
+func (r *queryResolver) Submissions(ctx context.Context, from *int) ([]*model.Submission, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	submissions, err := r.DB.GetAllSubmissions(getOffset(from))
+	if err != nil {
+		return nil, fmt.Errorf("error getting submissions: %w", err)
+	}
+	if submissions == nil {
+		return nil, nil
+	}
+
+	gqlSubmissions := []*model.Submission{}
+	for _, submission := range submissions {
+		gqlSubmission := &model.Submission{ID: fmt.Sprintf("%d", submission.ID), StudentID: submission.StudentID}
+		gqlSubmissions = append(gqlSubmissions, gqlSubmission)
+	}
+
+	return gqlSubmissions, nil
+}
+
+// Submission is the resolver for the submission field.
+func (r *queryResolver) Submission(ctx context.Context, id string) (*model.Submission, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	submission, err := getSubmission(r.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submission: %w", err)
+	}
+
+	return &model.Submission{ID: id, StudentID: submission.StudentID}, nil
+}
+
+// VersionDiff is the resolver for the versionDiff field.
+func (r *queryResolver) VersionDiff(ctx context.Context, fromVersionID string, toVersionID string) ([]*model.FileDiff, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	from, err := getSubmissionVersion(r.DB, fromVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := getSubmissionVersion(r.DB, toVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.SubmissionID != to.SubmissionID {
+		return nil, fmt.Errorf("versions belong to different submissions")
+	}
+
+	oldFiles, err := r.DB.GetSubmissionFiles(from.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting files: %w", err)
+	}
+
+	newFiles, err := r.DB.GetSubmissionFiles(to.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting files: %w", err)
+	}
+
+	oldContent := map[string]string{}
+	for _, file := range oldFiles {
+		oldContent[file.Path] = string(file.Content)
+	}
+
+	newContent := map[string]string{}
+	for _, file := range newFiles {
+		newContent[file.Path] = string(file.Content)
+	}
+
+	return toGQLFileDiffs(diff.Files(oldContent, newContent)), nil
+}
+
+// Results is the resolver for the results field.
+func (r *queryResolver) Results(ctx context.Context, from *int) ([]*model.Result, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	results, err := r.DB.GetAllResults(getOffset(from))
+	if err != nil {
+		return nil, fmt.Errorf("error getting results: %w", err)
+	}
+	if results == nil {
+		return nil, nil
+	}
+
+	gqlResults := []*model.Result{}
+	for _, result := range results {
+		// Leave out results for submissions the user is blind second marking.
+		visible, err := r.submissionFeedbackVisible(ctx, result.SubmissionID)
+		if err != nil {
+			return nil, err
+		}
+		if visible {
+			gqlResults = append(gqlResults, toGQLResult(result))
+		}
+	}
+
+	return gqlResults, nil
+}
+
+// Result is the resolver for the result field.
+func (r *queryResolver) Result(ctx context.Context, id string) (*model.Result, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := r.DB.GetResult(id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting result: %w", err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	visible, err := r.submissionFeedbackVisible(ctx, result.SubmissionID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+
+	return toGQLResult(result), nil
+}
+
+// Students is the resolver for the students field.
+func (r *queryResolver) Students(ctx context.Context, from *int) ([]*model.Student, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	students, err := r.DB.GetAllStudents(getOffset(from))
+	if err != nil {
+		return nil, fmt.Errorf("error getting students: %w", err)
+	}
+	if students == nil {
+		return nil, nil
+	}
+
+	gqlStudents := []*model.Student{}
+	for _, student := range students {
+		gqlStudents = append(gqlStudents, toGQLStudent(student))
+	}
+
+	return gqlStudents, nil
+}
+
+// Student is the resolver for the student field.
+func (r *queryResolver) Student(ctx context.Context, id string) (*model.Student, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	student, err := getStudent(r.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting student: %w", err)
+	}
+
+	return toGQLStudent(student), nil
+}
+
+// CurrentStudent is the resolver for the currentStudent field.
+func (r *queryResolver) CurrentStudent(ctx context.Context) (*model.Student, error) {
+	user := r.ExtractUser(ctx)
+	if user == nil {
+		return nil, errNotAuthenticated
+	}
+	if user.StudentID == nil {
+		return nil, nil
+	}
+
+	student, err := getStudent(r.DB, fmt.Sprintf("%d", *user.StudentID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting student: %w", err)
+	}
+
+	return toGQLStudent(student), nil
+}
+
+// MyMarkingQueue is the resolver for the myMarkingQueue field.
+func (r *queryResolver) MyMarkingQueue(ctx context.Context, assignmentID *string) (*model.MarkingQueue, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations, err := r.DB.GetMarkingAllocationsForMarker(user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("error getting allocations: %w", err)
+	}
+
+	queue := &model.MarkingQueue{
+		Pending:    []*model.Submission{},
+		InProgress: []*model.Submission{},
+		Done:       []*model.Submission{},
+		Progress:   &model.MarkingProgress{},
+	}
+	for _, allocation := range allocations {
+		if assignmentID != nil && fmt.Sprintf("%d", allocation.AssignmentID) != *assignmentID {
+			continue
+		}
+		// The submission has been deleted since it was allocated.
+		if allocation.Submission.ID == 0 {
+			continue
+		}
+
+		submission := &model.Submission{ID: fmt.Sprintf("%d", allocation.SubmissionID), StudentID: allocation.Submission.StudentID}
+		switch allocation.Status {
+		case models.MarkingStatusInProgress:
+			queue.InProgress = append(queue.InProgress, submission)
+		case models.MarkingStatusDone:
+			queue.Done = append(queue.Done, submission)
+		default:
+			queue.Pending = append(queue.Pending, submission)
+		}
+	}
+
+	queue.Progress.Pending = len(queue.Pending)
+	queue.Progress.InProgress = len(queue.InProgress)
+	queue.Progress.Done = len(queue.Done)
+	queue.Progress.Total = len(queue.Pending) + len(queue.InProgress) + len(queue.Done)
+
+	return queue, nil
+}
+
+// AuditLog is the resolver for the auditLog field.
+func (r *queryResolver) AuditLog(ctx context.Context, filter *model.AuditLogFilter, from *int) ([]*model.AuditEvent, error) {
+	user, err := r.requireStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != models.UserRoleAdmin {
+		return nil, fmt.Errorf("you must be an admin to view the audit log")
+	}
+
+	var dbFilter models.AuditEventFilter
+	if filter != nil {
+		dbFilter = models.AuditEventFilter{
+			Actor:          stringValue(filter.Actor),
+			AttemptedEmail: stringValue(filter.AttemptedEmail),
+			Action:         stringValue(filter.Action),
+			EntityType:     stringValue(filter.EntityType),
+			EntityID:       stringValue(filter.EntityID),
+		}
+		if filter.Since != nil {
+			dbFilter.Since = time.Unix(int64(*filter.Since), 0)
+		}
+		if filter.Until != nil {
+			dbFilter.Until = time.Unix(int64(*filter.Until), 0)
+		}
+	}
+
+	events, err := r.DB.GetAuditEvents(dbFilter, getOffset(from))
+	if err != nil {
+		return nil, fmt.Errorf("error getting audit log: %w", err)
+	}
+
+	gqlEvents := []*model.AuditEvent{}
+	for _, event := range events {
+		gqlEvents = append(gqlEvents, &model.AuditEvent{
+			ID:             fmt.Sprintf("%d", event.ID),
+			Actor:          event.Actor,
+			AttemptedEmail: optionalString(event.AttemptedEmail),
+			Action:         event.Action,
+			EntityType:     event.EntityType,
+			EntityID:       event.EntityID,
+			Before:         optionalString(event.Before),
+			After:          optionalString(event.After),
+			IP:             event.RequestIP,
+			Timestamp:      int(event.CreatedAt.Unix()),
+			Error:          optionalString(event.Error),
+		})
+	}
+
+	return gqlEvents, nil
+}
+
+// Overrides is the resolver for the overrides field.
+func (r *resultResolver) Overrides(ctx context.Context, obj *model.Result) ([]*model.ResultOverride, error) {
+	resultID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := r.DB.GetResultOverrides(uint(resultID))
+	if err != nil {
+		return nil, err
+	}
+
+	gqlOverrides := []*model.ResultOverride{}
+	for _, override := range overrides {
+		gqlOverrides = append(gqlOverrides, &model.ResultOverride{
+			ID:            fmt.Sprintf("%d", override.ID),
+			PreviousScore: override.PreviousScore,
+			Score:         override.Score,
+			Reason:        override.Reason,
+			OverriddenBy:  override.OverriddenBy,
+			OverriddenAt:  int(override.CreatedAt.Unix()),
+		})
+	}
+
+	return gqlOverrides, nil
+}
+
+// AdjustedScore is the resolver for the adjustedScore field.
+func (r *resultResolver) AdjustedScore(ctx context.Context, obj *model.Result) (float64, error) {
+	lateness, err := getResultLateness(r.DB, obj)
+	if err != nil {
+		return 0, err
+	}
+
+	return grading.AdjustScore(obj.Score, lateness), nil
+}
+
+// Lateness is the resolver for the lateness field.
+func (r *resultResolver) Lateness(ctx context.Context, obj *model.Result) (*model.Lateness, error) {
+	lateness, err := getResultLateness(r.DB, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLLateness(lateness), nil
+}
+
+// Classes is the resolver for the classes field.
+func (r *studentResolver) Classes(ctx context.Context, obj *model.Student) ([]*model.Class, error) {
+	studentID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.requireStaffOrStudent(ctx, uint(studentID)); err != nil {
+		return nil, err
+	}
+
+	classes, err := r.DB.GetClassesForStudent(uint(studentID))
+	if err != nil {
+		return nil, err
+	}
+
+	gqlClasses := []*model.Class{}
+	for _, class := range classes {
+		gqlClasses = append(gqlClasses, &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name})
+	}
+
+	return gqlClasses, nil
+}
+
+// Submissions is the resolver for the submissions field.
+func (r *studentResolver) Submissions(ctx context.Context, obj *model.Student) ([]*model.Submission, error) {
+	studentID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.requireStaffOrStudent(ctx, uint(studentID)); err != nil {
+		return nil, err
+	}
+
+	submissions, err := r.DB.GetSubmissionsForStudent(uint(studentID))
+	if err != nil {
+		return nil, err
+	}
+
+	gqlSubmissions := []*model.Submission{}
+	for _, submission := range submissions {
+		gqlSubmissions = append(gqlSubmissions, &model.Submission{ID: fmt.Sprintf("%d", submission.ID), StudentID: submission.StudentID})
+	}
+
+	return gqlSubmissions, nil
+}
+
+// Student is the resolver for the student field.
+func (r *submissionResolver) Student(ctx context.Context, obj *model.Submission) (*model.Student, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.StudentRecordID == nil {
+		return nil, nil
+	}
+
+	student, err := getStudent(r.DB, fmt.Sprintf("%d", *submission.StudentRecordID))
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLStudent(student), nil
+}
+
+// Result is the resolver for the result field.
+func (r *submissionResolver) Result(ctx context.Context, obj *model.Submission) (*model.Result, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := r.feedbackVisible(ctx, submission)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+
+	_, result, err := getCountedVersion(r.DB, submission)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil {
+		return toGQLResult(result), nil
+	}
+
+	// Fall back to the result recorded against the submission before versioning.
+	return &model.Result{ID: fmt.Sprintf("%d", submission.Result.ID), Score: submission.Result.Score, OriginalScore: submission.Result.Score, SubmissionID: obj.ID}, nil
+}
+
+// Unit is the resolver for the unit field.
+func (r *submissionResolver) Unit(ctx context.Context, obj *model.Submission) (*model.Unit, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, err
+	}
+
+	class, err := getClass(r.DB, fmt.Sprintf("%d", assignment.ClassID))
+	if err != nil {
+		return nil, err
+	}
+
+	unit, err := getUnit(r.DB, fmt.Sprintf("%d", class.UnitID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Unit{ID: fmt.Sprintf("%d", unit.ID), Name: unit.Name}, nil
+}
+
+// Class is the resolver for the class field.
+func (r *submissionResolver) Class(ctx context.Context, obj *model.Submission) (*model.Class, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, err
+	}
+
+	class, err := getClass(r.DB, fmt.Sprintf("%d", assignment.ClassID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name}, nil
+}
+
+// Assignment is the resolver for the assignment field.
+func (r *submissionResolver) Assignment(ctx context.Context, obj *model.Submission) (*model.Assignment, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name}, nil
+}
+
+// Files is the resolver for the files field.
+func (r *submissionResolver) Files(ctx context.Context, obj *model.Submission) ([]*model.SubmissionFile, error) {
+	submissionID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := r.DB.GetSubmissionVersions(uint(submissionID))
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return []*model.SubmissionFile{}, nil
+	}
+
+	files, err := r.DB.GetSubmissionFiles(versions[len(versions)-1].ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLSubmissionFiles(files), nil
+}
+
+// Attempts is the resolver for the attempts field.
+func (r *submissionResolver) Attempts(ctx context.Context, obj *model.Submission) (int, error) {
+	submissionID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	versions, err := r.DB.GetSubmissionVersions(uint(submissionID))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(versions), nil
+}
+
+// Versions is the resolver for the versions field.
+func (r *submissionResolver) Versions(ctx context.Context, obj *model.Submission) ([]*model.SubmissionVersion, error) {
+	submissionID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := r.DB.GetSubmissionVersions(uint(submissionID))
+	if err != nil {
+		return nil, err
+	}
+
+	gqlVersions := []*model.SubmissionVersion{}
+	for _, version := range versions {
+		gqlVersions = append(gqlVersions, toGQLSubmissionVersion(version))
+	}
+
+	return gqlVersions, nil
+}
+
+// CountedVersion is the resolver for the countedVersion field.
+func (r *submissionResolver) CountedVersion(ctx context.Context, obj *model.Submission) (*model.SubmissionVersion, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := r.feedbackVisible(ctx, submission)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+
+	version, _, err := getCountedVersion(r.DB, submission)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, nil
+	}
+
+	return toGQLSubmissionVersion(version), nil
+}
+
+// Lateness is the resolver for the lateness field.
+func (r *submissionResolver) Lateness(ctx context.Context, obj *model.Submission) (*model.Lateness, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, _, err := getCountedVersion(r.DB, submission)
+	if err != nil {
+		return nil, err
+	}
+
+	lateness, err := getLateness(r.DB, submission, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLLateness(lateness), nil
+}
+
+// Score is the resolver for the score field.
+func (r *submissionResolver) Score(ctx context.Context, obj *model.Submission) (*model.ScoreBreakdown, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := r.feedbackVisible(ctx, submission)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+
+	version, _, err := getCountedVersion(r.DB, submission)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, nil
+	}
+
+	score, err := grading.LoadScore(r.DB, submission.AssignmentID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLScore(score), nil
+}
+
+// RubricMarks is the resolver for the rubricMarks field.
+func (r *submissionResolver) RubricMarks(ctx context.Context, obj *model.Submission) ([]*model.RubricMark, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := r.feedbackVisible(ctx, submission)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return []*model.RubricMark{}, nil
+	}
+
+	criteria, err := r.DB.GetRubric(submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	marks, err := r.DB.GetRubricMarks(submission.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := map[uint]*models.RubricMark{}
+	for _, mark := range marks {
+		selected[mark.CriterionID] = mark
+	}
+
+	// Marks are returned in rubric order, skipping any whose level has since been removed.
+	gqlMarks := []*model.RubricMark{}
+	for _, criterion := range criteria {
+		mark, ok := selected[criterion.ID]
+		if !ok {
+			continue
+		}
+
+		gqlCriterion := toGQLRubricCriterion(criterion)
+		for _, level := range gqlCriterion.Levels {
+			if level.ID != fmt.Sprintf("%d", mark.LevelID) {
+				continue
+			}
+
+			gqlMarks = append(gqlMarks, &model.RubricMark{
+				Criterion: gqlCriterion,
+				Level:     level,
+				Comment:   optionalString(mark.Comment),
+				MarkedBy:  mark.MarkedBy,
+				MarkedAt:  int(mark.UpdatedAt.Unix()),
+			})
+		}
+	}
+
+	return gqlMarks, nil
+}
+
+// Grade is the resolver for the grade field.
+func (r *submissionResolver) Grade(ctx context.Context, obj *model.Submission) (*model.Grade, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := r.feedbackVisible(ctx, submission)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, nil
+	}
+
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, err
+	}
+
+	grade, err := grading.LoadGrade(r.DB, assignment, submission)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group members see their own share of the group's grade.
+	if user := r.ExtractUser(ctx); submission.GroupID != nil && user != nil && user.StudentID != nil {
+		adjustment, err := r.DB.GetMemberAdjustment(submission.ID, *user.StudentID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return nil, err
+		}
+		if adjustment != nil {
+			grade.Adjustment = adjustment.Marks
+		}
+	}
+
+	return &model.Grade{
+		Automated:    grade.Automated,
+		AutomatedMax: grade.AutomatedMax,
+		Rubric:       grade.Rubric,
+		RubricMax:    grade.RubricMax,
+		Total:        grade.Total(),
+		MaxScore:     grade.Max(),
+		Deductions:   grade.Deductions,
+		LatePenalty:  grade.Penalty,
+		Final:        grade.Final(),
+		Percentage:   grade.Percentage(),
+		Complete:     grade.Complete,
+	}, nil
+}
+
+// CodeMetrics is the resolver for the codeMetrics field.
+func (r *submissionResolver) CodeMetrics(ctx context.Context, obj *model.Submission) (*model.CodeMetrics, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, _, err := getCountedVersion(r.DB, submission)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, nil
+	}
+
+	files, err := r.DB.GetCodeMetrics(version.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting code metrics: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	return toGQLCodeMetrics(files), nil
 }
 
-// Login is the resolver for the login field.
-func (r *mutationResolver) Login(ctx context.Context, email string, password string) (string, error) {
-	if email == "" || password == "" {
-		return "", fmt.Errorf("email or password must not be empty")
+// LintFindings is the resolver for the lintFindings field.
+func (r *submissionResolver) LintFindings(ctx context.Context, obj *model.Submission) ([]*model.LintFinding, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	user, err := r.DB.GetUserByEmail(email)
+	visible, err := r.feedbackVisible(ctx, submission)
 	if err != nil {
-		return "", fmt.Errorf("error getting user: %w", err)
+		return nil, err
 	}
-	if user == nil {
-		return "", fmt.Errorf("user with email: %s does not exist", email)
+	if !visible {
+		return []*model.LintFinding{}, nil
 	}
 
-	err = user.CheckPassword(password)
+	version, _, err := getCountedVersion(r.DB, submission)
 	if err != nil {
-		return "", fmt.Errorf("incorrect username or password: %w", err)
+		return nil, err
+	}
+	if version == nil {
+		return []*model.LintFinding{}, nil
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub": user.Email,
-	})
-
-	tokenString, err := token.SignedString([]byte(r.Config.JWTSecret))
+	rules, err := r.DB.GetLintRules(submission.AssignmentID)
 	if err != nil {
-		return "", fmt.Errorf("error signing token: %w", err)
+		return nil, fmt.Errorf("error getting lint rules: %w", err)
 	}
 
-	return tokenString, nil
-}
-
-// ResetDb is the resolver for the resetDB field.
-func (r *mutationResolver) ResetDb(ctx context.Context) (bool, error) {
-	user := r.ExtractUser(ctx)
-	if user == nil {
-		return false, fmt.Errorf("user not authenticated")
-	}
-	if user.Role != models.UserRoleAdmin {
-		return false, fmt.Errorf("you must be an admin to reset the database")
+	gqlRules := map[uint]*model.LintRule{}
+	for _, rule := range rules {
+		gqlRules[rule.ID] = toGQLLintRule(rule)
 	}
 
-	newDB, err := r.DB.ResetDB()
+	findings, err := r.DB.GetLintFindings(version.ID)
 	if err != nil {
-		return false, err
+		return nil, fmt.Errorf("error getting lint findings: %w", err)
 	}
 
-	r.DB = newDB
+	gqlFindings := []*model.LintFinding{}
+	for _, finding := range findings {
+		rule, ok := gqlRules[finding.RuleID]
+		if !ok {
+			continue
+		}
 
-	return true, nil
+		gqlFinding := &model.LintFinding{
+			Rule:     rule,
+			File:     optionalString(finding.Path),
+			Severity: model.LintSeverity(finding.Severity),
+			Message:  finding.Message,
+		}
+		if finding.Line > 0 {
+			line := finding.Line
+			gqlFinding.Line = &line
+		}
+		gqlFindings = append(gqlFindings, gqlFinding)
+	}
+
+	return gqlFindings, nil
 }
 
-// Units is the resolver for the units field.
-func (r *queryResolver) Units(ctx context.Context, from *int) ([]*model.Unit, error) {
-	units, err := r.DB.GetAllUnits(getOffset(from))
+// Comments is the resolver for the comments field.
+func (r *submissionResolver) Comments(ctx context.Context, obj *model.Submission) ([]*model.CodeComment, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error getting units: %w", err)
+		return nil, err
 	}
-	if units == nil {
-		return nil, fmt.Errorf("error getting units")
+
+	visible, err := r.feedbackVisible(ctx, submission)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return []*model.CodeComment{}, nil
 	}
 
-	gqlUnits := []*model.Unit{}
-	for _, unit := range units {
-		gqlUnit := &model.Unit{ID: fmt.Sprintf("%d", unit.ID), Name: unit.Name, Classes: []*model.Class{}}
-		gqlUnits = append(gqlUnits, gqlUnit)
+	versions, err := r.DB.GetSubmissionVersions(submission.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting versions: %w", err)
 	}
 
-	return gqlUnits, nil
-}
+	versionsByID := map[uint]*models.SubmissionVersion{}
+	for _, version := range versions {
+		versionsByID[version.ID] = version
+	}
 
-// Unit is the resolver for the unit field.
-func (r *queryResolver) Unit(ctx context.Context, id string) (*model.Unit, error) {
-	unit, err := r.DB.GetUnitByID(id, false)
+	comments, err := r.DB.GetCodeCommentsForSubmission(submission.ID)
 	if err != nil {
-		return nil, err
-	}
-	if unit == nil {
-		return nil, nil
+		return nil, fmt.Errorf("error getting comments: %w", err)
 	}
 
-	gqlUnit := &model.Unit{ID: id, Name: unit.Name, Classes: []*model.Class{}}
+	gqlComments := []*model.CodeComment{}
+	for _, comment := range comments {
+		version, ok := versionsByID[comment.SubmissionVersionID]
+		if !ok {
+			continue
+		}
+		gqlComments = append(gqlComments, toGQLCodeComment(comment, version))
+	}
 
-	return gqlUnit, nil
+	return gqlComments, nil
 }
 
-// Classes is the resolver for the classes field.
-func (r *queryResolver) Classes(ctx context.Context, from *int) ([]*model.Class, error) {
-	classes, err := r.DB.GetAllClasses(getOffset(from))
-	if err != nil {
-		return nil, fmt.Errorf("error getting classes: %w", err)
-	}
-	if classes == nil {
+// Allocation is the resolver for the allocation field.
+func (r *submissionResolver) Allocation(ctx context.Context, obj *model.Submission) (*model.MarkingAllocation, error) {
+	// Students don't need to know who marks their work.
+	if !r.isStaff(ctx) {
 		return nil, nil
 	}
 
-	gqlClasses := []*model.Class{}
-	for _, class := range classes {
-		gqlClass := &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name}
-		gqlClasses = append(gqlClasses, gqlClass)
+	submissionID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
 	}
 
-	return gqlClasses, nil
-}
-
-// Class is the resolver for the class field.
-func (r *queryResolver) Class(ctx context.Context, id string) (*model.Class, error) {
-	class, err := getClass(r.DB, id)
+	allocation, err := r.DB.GetMarkingAllocation(uint(submissionID))
 	if err != nil {
-		return nil, fmt.Errorf("error getting class: %w", err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting allocation: %w", err)
 	}
 
-	return &model.Class{ID: id, Name: class.Name}, nil
+	allocation.Submission.StudentID = obj.StudentID
+
+	return toGQLMarkingAllocation(allocation), nil
 }
 
-// Assignments is the resolver for the assignments field.
-func (r *queryResolver) Assignments(ctx context.Context, from *int) ([]*model.Assignment, error) {
-	assignments, err := r.DB.GetAllAssignments(getOffset(from))
-	if err != nil {
-		return nil, fmt.Errorf("error getting assignments: %w", err)
-	}
-	if assignments == nil {
+// Moderation is the resolver for the moderation field.
+func (r *submissionResolver) Moderation(ctx context.Context, obj *model.Submission) (*model.ModerationSample, error) {
+	if !r.isStaff(ctx) {
 		return nil, nil
 	}
 
-	gqlAssignments := []*model.Assignment{}
-	for _, assignment := range assignments {
-		gqlAssignments = append(gqlAssignments, &model.Assignment{
-			ID:          fmt.Sprintf("%d", assignment.ID),
-			Name:        assignment.Name,
-			DueDate:     int(assignment.DueDate.Unix()),
-			Tests:       []*model.Test{},
-			Submissions: []*model.Submission{},
-		})
+	submissionID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
 	}
 
-	return gqlAssignments, nil
-}
-
-// Assignment is the resolver for the assignment field.
-func (r *queryResolver) Assignment(ctx context.Context, id string) (*model.Assignment, error) {
-	assignment, err := getAssignment(r.DB, id)
+	sample, err := r.DB.GetModerationSample(uint(submissionID))
 	if err != nil {
-		return nil, fmt.Errorf("error getting assignment: %w", err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting moderation sample: %w", err)
 	}
 
-	return &model.Assignment{ID: id, Name: assignment.Name}, nil
+	return toGQLModerationSample(sample), nil
 }
 
-// Tests is the resolver for the tests field.
-func (r *queryResolver) Tests(ctx context.Context, from *int) ([]*model.Test, error) {
-	tests, err := r.DB.GetAllTests(getOffset(from))
+// Group is the resolver for the group field.
+func (r *submissionResolver) Group(ctx context.Context, obj *model.Submission) (*model.Group, error) {
+	submission, err := getSubmission(r.DB, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error getting tests: %w", err)
+		return nil, err
 	}
-	if tests == nil {
+	if submission.GroupID == nil {
 		return nil, nil
 	}
 
-	gqlTests := []*model.Test{}
-	for _, test := range tests {
-		gqlTest := &model.Test{ID: fmt.Sprintf("%d", test.ID), Name: test.Name}
-		gqlTests = append(gqlTests, gqlTest)
+	group, err := getGroup(r.DB, fmt.Sprintf("%d", *submission.GroupID))
+	if err != nil {
+		return nil, err
 	}
 
-	return gqlTests, nil
+	return toGQLGroup(group), nil
 }
 
-// Test is the resolver for the test field.
-func (r *queryResolver) Test(ctx context.Context, id string) (*model.Test, error) {
-	test, err := getTest(r.DB, id)
-	if err != nil {
-		return nil, fmt.Errorf("error getting test: %w", err)
+// MemberGrades is the resolver for the memberGrades field.
+func (r *submissionResolver) MemberGrades(ctx context.Context, obj *model.Submission) ([]*model.MemberGrade, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
 	}
 
-	return &model.Test{ID: id, Name: test.Name}, nil
-}
-
-// Submissions is the resolver for the submissions field.
-func (r *queryResolver) Submissions(ctx context.Context, from *int) ([]*model.Submission, error) {
-	submissions, err := r.DB.GetAllSubmissions(getOffset(from))
+	submission, err := getSubmission(r.DB, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error getting submissions: %w", err)
+		return nil, err
 	}
-	if submissions == nil {
-		return nil, nil
+	if submission.GroupID == nil {
+		return []*model.MemberGrade{}, nil
 	}
 
-	gqlSubmissions := []*model.Submission{}
-	for _, submission := range submissions {
-		gqlSubmission := &model.Submission{ID: fmt.Sprintf("%d", submission.ID), StudentID: submission.StudentID}
-		gqlSubmissions = append(gqlSubmissions, gqlSubmission)
+	visible, err := r.feedbackVisible(ctx, submission)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return []*model.MemberGrade{}, nil
 	}
 
-	return gqlSubmissions, nil
-}
+	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	if err != nil {
+		return nil, err
+	}
 
-// Submission is the resolver for the submission field.
-func (r *queryResolver) Submission(ctx context.Context, id string) (*model.Submission, error) {
-	submission, err := getSubmission(r.DB, id)
+	memberGrades, err := grading.LoadMemberGrades(r.DB, assignment, submission)
 	if err != nil {
-		return nil, fmt.Errorf("error getting submission: %w", err)
+		return nil, err
 	}
 
-	return &model.Submission{ID: id, StudentID: submission.StudentID}, nil
+	gqlMemberGrades := []*model.MemberGrade{}
+	for _, memberGrade := range memberGrades {
+		gqlMemberGrades = append(gqlMemberGrades, toGQLMemberGrade(memberGrade))
+	}
+
+	return gqlMemberGrades, nil
 }
 
-// Results is the resolver for the results field.
-func (r *queryResolver) Results(ctx context.Context, from *int) ([]*model.Result, error) {
-	results, err := r.DB.GetAllResults(getOffset(from))
+// Files is the resolver for the files field.
+func (r *submissionVersionResolver) Files(ctx context.Context, obj *model.SubmissionVersion) ([]*model.SubmissionFile, error) {
+	versionID, err := strconv.ParseUint(obj.ID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("error getting results: %w", err)
-	}
-	if results == nil {
-		return nil, nil
+		return nil, err
 	}
 
-	gqlResults := []*model.Result{}
-	for _, result := range results {
-		gqlResult := &model.Result{
-			ID:           fmt.Sprintf("%d", result.ID),
-			Score:        result.Score,
-			Date:         result.CreatedAt.Format("02/01/2006"),
-			SubmissionID: fmt.Sprintf("%d", result.SubmissionID),
-		}
-		gqlResults = append(gqlResults, gqlResult)
+	files, err := r.DB.GetSubmissionFiles(uint(versionID))
+	if err != nil {
+		return nil, err
 	}
 
-	return gqlResults, nil
+	return toGQLSubmissionFiles(files), nil
 }
 
 // Result is the resolver for the result field.
-func (r *queryResolver) Result(ctx context.Context, id string) (*model.Result, error) {
-	result, err := r.DB.GetResult(id)
+func (r *submissionVersionResolver) Result(ctx context.Context, obj *model.SubmissionVersion) (*model.Result, error) {
+	version, err := getSubmissionVersion(r.DB, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error getting result: %w", err)
+		return nil, err
 	}
-	if result == nil {
+
+	visible, err := r.submissionFeedbackVisible(ctx, version.SubmissionID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
 		return nil, nil
 	}
 
-	return &model.Result{ID: fmt.Sprintf("%d", result.ID), Score: result.Score, SubmissionID: fmt.Sprintf("%d", result.SubmissionID), Date: result.CreatedAt.Format("02/01/2006")}, nil
-}
-
-// Result is the resolver for the result field.
-func (r *submissionResolver) Result(ctx context.Context, obj *model.Submission) (*model.Result, error) {
-	submission, err := getSubmission(r.DB, obj.ID)
+	results, err := r.DB.GetResultsForSubmission(version.SubmissionID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.Result{ID: fmt.Sprintf("%d", submission.Result.ID), Score: submission.Result.Score}, nil
+	result, ok := grading.LatestResults(results)[version.ID]
+	if !ok {
+		return nil, nil
+	}
+
+	return toGQLResult(result), nil
 }
 
-// Unit is the resolver for the unit field.
-func (r *submissionResolver) Unit(ctx context.Context, obj *model.Submission) (*model.Unit, error) {
-	submission, err := getSubmission(r.DB, obj.ID)
+// Counted is the resolver for the counted field.
+func (r *submissionVersionResolver) Counted(ctx context.Context, obj *model.SubmissionVersion) (bool, error) {
+	version, err := getSubmissionVersion(r.DB, obj.ID)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	submission, err := getSubmission(r.DB, fmt.Sprintf("%d", version.SubmissionID))
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	class, err := getClass(r.DB, fmt.Sprintf("%d", assignment.ClassID))
+	visible, err := r.feedbackVisible(ctx, submission)
 	if err != nil {
-		return nil, err
+		return false, err
+	}
+	if !visible {
+		return false, nil
 	}
 
-	unit, err := getUnit(r.DB, fmt.Sprintf("%d", class.UnitID))
+	counted, _, err := getCountedVersion(r.DB, submission)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	return &model.Unit{ID: fmt.Sprintf("%d", unit.ID), Name: unit.Name}, nil
+	return counted != nil && counted.ID == version.ID, nil
 }
 
-// Class is the resolver for the class field.
-func (r *submissionResolver) Class(ctx context.Context, obj *model.Submission) (*model.Class, error) {
-	submission, err := getSubmission(r.DB, obj.ID)
+// StarterDiff is the resolver for the starterDiff field.
+func (r *submissionVersionResolver) StarterDiff(ctx context.Context, obj *model.SubmissionVersion) ([]*model.FileDiff, error) {
+	version, err := getSubmissionVersion(r.DB, obj.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	submission, err := getSubmission(r.DB, fmt.Sprintf("%d", version.SubmissionID))
 	if err != nil {
 		return nil, err
 	}
 
-	class, err := getClass(r.DB, fmt.Sprintf("%d", assignment.ClassID))
+	starterFiles, err := r.DB.GetStarterFiles(submission.AssignmentID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error getting starter files: %w", err)
 	}
 
-	return &model.Class{ID: fmt.Sprintf("%d", class.ID), Name: class.Name}, nil
+	files, err := r.DB.GetSubmissionFiles(version.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting files: %w", err)
+	}
+
+	content := map[string]string{}
+	for _, file := range files {
+		content[file.Path] = string(file.Content)
+	}
+
+	return toGQLFileDiffs(starter.NewBaseline(starterFiles).Diff(content)), nil
 }
 
-// Assignment is the resolver for the assignment field.
-func (r *submissionResolver) Assignment(ctx context.Context, obj *model.Submission) (*model.Assignment, error) {
-	submission, err := getSubmission(r.DB, obj.ID)
+// Offerings is the resolver for the offerings field.
+func (r *termResolver) Offerings(ctx context.Context, obj *model.Term) ([]*model.Offering, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	termID, err := strconv.ParseUint(obj.ID, 10, 64)
 	if err != nil {
 		return nil, err
 	}
 
-	assignment, err := getAssignment(r.DB, fmt.Sprintf("%d", submission.AssignmentID))
+	offerings, err := r.DB.GetOfferingsForTerm(uint(termID))
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.Assignment{ID: fmt.Sprintf("%d", assignment.ID), Name: assignment.Name}, nil
+	gqlOfferings := []*model.Offering{}
+	for _, offering := range offerings {
+		gqlOfferings = append(gqlOfferings, toGQLOffering(offering))
+	}
+
+	return gqlOfferings, nil
 }
 
 // Unit is the resolver for the unit field.
@@ -770,6 +4143,10 @@ func (r *testResolver) Assignment(ctx context.Context, obj *model.Test) (*model.
 
 // Classes is the resolver for the classes field.
 func (r *unitResolver) Classes(ctx context.Context, obj *model.Unit) ([]*model.Class, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
 	unit, err := r.DB.GetUnitByID(obj.ID, true)
 	if err != nil {
 		return nil, err
@@ -787,21 +4164,68 @@ func (r *unitResolver) Classes(ctx context.Context, obj *model.Unit) ([]*model.C
 	return gqlClasses, nil
 }
 
+// Offerings is the resolver for the offerings field.
+func (r *unitResolver) Offerings(ctx context.Context, obj *model.Unit) ([]*model.Offering, error) {
+	if _, err := r.requireStaff(ctx); err != nil {
+		return nil, err
+	}
+
+	unitID, err := strconv.ParseUint(obj.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	offerings, err := r.DB.GetOfferingsForUnit(uint(unitID))
+	if err != nil {
+		return nil, err
+	}
+
+	gqlOfferings := []*model.Offering{}
+	for _, offering := range offerings {
+		gqlOfferings = append(gqlOfferings, toGQLOffering(offering))
+	}
+
+	return gqlOfferings, nil
+}
+
 // Assignment returns generated.AssignmentResolver implementation.
 func (r *Resolver) Assignment() generated.AssignmentResolver { return &assignmentResolver{r} }
 
 // Class returns generated.ClassResolver implementation.
 func (r *Resolver) Class() generated.ClassResolver { return &classResolver{r} }
 
+// Extension returns generated.ExtensionResolver implementation.
+func (r *Resolver) Extension() generated.ExtensionResolver { return &extensionResolver{r} }
+
+// Group returns generated.GroupResolver implementation.
+func (r *Resolver) Group() generated.GroupResolver { return &groupResolver{r} }
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
+// Offering returns generated.OfferingResolver implementation.
+func (r *Resolver) Offering() generated.OfferingResolver { return &offeringResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Result returns generated.ResultResolver implementation.
+func (r *Resolver) Result() generated.ResultResolver { return &resultResolver{r} }
+
+// Student returns generated.StudentResolver implementation.
+func (r *Resolver) Student() generated.StudentResolver { return &studentResolver{r} }
+
 // Submission returns generated.SubmissionResolver implementation.
 func (r *Resolver) Submission() generated.SubmissionResolver { return &submissionResolver{r} }
 
+// SubmissionVersion returns generated.SubmissionVersionResolver implementation.
+func (r *Resolver) SubmissionVersion() generated.SubmissionVersionResolver {
+	return &submissionVersionResolver{r}
+}
+
+// Term returns generated.TermResolver implementation.
+func (r *Resolver) Term() generated.TermResolver { return &termResolver{r} }
+
 // Test returns generated.TestResolver implementation.
 func (r *Resolver) Test() generated.TestResolver { return &testResolver{r} }
 
@@ -810,8 +4234,15 @@ func (r *Resolver) Unit() generated.UnitResolver { return &unitResolver{r} }
 
 type assignmentResolver struct{ *Resolver }
 type classResolver struct{ *Resolver }
+type extensionResolver struct{ *Resolver }
+type groupResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type offeringResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type resultResolver struct{ *Resolver }
+type studentResolver struct{ *Resolver }
 type submissionResolver struct{ *Resolver }
+type submissionVersionResolver struct{ *Resolver }
+type termResolver struct{ *Resolver }
 type testResolver struct{ *Resolver }
 type unitResolver struct{ *Resolver }
@@ -1,11 +1,16 @@
 package graph
 
 import (
+	"archive/zip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -23,6 +28,7 @@ import (
 	"github.com/COMP4050/square-team-5/api/internal/pkg/config"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/web/auth"
 )
 
 func mockHandler(w http.ResponseWriter, r *http.Request) {
@@ -35,6 +41,10 @@ func newClient(mockDB *mocks.MockDatabase, authenticated bool) *client.Client {
 		user = &models.User{Email: "user@example.com"}
 	}
 
+	return newClientAs(mockDB, user)
+}
+
+func newClientAs(mockDB *mocks.MockDatabase, user *models.User) *client.Client {
 	// New mock http server
 	srv := httptest.NewServer(http.HandlerFunc(mockHandler))
 
@@ -48,9 +58,12 @@ func newClient(mockDB *mocks.MockDatabase, authenticated bool) *client.Client {
 		handler.NewDefaultServer(
 			generated.NewExecutableSchema(
 				generated.Config{Resolvers: &Resolver{
-					DB:          mockDB,
-					ExtractUser: func(ctx context.Context) *models.User { return user },
-					Config:      newConfig,
+					DB:              mockDB,
+					ExtractUser:     func(ctx context.Context) *models.User { return user },
+					ExtractIP:       func(ctx context.Context) string { return "127.0.0.1" },
+					Config:          newConfig,
+					IPThrottle:      auth.NewLoginThrottle(auth.IPPolicy),
+					AccountThrottle: auth.NewLoginThrottle(auth.AccountPolicy),
 				},
 				},
 			),
@@ -333,7 +346,7 @@ func TestClassResolver(t *testing.T) {
 		c := newClient(mockDB, true)
 
 		mockDB.EXPECT().GetUnitByID("1", false).Return(&models.Unit{Model: gorm.Model{ID: 1}, Name: "COMP1000"}, nil)
-		mockDB.EXPECT().CreateClass("Class 1", uint(1)).Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().CreateClass("Class 1", uint(1), (*uint)(nil)).Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1"}, nil)
 
 		var resp struct {
 			CreateClass struct{ ID, Name string }
@@ -522,6 +535,116 @@ func TestAssignmentResolver(t *testing.T) {
 
 		assert.ErrorContains(t, err, "user not authenticated")
 	})
+
+	t.Run("Assignment Similarity", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		// Alice and Bob submitted the same Beak.pde, Dave wrote his own, and all three
+		// were given the same Landscape.pde.
+		students := []string{"s0001_Alice_Penguin", "s0003_Bob_Eagle", "s0007_Dave_Raven"}
+		var submissions []*models.Submission
+		for i, student := range students {
+			id := uint(i + 1)
+			submissions = append(submissions, &models.Submission{Model: gorm.Model{ID: id}, StudentID: student, AssignmentID: 1})
+
+			var files []*models.SubmissionFile
+			for _, name := range []string{"Beak.pde", "Landscape.pde"} {
+				content, err := os.ReadFile(filepath.Join("..", "scripts", "data", "submissions", student, "MarchPenguin", name))
+				require.NoError(t, err)
+				files = append(files, &models.SubmissionFile{Path: "MarchPenguin/" + name, Content: content})
+			}
+
+			mockDB.EXPECT().GetSubmissionVersions(id).Return([]*models.SubmissionVersion{{Model: gorm.Model{ID: id + 10}, Number: 1, SubmissionID: id}}, nil)
+			mockDB.EXPECT().GetResultsForSubmission(id).Return(nil, nil)
+			mockDB.EXPECT().GetSubmissionFiles(id+10).Return(files, nil)
+		}
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1"}, nil).Times(2)
+		mockDB.EXPECT().GetSubmissionsForAssignment("1").Return(submissions, nil)
+		mockDB.EXPECT().GetStarterFiles(uint(1)).Return(nil, nil)
+
+		var resp struct {
+			Assignment struct {
+				Similarity []struct {
+					SubmissionA, SubmissionB struct{ ID, StudentID string }
+					Similarity               float64
+					Matches                  []struct {
+						FileA, FileB         string
+						StartLineA, EndLineB int
+					}
+				}
+			}
+		}
+		c.MustPost(`{ assignment(id:"1") { similarity(minSimilarity: 0.9) { submissionA { id studentID } submissionB { id studentID } similarity matches { fileA fileB startLineA endLineB } } } }`, &resp)
+
+		require.Len(t, resp.Assignment.Similarity, 1)
+		pair := resp.Assignment.Similarity[0]
+		assert.Equal(t, "s0001_Alice_Penguin", pair.SubmissionA.StudentID)
+		assert.Equal(t, "2", pair.SubmissionB.ID)
+		assert.Equal(t, 1.0, pair.Similarity)
+		require.NotEmpty(t, pair.Matches)
+		for _, match := range pair.Matches {
+			assert.Equal(t, "MarchPenguin/Beak.pde", match.FileA)
+			assert.Equal(t, "MarchPenguin/Beak.pde", match.FileB)
+		}
+	})
+
+	t.Run("Upload Starter Code", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		archive, err := os.Create(filepath.Join(t.TempDir(), "starter.zip"))
+		require.NoError(t, err)
+		w := zip.NewWriter(archive)
+		f, err := w.Create("MarchPenguin/Landscape.pde")
+		require.NoError(t, err)
+		_, err = f.Write([]byte("void setup() {}\n"))
+		require.NoError(t, err)
+		_, err = w.Create("__MACOSX/MarchPenguin/._Landscape.pde")
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		_, err = archive.Seek(0, 0)
+		require.NoError(t, err)
+
+		beak, err := os.Create(filepath.Join(t.TempDir(), "Beak.pde"))
+		require.NoError(t, err)
+		_, err = beak.WriteString("class Beak {}\n")
+		require.NoError(t, err)
+		_, err = beak.Seek(0, 0)
+		require.NoError(t, err)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1"}, nil)
+		mockDB.EXPECT().SetStarterFiles(uint(1), []models.StarterFile{
+			{Path: "MarchPenguin/Landscape.pde", Content: []byte("void setup() {}\n")},
+			{Path: "Beak.pde", Content: []byte("class Beak {}\n")},
+		}).Return(nil, nil)
+		mockDB.EXPECT().GetStarterFiles(uint(1)).Return([]*models.StarterFile{
+			{Model: gorm.Model{ID: 2}, Path: "Beak.pde", Content: []byte("class Beak {}\n")},
+			{Model: gorm.Model{ID: 1}, Path: "MarchPenguin/Landscape.pde", Content: []byte("void setup() {}\n")},
+		}, nil)
+
+		var resp struct {
+			UploadStarterCode struct {
+				StarterFiles []struct {
+					Path string
+					Size int
+				}
+			}
+		}
+		c.MustPost(`mutation($files: [Upload!]!) { uploadStarterCode(assignmentID: "1", files: $files) { starterFiles { path size } } }`, &resp,
+			client.Var("files", []*os.File{archive, beak}), client.WithFiles())
+
+		require.Len(t, resp.UploadStarterCode.StarterFiles, 2)
+		assert.Equal(t, "Beak.pde", resp.UploadStarterCode.StarterFiles[0].Path)
+		assert.Equal(t, 14, resp.UploadStarterCode.StarterFiles[0].Size)
+	})
 }
 
 func TestTestResolver(t *testing.T) {
@@ -664,7 +787,7 @@ func TestTestResolver(t *testing.T) {
 		c := newClient(mockDB, true)
 
 		// Storage path here is tests/{assignmentID}/test_{testID}.java
-		mockDB.EXPECT().CreateTest("Test 1", uint(1)).Return(&models.Test{Model: gorm.Model{ID: 1}, Name: "Test 1"}, nil)
+		mockDB.EXPECT().CreateTest("Test 1", uint(1), 1.0, 1.0).Return(&models.Test{Model: gorm.Model{ID: 1}, Name: "Test 1"}, nil)
 
 		var resp struct {
 			CreateTest struct{ ID, Name string }
@@ -675,6 +798,238 @@ func TestTestResolver(t *testing.T) {
 		assert.Equal(t, "Test 1", resp.CreateTest.Name)
 	})
 
+	t.Run("Create Test - Weighted", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().CreateTest("Test 1", uint(1), 10.0, 2.0).Return(&models.Test{Model: gorm.Model{ID: 1}, Name: "Test 1", MaxPoints: 10, Weight: 2}, nil)
+
+		var resp struct {
+			CreateTest struct {
+				ID                string
+				MaxPoints, Weight float64
+			}
+		}
+		c.MustPost(`mutation { createTest(input: {name: "Test 1", assignmentID: "1", maxPoints: 10, weight: 2}) { id maxPoints weight } }`, &resp)
+
+		assert.Equal(t, "1", resp.CreateTest.ID)
+		assert.Equal(t, float64(10), resp.CreateTest.MaxPoints)
+		assert.Equal(t, float64(2), resp.CreateTest.Weight)
+	})
+
+	t.Run("Create Test - Invalid Weight", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		var resp struct {
+			CreateTest struct{ ID string }
+		}
+		err := c.Post(`mutation { createTest(input: {name: "Test 1", assignmentID: "1", weight: 0}) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "max points and weight must be positive")
+	})
+
+	t.Run("Update Test Scoring", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetTest("1").Return(&models.Test{Model: gorm.Model{ID: 1}, Name: "Test 1", MaxPoints: 1, Weight: 1}, nil)
+		mockDB.EXPECT().UpdateTestScoring(uint(1), 5.0, 0.5).Return(&models.Test{Model: gorm.Model{ID: 1}, Name: "Test 1", MaxPoints: 5, Weight: 0.5}, nil)
+
+		var resp struct {
+			UpdateTestScoring struct{ MaxPoints, Weight float64 }
+		}
+		c.MustPost(`mutation { updateTestScoring(testID: "1", maxPoints: 5, weight: 0.5) { maxPoints weight } }`, &resp)
+
+		assert.Equal(t, float64(5), resp.UpdateTestScoring.MaxPoints)
+		assert.Equal(t, 0.5, resp.UpdateTestScoring.Weight)
+	})
+
+	t.Run("Assignment Max Score", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1"}, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{
+			{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 2},
+			{Model: gorm.Model{ID: 2}, MaxPoints: 5, Weight: 1},
+		}, nil)
+
+		var resp struct {
+			Assignment struct{ MaxScore float64 }
+		}
+		c.MustPost(`{ assignment(id:"1") { maxScore } }`, &resp)
+
+		assert.Equal(t, float64(25), resp.Assignment.MaxScore)
+	})
+
+	t.Run("Assignment Statistics", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		meanPoints := 0.5
+		assignment := &models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", DueDate: time.Now().Add(time.Hour)}
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil).Times(2)
+		mockDB.EXPECT().GetAssignmentStatistics(uint(1)).Return(&models.AssignmentStatistics{
+			Submissions: 3,
+			Missing:     1,
+			Tests: []models.TestStatistics{
+				{Test: &models.Test{Model: gorm.Model{ID: 1}, Name: "Test 1"}, Attempts: 2, Passed: 1, MeanPoints: &meanPoints},
+				{Test: &models.Test{Model: gorm.Model{ID: 2}, Name: "Test 2"}},
+			},
+		}, nil)
+		// The inputs are loaded once for the assignment, with the tests and rubric loaded
+		// again for the maximum grade, rather than once per submission.
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil).Times(2)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil).Times(2)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetSubmissionsForAssignment("1").Return([]*models.Submission{
+			{Model: gorm.Model{ID: 1}, AssignmentID: 1},
+			{Model: gorm.Model{ID: 2}, AssignmentID: 1},
+			{Model: gorm.Model{ID: 3}, AssignmentID: 1},
+		}, nil)
+
+		// The first two submissions have overridden results of 3 and 7, and the third
+		// hasn't been marked.
+		versionIDs, original := []uint{1, 2}, 0.0
+		mockDB.EXPECT().GetSubmissionVersionsForAssignment(uint(1)).Return([]*models.SubmissionVersion{
+			{Model: gorm.Model{ID: 1}, SubmissionID: 1, Number: 1},
+			{Model: gorm.Model{ID: 2}, SubmissionID: 2, Number: 1},
+		}, nil)
+		mockDB.EXPECT().GetResultsForAssignment(uint(1)).Return([]*models.Result{
+			{Score: 3, OriginalScore: &original, SubmissionID: 1, SubmissionVersionID: &versionIDs[0]},
+			{Score: 7, OriginalScore: &original, SubmissionID: 2, SubmissionVersionID: &versionIDs[1]},
+		}, nil)
+		mockDB.EXPECT().GetTestOutcomesForAssignment(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetRubricMarksForAssignment(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetExtensionsForAssignment(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetModerationSamples(uint(1)).Return(nil, nil)
+
+		var resp struct {
+			Assignment struct {
+				Statistics struct {
+					Submissions, Missing, Graded    int
+					Mean, Median, StandardDeviation *float64
+					Histogram                       []struct {
+						Lower, Upper float64
+						Count        int
+					}
+					Tests []struct {
+						Test             struct{ Name string }
+						Attempts, Passed int
+						PassRate         *float64
+					}
+				}
+			}
+		}
+		c.MustPost(`{ assignment(id:"1") { statistics(buckets: 2) { submissions missing graded mean median standardDeviation histogram { lower upper count } tests { test { name } attempts passed passRate } } } }`, &resp)
+
+		stats := resp.Assignment.Statistics
+		assert.Equal(t, 3, stats.Submissions)
+		assert.Equal(t, 1, stats.Missing)
+		assert.Equal(t, 2, stats.Graded)
+		assert.Equal(t, 5.0, *stats.Mean)
+		assert.Equal(t, 5.0, *stats.Median)
+		assert.Equal(t, 2.0, *stats.StandardDeviation)
+		assert.Len(t, stats.Histogram, 2)
+		assert.Equal(t, 5.0, stats.Histogram[1].Lower)
+		assert.Equal(t, 1, stats.Histogram[1].Count)
+		assert.Equal(t, "Test 1", stats.Tests[0].Test.Name)
+		assert.Equal(t, 0.5, *stats.Tests[0].PassRate)
+		assert.Nil(t, stats.Tests[1].PassRate)
+	})
+
+	t.Run("Assignment Statistics - Invalid Buckets", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1"}, nil)
+
+		var resp struct {
+			Assignment struct {
+				Statistics struct{ Graded int }
+			}
+		}
+		err := c.Post(`{ assignment(id:"1") { statistics(buckets: 0) { graded } } }`, &resp)
+
+		assert.ErrorContains(t, err, "buckets must be between 1 and 100")
+	})
+
+	t.Run("Record Test Results", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		tests := []*models.Test{
+			{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 2},
+			{Model: gorm.Model{ID: 2}, MaxPoints: 5, Weight: 1},
+		}
+		versionID := uint(4)
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(&models.SubmissionVersion{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}, nil)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return(tests, nil)
+		mockDB.EXPECT().CreateTestOutcomes([]models.TestOutcome{
+			{Points: 7, TestID: 1, SubmissionVersionID: 4},
+			{Points: 9, TestID: 2, SubmissionVersionID: 4},
+		}).Return(nil)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(4)).Return([]*models.TestOutcome{
+			{Model: gorm.Model{ID: 1}, Points: 7, TestID: 1, SubmissionVersionID: 4},
+			{Model: gorm.Model{ID: 2}, Points: 9, TestID: 2, SubmissionVersionID: 4},
+		}, nil)
+		// 7 * 2 for the first test, plus the second test's 9 points capped at 5.
+		mockDB.EXPECT().CreateResult(19.0, uint(1), uint(4)).Return(&models.Result{Model: gorm.Model{ID: 3}, Score: 19, SubmissionID: 1, SubmissionVersionID: &versionID}, nil)
+
+		var resp struct {
+			RecordTestResults struct {
+				ID    string
+				Score float64
+			}
+		}
+		c.MustPost(`mutation { recordTestResults(versionID: "4", outcomes: [{testID: "1", points: 7}, {testID: "2", points: 9}]) { id score } }`, &resp)
+
+		assert.Equal(t, "3", resp.RecordTestResults.ID)
+		assert.Equal(t, float64(19), resp.RecordTestResults.Score)
+	})
+
+	t.Run("Record Test Results - Test From Another Assignment", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(&models.SubmissionVersion{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}, nil)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 1, Weight: 1}}, nil)
+
+		var resp struct {
+			RecordTestResults struct{ ID string }
+		}
+		err := c.Post(`mutation { recordTestResults(versionID: "4", outcomes: [{testID: "8", points: 1}]) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "test 8 does not belong to the submission's assignment")
+	})
+
 	t.Run("Create Test - Unauthenticated", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -819,6 +1174,11 @@ func TestSubmissionResolver(t *testing.T) {
 					Score: 51,
 				},
 				AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1"}, nil).Times(2)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(2)).Return(nil, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(2)).Return(nil, nil)
 
 		var resp struct {
 			Submissions []struct {
@@ -861,7 +1221,8 @@ func TestSubmissionResolver(t *testing.T) {
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().CreateSubmission("44444444", uint(1)).Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "44444444"}, nil)
+		mockDB.EXPECT().GetStudentByNumber("44444444").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().SubmitVersion(uint(1), "44444444", nil, nil).Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "44444444"}, &models.SubmissionVersion{Model: gorm.Model{ID: 1}, Number: 1, SubmissionID: 1}, true, nil)
 
 		var resp struct {
 			CreateSubmission struct{ ID, StudentID string }
@@ -872,125 +1233,728 @@ func TestSubmissionResolver(t *testing.T) {
 		assert.Equal(t, "44444444", resp.CreateSubmission.StudentID)
 	})
 
-	t.Run("Create Submission - Unauthenticated", func(t *testing.T) {
+	t.Run("Create Submission - Linked To Student", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 
 		mockDB := mocks.NewMockDatabase(ctrl)
-		c := newClient(mockDB, false)
+		c := newClient(mockDB, true)
+
+		student := &models.Student{Model: gorm.Model{ID: 7}, StudentNumber: "s0001", Name: "Alice Penguin"}
+		studentRecordID := uint(7)
+		mockDB.EXPECT().GetStudentByNumber("s0001").Return(student, nil)
+		mockDB.EXPECT().SubmitVersion(uint(1), "s0001_Alice_Penguin", &studentRecordID, nil).Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001_Alice_Penguin", StudentRecordID: &studentRecordID}, &models.SubmissionVersion{Model: gorm.Model{ID: 1}, Number: 1, SubmissionID: 1}, true, nil)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001_Alice_Penguin", StudentRecordID: &studentRecordID}, nil)
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
 
 		var resp struct {
-			CreateSubmission struct{ ID, StudentID string }
+			CreateSubmission struct {
+				ID      string
+				Student struct{ ID, Name string }
+			}
 		}
-		err := c.Post(`mutation { createSubmission(input: {studentID: "44444444", assignmentID: "1"}) { id studentID} }`, &resp)
+		c.MustPost(`mutation { createSubmission(input: {studentID: "s0001_Alice_Penguin", assignmentID: "1"}) { id student { id name } } }`, &resp)
 
-		assert.ErrorContains(t, err, "user not authenticated")
+		assert.Equal(t, "1", resp.CreateSubmission.ID)
+		assert.Equal(t, "7", resp.CreateSubmission.Student.ID)
+		assert.Equal(t, "Alice Penguin", resp.CreateSubmission.Student.Name)
 	})
-}
 
-func TestResultResolver(t *testing.T) {
-	t.Parallel()
+	t.Run("Create Submission - Resubmission", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
 
-	var resp struct {
-		Result struct {
-			ID, Date, SubmissionID string
-			Score                  float64
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudentByNumber("44444444").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().SubmitVersion(uint(1), "44444444", nil, nil).Return(&models.Submission{Model: gorm.Model{ID: 3}, StudentID: "44444444", AssignmentID: 1}, &models.SubmissionVersion{Model: gorm.Model{ID: 5}, Number: 2, SubmissionID: 3}, false, nil)
+
+		var resp struct {
+			CreateSubmission struct{ ID, StudentID string }
 		}
-	}
+		c.MustPost(`mutation { createSubmission(input: {studentID: "44444444", assignmentID: "1"}) { id studentID} }`, &resp)
 
-	t.Run("Get Result", func(t *testing.T) {
-		t.Parallel()
+		assert.Equal(t, "3", resp.CreateSubmission.ID)
+		assert.Equal(t, "44444444", resp.CreateSubmission.StudentID)
+	})
 
-		now := time.Now()
+	t.Run("Get Submission Versions", func(t *testing.T) {
+		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetResult("1").Return(&models.Result{Model: gorm.Model{ID: 1, CreatedAt: now}, Score: 99, SubmissionID: 1}, nil)
+		first, second := uint(4), uint(5)
+		versions := []*models.SubmissionVersion{
+			{Model: gorm.Model{ID: first}, Number: 1, SubmissionID: 1},
+			{Model: gorm.Model{ID: second}, Number: 2, SubmissionID: 1},
+		}
+		results := []*models.Result{
+			{Model: gorm.Model{ID: 1}, Score: 80, SubmissionID: 1, SubmissionVersionID: &first},
+			{Model: gorm.Model{ID: 2}, Score: 60, SubmissionID: 1, SubmissionVersionID: &second},
+		}
 
-		c.MustPost(`{ result(id:"1") { id date score submissionID } }`, &resp)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "44444444", AssignmentID: 1}, nil).AnyTimes()
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, AttemptPolicy: models.AttemptPolicyBest}, nil).AnyTimes()
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return(versions, nil).AnyTimes()
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(results, nil).AnyTimes()
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(versions[0], nil).AnyTimes()
+		mockDB.EXPECT().GetSubmissionVersion("5").Return(versions[1], nil).AnyTimes()
 
-		assert.Equal(t, "1", resp.Result.ID)
-		year, month, day := now.Date()
-		assert.Equal(t, fmt.Sprintf("%02d/%02d/%d", day, month, year), resp.Result.Date)
-		assert.Equal(t, "1", resp.Result.SubmissionID)
-		assert.Equal(t, float64(99), resp.Result.Score)
+		var resp struct {
+			Submission struct {
+				Attempts       int
+				Result         struct{ Score float64 }
+				CountedVersion struct{ ID string }
+				Versions       []struct {
+					ID      string
+					Number  int
+					Counted bool
+					Result  *struct{ Score float64 }
+				}
+			}
+		}
+		c.MustPost(`{ submission(id:"1") { attempts result { score } countedVersion { id } versions { id number counted result { score } } } }`, &resp)
+
+		assert.Equal(t, 2, resp.Submission.Attempts)
+		assert.Equal(t, float64(80), resp.Submission.Result.Score)
+		assert.Equal(t, "4", resp.Submission.CountedVersion.ID)
+		require.Len(t, resp.Submission.Versions, 2)
+		assert.Equal(t, 1, resp.Submission.Versions[0].Number)
+		assert.True(t, resp.Submission.Versions[0].Counted)
+		assert.Equal(t, float64(80), resp.Submission.Versions[0].Result.Score)
+		assert.Equal(t, 2, resp.Submission.Versions[1].Number)
+		assert.False(t, resp.Submission.Versions[1].Counted)
+		assert.Equal(t, float64(60), resp.Submission.Versions[1].Result.Score)
 	})
 
-	t.Run("Get All Results", func(t *testing.T) {
+	t.Run("Get Starter Diff", func(t *testing.T) {
 		t.Parallel()
-
 		ctrl := gomock.NewController(t)
+
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		now := time.Now()
+		version := &models.SubmissionVersion{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}
 
-		mockDB.EXPECT().GetAllResults(1).Return([]*models.Result{
-			{Model: gorm.Model{ID: 1, CreatedAt: now}, Score: 99, SubmissionID: 1},
-			{Model: gorm.Model{ID: 2, CreatedAt: now}, Score: 51, SubmissionID: 2},
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "44444444", AssignmentID: 1}, nil).Times(2)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{version}, nil)
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(version, nil)
+		mockDB.EXPECT().GetStarterFiles(uint(1)).Return([]*models.StarterFile{
+			{Path: "MarchPenguin/Beak.pde", Content: []byte("class Beak {}\n")},
+			{Path: "MarchPenguin/Landscape.pde", Content: []byte("void setup() {\n  size(800, 600);\n}\n")},
+		}, nil)
+		// The student renamed the sketch folder, left out Beak.pde and added Extra.pde.
+		mockDB.EXPECT().GetSubmissionFiles(uint(4)).Return([]*models.SubmissionFile{
+			{Path: "Penguin/Landscape.pde", Content: []byte("void setup() {\n  size(400, 300);\n}\n")},
+			{Path: "Penguin/Extra.pde", Content: []byte("class Extra {}\n")},
 		}, nil)
 
 		var resp struct {
-			Results []struct {
-				ID, Date, SubmissionID string
-				Score                  float64
+			Submission struct {
+				Versions []struct {
+					StarterDiff []struct {
+						Path, Status         string
+						Additions, Deletions int
+					}
+				}
 			}
 		}
-		c.MustPost(`{ results() { id score date submissionID } }`, &resp)
-
-		assert.Equal(t, "1", resp.Results[0].ID)
-		assert.Equal(t, "2", resp.Results[1].ID)
-		assert.Equal(t, now.Format("02/01/2006"), resp.Results[0].Date)
-		assert.Equal(t, now.Format("02/01/2006"), resp.Results[1].Date)
-		assert.Equal(t, float64(99), resp.Results[0].Score)
-		assert.Equal(t, float64(51), resp.Results[1].Score)
-		assert.Equal(t, "1", resp.Results[0].SubmissionID)
-		assert.Equal(t, "2", resp.Results[1].SubmissionID)
+		c.MustPost(`{ submission(id:"1") { versions { starterDiff { path status additions deletions } } } }`, &resp)
+
+		require.Len(t, resp.Submission.Versions, 1)
+		diffs := resp.Submission.Versions[0].StarterDiff
+		require.Len(t, diffs, 3)
+		assert.Equal(t, "MarchPenguin/Beak.pde", diffs[0].Path)
+		assert.Equal(t, "REMOVED", diffs[0].Status)
+		assert.Equal(t, "Penguin/Extra.pde", diffs[1].Path)
+		assert.Equal(t, "ADDED", diffs[1].Status)
+		assert.Equal(t, "Penguin/Landscape.pde", diffs[2].Path)
+		assert.Equal(t, "MODIFIED", diffs[2].Status)
+		assert.Equal(t, 1, diffs[2].Additions)
+		assert.Equal(t, 1, diffs[2].Deletions)
 	})
 
-	t.Run("Get Result Not Found", func(t *testing.T) {
+	t.Run("Get Submission Code Metrics", func(t *testing.T) {
 		t.Parallel()
-
 		ctrl := gomock.NewController(t)
+
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetResult("1").Return(nil, db.ErrRecordNotFound)
-
-		err := c.Post(`{ result(id:"1") { id date score submissionID } }`, &resp)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "44444444", AssignmentID: 1}, nil).Times(2)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetCodeMetrics(uint(4)).Return([]*models.CodeMetrics{
+			{
+				Path:      "MarchPenguin/Landscape.pde",
+				Starter:   true,
+				Lines:     51,
+				Functions: []models.FunctionMetrics{{Name: "drawHills", Class: "Landscape", StartLine: 9, EndLine: 25, Complexity: 2}},
+				Classes:   []string{"Landscape"},
+			},
+			{
+				Path:         "MarchPenguin/MarchPenguin.pde",
+				Lines:        46,
+				CodeLines:    34,
+				Functions:    []models.FunctionMetrics{{Name: "setup", StartLine: 9, EndLine: 15, Complexity: 2}, {Name: "draw", StartLine: 18, EndLine: 26, Complexity: 4}},
+				HasSetup:     true,
+				HasDraw:      true,
+				MagicNumbers: []models.MagicNumber{{Line: 8, Value: "500"}},
+				NamingIssues: []models.NamingIssue{{Line: 3, Name: "target_velocity", Kind: "variable", Expected: "lowerCamelCase"}},
+			},
+		}, nil)
 
-		assert.ErrorContains(t, err, "record not found")
-		assert.NotEqual(t, "1", resp.Result.ID)
+		var resp struct {
+			Submission struct {
+				CodeMetrics struct {
+					Lines, Functions, Classes, MaxComplexity, MagicNumbers, NamingIssues int
+					AverageComplexity                                                    float64
+					HasSetup, HasDraw                                                    bool
+					Files                                                                []struct {
+						Path      string
+						Starter   bool
+						Functions []struct {
+							Name  string
+							Class *string
+						}
+						NamingIssues []struct{ Name, Expected string }
+					}
+				}
+			}
+		}
+		c.MustPost(`{ submission(id:"1") { codeMetrics { lines functions classes maxComplexity averageComplexity magicNumbers namingIssues hasSetup hasDraw
+			files { path starter functions { name class } namingIssues { name expected } } } } }`, &resp)
+
+		metrics := resp.Submission.CodeMetrics
+		assert.Equal(t, 46, metrics.Lines)
+		assert.Equal(t, 2, metrics.Functions)
+		assert.Equal(t, 0, metrics.Classes)
+		assert.Equal(t, 4, metrics.MaxComplexity)
+		assert.Equal(t, 3.0, metrics.AverageComplexity)
+		assert.Equal(t, 1, metrics.MagicNumbers)
+		assert.Equal(t, 1, metrics.NamingIssues)
+		assert.True(t, metrics.HasSetup)
+		assert.True(t, metrics.HasDraw)
+		require.Len(t, metrics.Files, 2)
+		assert.True(t, metrics.Files[0].Starter)
+		assert.Equal(t, "Landscape", *metrics.Files[0].Functions[0].Class)
+		assert.Nil(t, metrics.Files[1].Functions[0].Class)
+		assert.Equal(t, "target_velocity", metrics.Files[1].NamingIssues[0].Name)
 	})
-}
 
-func TestRegisterResolver(t *testing.T) {
-	t.Parallel()
+	t.Run("Analyse Submissions", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
 
-	var resp struct {
-		Register string
-	}
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
 
-	user := &models.User{Model: gorm.Model{ID: 1}, Email: "a@b.com", PasswordHash: "password"}
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().GetStarterFiles(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return([]*models.LintRule{
+			{Model: gorm.Model{ID: 3}, Kind: models.LintBannedAPI, Severity: models.LintSeverityWarning, Names: []string{"size"}, Message: "Use settings() instead"},
+		}, nil)
+		mockDB.EXPECT().GetSubmissionsForAssignment("1").Return([]*models.Submission{{Model: gorm.Model{ID: 1}}}, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{
+			{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1},
+			{Model: gorm.Model{ID: 5}, Number: 2, SubmissionID: 1},
+		}, nil)
+		mockDB.EXPECT().GetSubmissionFiles(uint(4)).Return([]*models.SubmissionFile{
+			{Path: "Sketch/Sketch.pde", Content: []byte("void setup() {\n  size(400, 300);\n}\n")},
+			{Path: "Sketch/notes.txt", Content: []byte("not code")},
+		}, nil)
+		mockDB.EXPECT().GetSubmissionFiles(uint(5)).Return(nil, nil)
+		mockDB.EXPECT().SaveCodeMetrics(uint(4), gomock.Any()).DoAndReturn(func(versionID uint, metrics []models.CodeMetrics) error {
+			require.Len(t, metrics, 1)
+			assert.True(t, metrics[0].HasSetup)
+			assert.Equal(t, []models.MagicNumber{{Line: 2, Value: "400"}, {Line: 2, Value: "300"}}, metrics[0].MagicNumbers)
+
+			return nil
+		})
+		mockDB.EXPECT().SaveCodeMetrics(uint(5), nil).Return(nil)
+		mockDB.EXPECT().SaveLintFindings(uint(4), []models.LintFinding{
+			{Path: "Sketch/Sketch.pde", Line: 2, Severity: models.LintSeverityWarning, Message: "Use settings() instead", RuleID: 3},
+		}).Return(nil)
+		mockDB.EXPECT().SaveLintFindings(uint(5), []models.LintFinding{}).Return(nil)
+
+		var resp struct{ AnalyseSubmissions int }
+		c.MustPost(`mutation { analyseSubmissions(assignmentID: "1") }`, &resp)
+
+		assert.Equal(t, 2, resp.AnalyseSubmissions)
+	})
 
-	t.Run("New User", func(t *testing.T) {
+	t.Run("Get Submission Score Breakdown", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
-		mockDB.EXPECT().CreateUser("a@b.com", gomock.Any(), models.UserRoleAdmin).Return(user, nil)
-
-		c.MustPost(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil).Times(2)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{
+			{Model: gorm.Model{ID: 1}, Name: "Beak", MaxPoints: 10, Weight: 2},
+			{Model: gorm.Model{ID: 2}, Name: "Wings", MaxPoints: 5, Weight: 1},
+		}, nil)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(4)).Return([]*models.TestOutcome{
+			{Model: gorm.Model{ID: 1}, Points: 5, TestID: 1, SubmissionVersionID: 4},
+		}, nil)
 
-		assert.NotEmpty(t, resp.Register)
+		var resp struct {
+			Submission struct {
+				Score struct {
+					Total, MaxScore, Percentage float64
+					Tests                       []struct {
+						Test                              struct{ Name string }
+						Points                            *float64
+						WeightedPoints, WeightedMaxPoints float64
+					}
+				}
+			}
+		}
+		c.MustPost(`{ submission(id:"1") { score { total maxScore percentage tests { test { name } points weightedPoints weightedMaxPoints } } } }`, &resp)
+
+		score := resp.Submission.Score
+		assert.Equal(t, float64(10), score.Total)
+		assert.Equal(t, float64(25), score.MaxScore)
+		assert.Equal(t, float64(40), score.Percentage)
+		require.Len(t, score.Tests, 2)
+		assert.Equal(t, "Beak", score.Tests[0].Test.Name)
+		require.NotNil(t, score.Tests[0].Points)
+		assert.Equal(t, float64(5), *score.Tests[0].Points)
+		assert.Equal(t, float64(10), score.Tests[0].WeightedPoints)
+		assert.Equal(t, float64(20), score.Tests[0].WeightedMaxPoints)
+		assert.Nil(t, score.Tests[1].Points)
+		assert.Equal(t, float64(0), score.Tests[1].WeightedPoints)
 	})
 
-	t.Run("User Exists", func(t *testing.T) {
+	t.Run("Version Diff", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(&models.SubmissionVersion{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}, nil)
+		mockDB.EXPECT().GetSubmissionVersion("5").Return(&models.SubmissionVersion{Model: gorm.Model{ID: 5}, Number: 2, SubmissionID: 1}, nil)
+		mockDB.EXPECT().GetSubmissionFiles(uint(4)).Return([]*models.SubmissionFile{
+			{Path: "Beak.pde", Content: []byte("void beak() {\n  fill(255);\n}\n")},
+			{Path: "Notes.txt", Content: []byte("todo\n")},
+		}, nil)
+		mockDB.EXPECT().GetSubmissionFiles(uint(5)).Return([]*models.SubmissionFile{
+			{Path: "Beak.pde", Content: []byte("void beak() {\n  fill(0);\n}\n")},
+			{Path: "Wing.pde", Content: []byte("void wing() {}\n")},
+		}, nil)
+
+		var resp struct {
+			VersionDiff []struct {
+				Path, Status, Patch  string
+				Additions, Deletions int
+			}
+		}
+		c.MustPost(`{ versionDiff(fromVersionID: "4", toVersionID: "5") { path status additions deletions patch } }`, &resp)
+
+		require.Len(t, resp.VersionDiff, 3)
+		assert.Equal(t, "Beak.pde", resp.VersionDiff[0].Path)
+		assert.Equal(t, "MODIFIED", resp.VersionDiff[0].Status)
+		assert.Equal(t, 1, resp.VersionDiff[0].Additions)
+		assert.Equal(t, 1, resp.VersionDiff[0].Deletions)
+		assert.Equal(t, "--- a/Beak.pde\n+++ b/Beak.pde\n@@ -1,3 +1,3 @@\n void beak() {\n-  fill(255);\n+  fill(0);\n }\n", resp.VersionDiff[0].Patch)
+		assert.Equal(t, "Notes.txt", resp.VersionDiff[1].Path)
+		assert.Equal(t, "REMOVED", resp.VersionDiff[1].Status)
+		assert.Equal(t, "Wing.pde", resp.VersionDiff[2].Path)
+		assert.Equal(t, "ADDED", resp.VersionDiff[2].Status)
+		assert.Equal(t, 1, resp.VersionDiff[2].Additions)
+	})
+
+	t.Run("Version Diff - Different Submissions", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(&models.SubmissionVersion{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}, nil)
+		mockDB.EXPECT().GetSubmissionVersion("9").Return(&models.SubmissionVersion{Model: gorm.Model{ID: 9}, Number: 1, SubmissionID: 2}, nil)
+
+		var resp struct {
+			VersionDiff []struct{ Path string }
+		}
+		err := c.Post(`{ versionDiff(fromVersionID: "4", toVersionID: "9") { path } }`, &resp)
+
+		assert.ErrorContains(t, err, "versions belong to different submissions")
+	})
+
+	t.Run("Get Submission Lateness", func(t *testing.T) {
+		t.Parallel()
+
+		due := time.Date(2022, 10, 1, 17, 0, 0, 0, time.UTC)
+		cutoff := due.Add(72 * time.Hour)
+		policy := models.LatePolicy{GracePeriod: 15 * time.Minute, PenaltyPerDay: 10, Cutoff: &cutoff}
+
+		tests := []struct {
+			name        string
+			submittedAt time.Time
+			late        bool
+			pastCutoff  bool
+			daysLate    int
+			penalty     float64
+		}{
+			{"On Time", due.Add(-time.Minute), false, false, 0, 0},
+			{"Within Grace Period", due.Add(10 * time.Minute), false, false, 0, 0},
+			{"Part Day Late", due.Add(time.Hour), true, false, 1, 10},
+			{"Past Cutoff", due.Add(80 * time.Hour), true, true, 4, 100},
+		}
+
+		for _, tt := range tests {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				ctrl := gomock.NewController(t)
+				mockDB := mocks.NewMockDatabase(ctrl)
+				c := newClient(mockDB, true)
+
+				mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil)
+				mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil)
+				mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, DueDate: due, LatePolicy: policy}, nil).Times(2)
+				mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{
+					{Model: gorm.Model{ID: 4, CreatedAt: tt.submittedAt}, Number: 1, SubmissionID: 1},
+				}, nil)
+				mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+
+				var resp struct {
+					Submission struct {
+						Lateness struct {
+							Late, PastCutoff bool
+							DaysLate         int
+							Penalty          float64
+						}
+					}
+				}
+				c.MustPost(`{ submission(id:"1") { lateness { late pastCutoff daysLate penalty } } }`, &resp)
+
+				assert.Equal(t, tt.late, resp.Submission.Lateness.Late)
+				assert.Equal(t, tt.pastCutoff, resp.Submission.Lateness.PastCutoff)
+				assert.Equal(t, tt.daysLate, resp.Submission.Lateness.DaysLate)
+				assert.Equal(t, tt.penalty, resp.Submission.Lateness.Penalty)
+			})
+		}
+	})
+
+	t.Run("Update Late Policy", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		due := time.Unix(1664643600, 0)
+		cutoff := due.Add(48 * time.Hour)
+		policy := models.LatePolicy{GracePeriod: 10 * time.Minute, PenaltyPerDay: 5, MaxPenalty: 20, Cutoff: &cutoff}
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", DueDate: due}, nil)
+		mockDB.EXPECT().UpdateLatePolicy(uint(1), policy).Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", DueDate: due, LatePolicy: policy}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", DueDate: due, LatePolicy: policy}, nil)
+
+		var resp struct {
+			UpdateLatePolicy struct {
+				ID         string
+				LatePolicy struct {
+					GracePeriod               int
+					PenaltyPerDay, MaxPenalty float64
+					Cutoff                    *int
+				}
+			}
+		}
+		c.MustPost(fmt.Sprintf(`mutation { updateLatePolicy(assignmentID: "1", policy: {gracePeriod: 600, penaltyPerDay: 5, maxPenalty: 20, cutoff: %d}) { id latePolicy { gracePeriod penaltyPerDay maxPenalty cutoff } } }`, cutoff.Unix()), &resp)
+
+		assert.Equal(t, "1", resp.UpdateLatePolicy.ID)
+		assert.Equal(t, 600, resp.UpdateLatePolicy.LatePolicy.GracePeriod)
+		assert.Equal(t, float64(5), resp.UpdateLatePolicy.LatePolicy.PenaltyPerDay)
+		assert.Equal(t, float64(20), resp.UpdateLatePolicy.LatePolicy.MaxPenalty)
+		require.NotNil(t, resp.UpdateLatePolicy.LatePolicy.Cutoff)
+		assert.Equal(t, int(cutoff.Unix()), *resp.UpdateLatePolicy.LatePolicy.Cutoff)
+	})
+
+	t.Run("Update Late Policy - Cutoff Before Due Date", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		due := time.Unix(1664643600, 0)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", DueDate: due}, nil)
+
+		var resp struct {
+			UpdateLatePolicy struct{ ID string }
+		}
+		err := c.Post(fmt.Sprintf(`mutation { updateLatePolicy(assignmentID: "1", policy: {gracePeriod: 0, penaltyPerDay: 5, maxPenalty: 0, cutoff: %d}) { id } }`, due.Add(-time.Hour).Unix()), &resp)
+
+		assert.ErrorContains(t, err, "cutoff must not be before the due date")
+	})
+
+	t.Run("Update Attempt Policy", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", AttemptPolicy: models.AttemptPolicyLatest}, nil)
+		mockDB.EXPECT().UpdateAttemptPolicy(uint(1), models.AttemptPolicyFirst).Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", AttemptPolicy: models.AttemptPolicyFirst}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", AttemptPolicy: models.AttemptPolicyFirst}, nil)
+
+		var resp struct {
+			UpdateAttemptPolicy struct{ ID, AttemptPolicy string }
+		}
+		c.MustPost(`mutation { updateAttemptPolicy(assignmentID: "1", policy: FIRST) { id attemptPolicy } }`, &resp)
+
+		assert.Equal(t, "1", resp.UpdateAttemptPolicy.ID)
+		assert.Equal(t, "FIRST", resp.UpdateAttemptPolicy.AttemptPolicy)
+	})
+
+	t.Run("Create Submission - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		var resp struct {
+			CreateSubmission struct{ ID, StudentID string }
+		}
+		err := c.Post(`mutation { createSubmission(input: {studentID: "44444444", assignmentID: "1"}) { id studentID} }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+}
+
+func TestResultResolver(t *testing.T) {
+	t.Parallel()
+
+	var resp struct {
+		Result struct {
+			ID, Date, SubmissionID string
+			Score                  float64
+		}
+	}
+
+	t.Run("Get Result", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetResult("1").Return(&models.Result{Model: gorm.Model{ID: 1, CreatedAt: now}, Score: 99, SubmissionID: 1}, nil)
+
+		c.MustPost(`{ result(id:"1") { id date score submissionID } }`, &resp)
+
+		assert.Equal(t, "1", resp.Result.ID)
+		year, month, day := now.Date()
+		assert.Equal(t, fmt.Sprintf("%02d/%02d/%d", day, month, year), resp.Result.Date)
+		assert.Equal(t, "1", resp.Result.SubmissionID)
+		assert.Equal(t, float64(99), resp.Result.Score)
+	})
+
+	t.Run("Get All Results", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		now := time.Now()
+
+		mockDB.EXPECT().GetAllResults(1).Return([]*models.Result{
+			{Model: gorm.Model{ID: 1, CreatedAt: now}, Score: 99, SubmissionID: 1},
+			{Model: gorm.Model{ID: 2, CreatedAt: now}, Score: 51, SubmissionID: 2},
+		}, nil)
+
+		var resp struct {
+			Results []struct {
+				ID, Date, SubmissionID string
+				Score                  float64
+			}
+		}
+		c.MustPost(`{ results() { id score date submissionID } }`, &resp)
+
+		assert.Equal(t, "1", resp.Results[0].ID)
+		assert.Equal(t, "2", resp.Results[1].ID)
+		assert.Equal(t, now.Format("02/01/2006"), resp.Results[0].Date)
+		assert.Equal(t, now.Format("02/01/2006"), resp.Results[1].Date)
+		assert.Equal(t, float64(99), resp.Results[0].Score)
+		assert.Equal(t, float64(51), resp.Results[1].Score)
+		assert.Equal(t, "1", resp.Results[0].SubmissionID)
+		assert.Equal(t, "2", resp.Results[1].SubmissionID)
+	})
+
+	t.Run("Get Result With Late Penalty", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		due := time.Date(2022, 10, 1, 17, 0, 0, 0, time.UTC)
+		versionID := uint(4)
+		mockDB.EXPECT().GetResult("1").Return(&models.Result{Model: gorm.Model{ID: 1}, Score: 80, SubmissionID: 1, SubmissionVersionID: &versionID}, nil)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1, CreatedAt: due.Add(-time.Hour)}, AssignmentID: 1}, nil).Times(2)
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(&models.SubmissionVersion{Model: gorm.Model{ID: 4, CreatedAt: due.Add(30 * time.Hour)}, Number: 2, SubmissionID: 1}, nil).Times(2)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{
+			Model:      gorm.Model{ID: 1},
+			DueDate:    due,
+			LatePolicy: models.LatePolicy{GracePeriod: 15 * time.Minute, PenaltyPerDay: 10, MaxPenalty: 50},
+		}, nil).Times(2)
+
+		var resp struct {
+			Result struct {
+				Score, AdjustedScore float64
+				SubmissionVersionID  string
+				Lateness             struct {
+					Late, PastCutoff bool
+					LateBy, DaysLate int
+					Penalty          float64
+				}
+			}
+		}
+		c.MustPost(`{ result(id:"1") { score adjustedScore submissionVersionID lateness { late pastCutoff lateBy daysLate penalty } } }`, &resp)
+
+		assert.Equal(t, float64(80), resp.Result.Score)
+		assert.Equal(t, float64(64), resp.Result.AdjustedScore)
+		assert.Equal(t, "4", resp.Result.SubmissionVersionID)
+		assert.True(t, resp.Result.Lateness.Late)
+		assert.False(t, resp.Result.Lateness.PastCutoff)
+		assert.Equal(t, 30*60*60, resp.Result.Lateness.LateBy)
+		assert.Equal(t, 2, resp.Result.Lateness.DaysLate)
+		assert.Equal(t, float64(20), resp.Result.Lateness.Penalty)
+	})
+
+	t.Run("Override Result", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		original := 4.0
+		mockDB.EXPECT().GetResult("1").Return(&models.Result{Model: gorm.Model{ID: 1}, Score: 4, SubmissionID: 1}, nil)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil)
+		mockDB.EXPECT().OverrideResult(uint(1), 9.0, "Executor timed out on the last test", "user@example.com").
+			Return(&models.Result{Model: gorm.Model{ID: 1}, Score: 9, OriginalScore: &original, SubmissionID: 1}, nil)
+		mockDB.EXPECT().GetResultOverrides(uint(1)).Return([]*models.ResultOverride{
+			{Model: gorm.Model{ID: 2}, PreviousScore: 4, Score: 9, Reason: "Executor timed out on the last test", OverriddenBy: "user@example.com", ResultID: 1},
+		}, nil)
+
+		var resp struct {
+			OverrideResult struct {
+				Score, OriginalScore float64
+				Overridden           bool
+				Overrides            []struct {
+					PreviousScore, Score float64
+					Reason, OverriddenBy string
+				}
+			}
+		}
+		c.MustPost(`mutation { overrideResult(resultID: "1", score: 9, reason: " Executor timed out on the last test ") {
+			score originalScore overridden overrides { previousScore score reason overriddenBy }
+		} }`, &resp)
+
+		assert.Equal(t, float64(9), resp.OverrideResult.Score)
+		assert.Equal(t, float64(4), resp.OverrideResult.OriginalScore)
+		assert.True(t, resp.OverrideResult.Overridden)
+		require.Len(t, resp.OverrideResult.Overrides, 1)
+		assert.Equal(t, float64(4), resp.OverrideResult.Overrides[0].PreviousScore)
+		assert.Equal(t, float64(9), resp.OverrideResult.Overrides[0].Score)
+		assert.Equal(t, "user@example.com", resp.OverrideResult.Overrides[0].OverriddenBy)
+	})
+
+	t.Run("Override Result - Missing Reason", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		var resp struct {
+			OverrideResult struct{ ID string }
+		}
+		err := c.Post(`mutation { overrideResult(resultID: "1", score: 9, reason: " ") { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "reason is required")
+	})
+
+	t.Run("Override Result - Above Maximum", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetResult("1").Return(&models.Result{Model: gorm.Model{ID: 1}, Score: 4, SubmissionID: 1}, nil)
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil)
+
+		var resp struct {
+			OverrideResult struct{ ID string }
+		}
+		err := c.Post(`mutation { overrideResult(resultID: "1", score: 11, reason: "Bonus") { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "score must not be more than the maximum score of 10")
+	})
+
+	t.Run("Get Result Not Found", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetResult("1").Return(nil, db.ErrRecordNotFound)
+
+		err := c.Post(`{ result(id:"1") { id date score submissionID } }`, &resp)
+
+		assert.ErrorContains(t, err, "record not found")
+		assert.NotEqual(t, "1", resp.Result.ID)
+	})
+}
+
+func TestRegisterResolver(t *testing.T) {
+	t.Parallel()
+
+	var resp struct {
+		Register string
+	}
+
+	user := &models.User{Model: gorm.Model{ID: 1}, Email: "a@b.com", PasswordHash: "password"}
+
+	t.Run("New User", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateUser("a@b.com", gomock.Any(), models.UserRoleTutor).Return(user, nil)
+
+		c.MustPost(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+
+		assert.NotEmpty(t, resp.Register)
+	})
+
+	t.Run("New Admin", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
@@ -999,222 +1963,3485 @@ func TestRegisterResolver(t *testing.T) {
 
 		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
 		mockDB.EXPECT().CreateUser("a@b.com", gomock.Any(), models.UserRoleAdmin).Return(user, nil)
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(user, db.ErrRecordNotFound)
 
-		c.MustPost(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
-		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
-		assert.Error(t, err)
+		c.MustPost(`mutation { register(email:"a@b.com", password: "password", role: ADMIN) }`, &resp)
+
+		assert.NotEmpty(t, resp.Register)
+	})
+
+	t.Run("Not Authenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+
+	t.Run("Not Admin", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, &models.User{Email: "tutor@example.com", Role: models.UserRoleTutor})
+
+		err := c.Post(`mutation { register(email:"a@b.com", password: "password", role: ADMIN) }`, &resp)
+
+		assert.ErrorContains(t, err, "you must be an admin to register a user")
+	})
+
+	t.Run("User Exists", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateUser("a@b.com", gomock.Any(), models.UserRoleTutor).Return(user, nil)
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(user, db.ErrRecordNotFound)
+
+		c.MustPost(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("No Email", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		err := c.Post(`mutation { register(email:"", password: "password") }`, &resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("No Password", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		err := c.Post(`mutation { register(email:"a@b.com", password: "") }`, &resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("Error Getting User", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		customErr := errors.New("my cool error")
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, customErr)
+
+		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+
+		assert.ErrorContains(t, err, customErr.Error())
+	})
+
+	t.Run("Error Creating User - error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		customErr := errors.New("my cool error")
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateUser("a@b.com", gomock.Any(), models.UserRoleTutor).Return(nil, customErr)
+
+		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+
+		assert.ErrorContains(t, err, customErr.Error())
+	})
+
+	t.Run("Error Creating User - nil user", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateUser("a@b.com", gomock.Any(), models.UserRoleTutor).Return(nil, nil)
+
+		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+
+		assert.ErrorContains(t, err, "error creating user")
+	})
+}
+
+func TestLoginResolver(t *testing.T) {
+	t.Parallel()
+
+	var resp struct {
+		Login string
+	}
+
+	user := &models.User{Model: gorm.Model{ID: 1}, Email: "a@b.com", PasswordHash: "$2y$10$iVyaKJWb4LzkbCMNKl6biuNQNdBG1WSsn3/cMkg3VHg5RSpQTJW0K"}
+
+	t.Run("User", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(user, nil)
+
+		c.MustPost(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
+
+		assert.NotEmpty(t, resp.Login)
+	})
+
+	t.Run("No Email", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		err := c.Post(`mutation { login(email:"", password: "password") }`, &resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("No Password", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		err := c.Post(`mutation { login(email:"a@b.com", password: "") }`, &resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("User Does Not Exist - not found error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
+
+		err := c.Post(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("User Does Not Exist - nil user", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, nil)
+
+		err := c.Post(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
+		assert.ErrorContains(t, err, "incorrect email or password")
+	})
+
+	t.Run("User Incorrect Password", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(user, nil)
+		mockDB.EXPECT().RecordLoginFailure(uint(1)).Return(1, nil)
+		mockDB.EXPECT().LockUser(uint(1), gomock.Any()).Return(nil)
+
+		err := c.Post(`mutation { login(email:"a@b.com", password: "wrong_password") }`, &resp)
+		assert.ErrorContains(t, err, "incorrect email or password")
+	})
+
+	t.Run("User Locked", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		lockedUser := *user
+		lockedUser.FailedLoginAttempts = 10
+		lockedUser.LockedUntil = time.Now().Add(time.Hour)
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(&lockedUser, nil)
+
+		err := c.Post(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
+		assert.ErrorContains(t, err, "too many failed login attempts")
+	})
+
+	t.Run("User Previously Failed", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		failedUser := *user
+		failedUser.FailedLoginAttempts = 2
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(&failedUser, nil)
+		mockDB.EXPECT().UpdateLoginFailures(uint(1), 0, time.Time{}).Return(nil)
+
+		c.MustPost(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
+
+		assert.NotEmpty(t, resp.Login)
+	})
+
+	t.Run("Unknown Email Throttled", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound).Times(auth.AccountPolicy.FreeAttempts)
+
+		for i := 0; i < auth.AccountPolicy.FreeAttempts; i++ {
+			err := c.Post(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
+			assert.ErrorContains(t, err, "incorrect email or password")
+		}
+
+		err := c.Post(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
+		assert.ErrorContains(t, err, "too many failed login attempts")
+	})
+}
+
+func TestUnlockUserMutation(t *testing.T) {
+	t.Parallel()
+
+	var resp struct {
+		UnlockUser bool
+	}
+
+	t.Run("Unlock User", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(&models.User{Model: gorm.Model{ID: 2}, Email: "a@b.com", FailedLoginAttempts: 10}, nil)
+		mockDB.EXPECT().UpdateLoginFailures(uint(2), 0, time.Time{}).Return(nil)
+
+		c.MustPost(`mutation { unlockUser(email:"a@b.com") }`, &resp)
+
+		assert.True(t, resp.UnlockUser)
+	})
+
+	t.Run("Unlock User - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		err := c.Post(`mutation { unlockUser(email:"a@b.com") }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+}
+
+func TestResetDBMutation(t *testing.T) {
+	t.Parallel()
+
+	var resp struct {
+		ResetDB bool
+	}
+
+	t.Run("Reset DB", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().ResetDB().Return(mockDB, nil)
+
+		c.MustPost(`mutation { resetDB() }`, &resp)
+
+		assert.True(t, resp.ResetDB)
+	})
+
+	t.Run("Reset DB - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		err := c.Post(`mutation { resetDB() }`, &resp)
+
+		require.Error(t, err)
+
+		assert.False(t, resp.ResetDB)
+	})
+}
+
+func TestRunTestMutation(t *testing.T) {
+	var resp struct {
+		RunTest bool
+	}
+
+	t.Run("Run Test", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+
+		var body map[string]string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}))
+		defer srv.Close()
+		c := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: &Resolver{
+			DB:          mockDB,
+			ExtractUser: func(ctx context.Context) *models.User { return &models.User{Email: "user@example.com"} },
+			Config:      config.Config{TestExecutorEndpoint: srv.URL},
+		}})))
+
+		mockDB.EXPECT().GetTest("2").Return(&models.Test{Model: gorm.Model{ID: 2}, Name: "Test 1", AssignmentID: 3}, nil)
+		mockDB.EXPECT().GetAssignment("3").Return(&models.Assignment{Model: gorm.Model{ID: 3}, Name: "Assignment 1", ClassID: 1}, nil)
+
+		c.MustPost(`mutation { runTest(testID: "2") }`, &resp)
+
+		assert.True(t, resp.RunTest)
+		// Keys are by ID, so the same assignment in another term doesn't share them.
+		assert.Equal(t, map[string]string{
+			"s3KeyTestFile":    "Assignments/3/Tests/2/Test.java",
+			"s3KeyProjectFile": "Assignments/3/Projects/",
+		}, body)
+	})
+}
+
+// expectTransaction runs the next transaction against the mock itself.
+func expectTransaction(mockDB *mocks.MockDatabase) {
+	mockDB.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(db.Database) error) error {
+		return fn(mockDB)
+	})
+}
+
+func newAuditedClient(mockDB *mocks.MockDatabase) *client.Client {
+	return newAuditedClientAs(mockDB, &models.User{Email: "user@example.com"})
+}
+
+func newAuditedClientAs(mockDB *mocks.MockDatabase, user *models.User) *client.Client {
+	resolver := &Resolver{
+		DB:              mockDB,
+		ExtractUser:     func(ctx context.Context) *models.User { return user },
+		ExtractIP:       func(ctx context.Context) string { return "127.0.0.1" },
+		IPThrottle:      auth.NewLoginThrottle(auth.IPPolicy),
+		AccountThrottle: auth.NewLoginThrottle(auth.AccountPolicy),
+	}
+
+	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
+	srv.AroundFields(resolver.AuditMiddleware)
+
+	return client.New(srv)
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Records Mutation", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newAuditedClient(mockDB)
+
+		mockDB.EXPECT().GetUnitByName("COMP1000").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateUnit("COMP1000").Return(&models.Unit{Model: gorm.Model{ID: 1}, Name: "COMP1000"}, nil)
+		mockDB.EXPECT().CreateAuditEvent(gomock.Any()).DoAndReturn(func(event models.AuditEvent) (*models.AuditEvent, error) {
+			assert.Equal(t, "user@example.com", event.Actor)
+			assert.Empty(t, event.AttemptedEmail)
+			assert.Equal(t, "createUnit", event.Action)
+			assert.Equal(t, "Unit", event.EntityType)
+			assert.Equal(t, "1", event.EntityID)
+			assert.Equal(t, "127.0.0.1", event.RequestIP)
+			assert.Empty(t, event.Before)
+			assert.Contains(t, event.After, `"name":"COMP1000"`)
+
+			return &event, nil
+		})
+
+		var resp struct {
+			CreateUnit struct{ ID, Name string }
+		}
+		c.MustPost(`mutation { createUnit(input: {name: "COMP1000"}) { id name } }`, &resp)
+
+		assert.Equal(t, "1", resp.CreateUnit.ID)
+	})
+
+	t.Run("Records Entity Of Scalar Mutation", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newAuditedClient(mockDB)
+
+		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(&models.User{Model: gorm.Model{ID: 2}, Email: "a@b.com", FailedLoginAttempts: 10}, nil)
+		mockDB.EXPECT().UpdateLoginFailures(uint(2), 0, time.Time{}).Return(nil)
+		mockDB.EXPECT().CreateAuditEvent(gomock.Any()).DoAndReturn(func(event models.AuditEvent) (*models.AuditEvent, error) {
+			// The email argument names the user being unlocked, not the actor.
+			assert.Equal(t, "user@example.com", event.Actor)
+			assert.Empty(t, event.AttemptedEmail)
+			assert.Equal(t, "unlockUser", event.Action)
+			assert.Equal(t, "User", event.EntityType)
+			assert.Equal(t, "2", event.EntityID)
+			assert.Contains(t, event.Before, `"failedLoginAttempts":10`)
+			assert.Empty(t, event.After)
+
+			return &event, nil
+		})
+
+		var resp struct {
+			UnlockUser bool
+		}
+		c.MustPost(`mutation { unlockUser(email:"a@b.com") }`, &resp)
+
+		assert.True(t, resp.UnlockUser)
+	})
+
+	t.Run("Records Failed Mutation", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newAuditedClient(mockDB)
+
+		mockDB.EXPECT().GetUnitByName("COMP1000").Return(&models.Unit{Model: gorm.Model{ID: 1}, Name: "COMP1000"}, nil)
+		mockDB.EXPECT().CreateAuditEvent(gomock.Any()).DoAndReturn(func(event models.AuditEvent) (*models.AuditEvent, error) {
+			assert.Equal(t, "createUnit", event.Action)
+			assert.Equal(t, "user@example.com", event.Actor)
+			assert.Contains(t, event.Error, "unit already exists")
+			assert.Empty(t, event.After)
+
+			return &event, nil
+		})
+
+		var resp struct {
+			CreateUnit struct{ ID, Name string }
+		}
+		err := c.Post(`mutation { createUnit(input: {name: "COMP1000"}) { id name } }`, &resp)
+
+		assert.ErrorContains(t, err, "unit already exists")
+	})
+
+	t.Run("Records Anonymous Login", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newAuditedClientAs(mockDB, nil)
+
+		mockDB.EXPECT().GetUserByEmail("victim@example.com").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateAuditEvent(gomock.Any()).DoAndReturn(func(event models.AuditEvent) (*models.AuditEvent, error) {
+			// Anyone can log in as any email, so the attempt isn't attributed to it.
+			assert.Equal(t, "anonymous", event.Actor)
+			assert.Equal(t, "victim@example.com", event.AttemptedEmail)
+			assert.Equal(t, "login", event.Action)
+			assert.Contains(t, event.Error, "incorrect email or password")
+
+			return &event, nil
+		})
+
+		var resp struct {
+			Login string
+		}
+		err := c.Post(`mutation { login(email:"victim@example.com", password: "password") }`, &resp)
+
+		assert.ErrorContains(t, err, "incorrect email or password")
+	})
+
+	t.Run("Skips Query", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newAuditedClient(mockDB)
+
+		mockDB.EXPECT().GetUnitByID("1", false).Return(&models.Unit{Model: gorm.Model{ID: 1}, Name: "COMP1000"}, nil)
+
+		var resp struct {
+			Unit struct{ ID, Name string }
+		}
+		c.MustPost(`{ unit(id:"1") { id name } }`, &resp)
+
+		assert.Equal(t, "1", resp.Unit.ID)
+	})
+}
+
+func TestAuditLogResolver(t *testing.T) {
+	t.Parallel()
+
+	var resp struct {
+		AuditLog []struct {
+			ID, Actor, Action, EntityType, EntityID, IP string
+			Before, After                               *string
+			Timestamp                                   int
+		}
+	}
+
+	t.Run("Get Audit Log", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		createdAt := time.Unix(1660657596, 0)
+		mockDB.EXPECT().GetAuditEvents(models.AuditEventFilter{Action: "createUnit", Since: time.Unix(1660000000, 0)}, 1).Return([]*models.AuditEvent{
+			{Model: gorm.Model{ID: 1, CreatedAt: createdAt}, Actor: "a@b.com", Action: "createUnit", EntityType: "Unit", EntityID: "3", After: `{"id":"3"}`, RequestIP: "10.0.0.1"},
+		}, nil)
+
+		c.MustPost(`{ auditLog(filter: {action: "createUnit", since: 1660000000}) { id actor action entityType entityID before after ip timestamp } }`, &resp)
+
+		require.Len(t, resp.AuditLog, 1)
+		assert.Equal(t, "a@b.com", resp.AuditLog[0].Actor)
+		assert.Equal(t, "3", resp.AuditLog[0].EntityID)
+		assert.Nil(t, resp.AuditLog[0].Before)
+		assert.Equal(t, `{"id":"3"}`, *resp.AuditLog[0].After)
+		assert.Equal(t, "10.0.0.1", resp.AuditLog[0].IP)
+		assert.Equal(t, 1660657596, resp.AuditLog[0].Timestamp)
+	})
+
+	t.Run("Get Audit Log - Attempted Email", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAuditEvents(models.AuditEventFilter{AttemptedEmail: "a@b.com"}, 1).Return([]*models.AuditEvent{
+			{Model: gorm.Model{ID: 2}, Actor: "anonymous", AttemptedEmail: "a@b.com", Action: "login", Error: "incorrect email or password"},
+		}, nil)
+
+		var resp struct {
+			AuditLog []struct {
+				Actor          string
+				AttemptedEmail *string
+			}
+		}
+		c.MustPost(`{ auditLog(filter: {attemptedEmail: "a@b.com"}) { actor attemptedEmail } }`, &resp)
+
+		require.Len(t, resp.AuditLog, 1)
+		assert.Equal(t, "anonymous", resp.AuditLog[0].Actor)
+		require.NotNil(t, resp.AuditLog[0].AttemptedEmail)
+		assert.Equal(t, "a@b.com", *resp.AuditLog[0].AttemptedEmail)
+	})
+
+	t.Run("Get Audit Log - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		err := c.Post(`{ auditLog { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+}
+
+func TestStudentResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get Student", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudent("1").Return(&models.Student{Model: gorm.Model{ID: 1}, StudentNumber: "s0001", Name: "Alice Penguin", Email: "alice@example.com"}, nil)
+		mockDB.EXPECT().GetClassesForStudent(uint(1)).Return([]*models.Class{{Model: gorm.Model{ID: 2}, Name: "Class 1"}}, nil)
+		mockDB.EXPECT().GetSubmissionsForStudent(uint(1)).Return([]*models.Submission{{Model: gorm.Model{ID: 3}, StudentID: "s0001_Alice_Penguin"}}, nil)
+
+		var resp struct {
+			Student struct {
+				ID, StudentNumber, Name, Email string
+				Classes                        []struct{ ID, Name string }
+				Submissions                    []struct{ ID, StudentID string }
+			}
+		}
+		c.MustPost(`{ student(id:"1") { id studentNumber name email classes { id name } submissions { id studentID } } }`, &resp)
+
+		assert.Equal(t, "s0001", resp.Student.StudentNumber)
+		assert.Equal(t, "Alice Penguin", resp.Student.Name)
+		assert.Equal(t, "alice@example.com", resp.Student.Email)
+		assert.Equal(t, "2", resp.Student.Classes[0].ID)
+		assert.Equal(t, "3", resp.Student.Submissions[0].ID)
+	})
+
+	t.Run("Get Class Students", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetClass("1").Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(1)).Return([]*models.Student{{Model: gorm.Model{ID: 1}, StudentNumber: "s0001"}}, nil)
+
+		var resp struct {
+			Class struct {
+				Students []struct{ ID, StudentNumber string }
+			}
+		}
+		c.MustPost(`{ class(id:"1") { students { id studentNumber } } }`, &resp)
+
+		assert.Equal(t, "s0001", resp.Class.Students[0].StudentNumber)
+	})
+
+	t.Run("Get Missing Students", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1"}, nil)
+		mockDB.EXPECT().GetStudentsWithoutSubmission("1").Return([]*models.Student{{Model: gorm.Model{ID: 2}, StudentNumber: "s0003", Name: "Bob Eagle"}}, nil)
+
+		var resp struct {
+			Assignment struct {
+				MissingStudents []struct{ ID, Name string }
+			}
+		}
+		c.MustPost(`{ assignment(id:"1") { missingStudents { id name } } }`, &resp)
+
+		assert.Equal(t, "Bob Eagle", resp.Assignment.MissingStudents[0].Name)
+	})
+
+	t.Run("Create Student", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudentByNumber("s0001").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateStudent("s0001", "Alice Penguin", "alice@example.com").Return(&models.Student{Model: gorm.Model{ID: 1}, StudentNumber: "s0001", Name: "Alice Penguin", Email: "alice@example.com"}, nil)
+
+		var resp struct {
+			CreateStudent struct{ ID, StudentNumber string }
+		}
+		c.MustPost(`mutation { createStudent(input: {studentNumber: "s0001", name: "Alice Penguin", email: "alice@example.com"}) { id studentNumber } }`, &resp)
+
+		assert.Equal(t, "1", resp.CreateStudent.ID)
+		assert.Equal(t, "s0001", resp.CreateStudent.StudentNumber)
+	})
+
+	t.Run("Create Student - Already Exists", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudentByNumber("s0001").Return(&models.Student{Model: gorm.Model{ID: 1}, StudentNumber: "s0001"}, nil)
+
+		var resp struct {
+			CreateStudent struct{ ID string }
+		}
+		err := c.Post(`mutation { createStudent(input: {studentNumber: "s0001", name: "Alice Penguin", email: "alice@example.com"}) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "student already exists")
+	})
+
+	t.Run("Enrol Student", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudent("1").Return(&models.Student{Model: gorm.Model{ID: 1}, StudentNumber: "s0001"}, nil)
+		mockDB.EXPECT().GetClass("2").Return(&models.Class{Model: gorm.Model{ID: 2}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().EnrolStudent(uint(1), uint(2)).Return(nil)
+
+		var resp struct {
+			EnrolStudent struct{ ID string }
+		}
+		c.MustPost(`mutation { enrolStudent(studentID: "1", classID: "2") { id } }`, &resp)
+
+		assert.Equal(t, "1", resp.EnrolStudent.ID)
+	})
+
+	t.Run("Unenrol Student", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudent("1").Return(&models.Student{Model: gorm.Model{ID: 1}, StudentNumber: "s0001"}, nil)
+		mockDB.EXPECT().GetClass("2").Return(&models.Class{Model: gorm.Model{ID: 2}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().UnenrolStudent(uint(1), uint(2)).Return(nil)
+
+		var resp struct {
+			UnenrolStudent bool
+		}
+		c.MustPost(`mutation { unenrolStudent(studentID: "1", classID: "2") }`, &resp)
+
+		assert.True(t, resp.UnenrolStudent)
+	})
+
+	t.Run("Enrol Student - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		var resp struct {
+			EnrolStudent struct{ ID string }
+		}
+		err := c.Post(`mutation { enrolStudent(studentID: "1", classID: "2") { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+}
+
+func TestRubricResolver(t *testing.T) {
+	t.Parallel()
+
+	rubric := []*models.RubricCriterion{
+		{Model: gorm.Model{ID: 1}, Name: "Visuals", AssignmentID: 1, Levels: []models.RubricLevel{
+			{Model: gorm.Model{ID: 11}, Name: "Excellent", Points: 10, CriterionID: 1},
+			{Model: gorm.Model{ID: 12}, Name: "Poor", Points: 2, CriterionID: 1},
+		}},
+		{Model: gorm.Model{ID: 2}, Name: "Code Quality", AssignmentID: 1, Levels: []models.RubricLevel{
+			{Model: gorm.Model{ID: 21}, Name: "Good", Points: 5, CriterionID: 2},
+		}},
+	}
+
+	t.Run("Set Rubric", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "MarchPenguin"}, nil)
+		mockDB.EXPECT().HasRubricMarks(uint(1)).Return(false, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().SetRubric(uint(1), []models.RubricCriterion{
+			{Name: "Visuals", Description: "Penguin looks like a penguin", Levels: []models.RubricLevel{
+				{Name: "Excellent", Points: 10},
+				{Name: "Poor", Points: 2},
+			}},
+			{Name: "Code Quality", Levels: []models.RubricLevel{{Name: "Good", Points: 5}}},
+		}).Return(rubric, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(rubric, nil)
+
+		var resp struct {
+			SetRubric struct {
+				ID     string
+				Rubric []struct {
+					Name      string
+					MaxPoints float64
+					Levels    []struct {
+						Name   string
+						Points float64
+					}
+				}
+			}
+		}
+		c.MustPost(`mutation { setRubric(assignmentID: "1", criteria: [
+			{name: "Visuals", description: "Penguin looks like a penguin", levels: [{name: "Excellent", points: 10}, {name: "Poor", points: 2}]},
+			{name: "Code Quality", levels: [{name: "Good", points: 5}]}
+		]) { id rubric { name maxPoints levels { name points } } } }`, &resp)
+
+		assert.Equal(t, "1", resp.SetRubric.ID)
+		require.Len(t, resp.SetRubric.Rubric, 2)
+		assert.Equal(t, "Visuals", resp.SetRubric.Rubric[0].Name)
+		assert.Equal(t, float64(10), resp.SetRubric.Rubric[0].MaxPoints)
+		require.Len(t, resp.SetRubric.Rubric[0].Levels, 2)
+		assert.Equal(t, "Poor", resp.SetRubric.Rubric[0].Levels[1].Name)
+	})
+
+	t.Run("Set Rubric - Already Marked", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "MarchPenguin"}, nil)
+		mockDB.EXPECT().HasRubricMarks(uint(1)).Return(true, nil)
+
+		var resp struct {
+			SetRubric struct{ ID string }
+		}
+		err := c.Post(`mutation { setRubric(assignmentID: "1", criteria: [{name: "Visuals", levels: [{name: "Good", points: 1}]}]) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "rubric can't be changed once submissions have been marked")
+	})
+
+	t.Run("Mark Submission", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(rubric, nil)
+		mockDB.EXPECT().GetRubricMarks(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().SaveRubricMarks([]models.RubricMark{
+			{SubmissionID: 1, CriterionID: 1, LevelID: 11, Comment: "Lovely colours", MarkedBy: "user@example.com"},
+		}).Return(nil)
+
+		var resp struct {
+			MarkSubmission struct{ ID string }
+		}
+		c.MustPost(`mutation { markSubmission(submissionID: "1", selections: [{criterionID: "1", levelID: "11", comment: "Lovely colours"}]) { id } }`, &resp)
+
+		assert.Equal(t, "1", resp.MarkSubmission.ID)
+	})
+
+	t.Run("Mark Submission - Level From Another Criterion", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(rubric, nil)
+
+		var resp struct {
+			MarkSubmission struct{ ID string }
+		}
+		err := c.Post(`mutation { markSubmission(submissionID: "1", selections: [{criterionID: "1", levelID: "21"}]) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "level 21 is not a level of criterion 1")
+	})
+
+	t.Run("Get Rubric Marks And Grade", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		due := time.Date(2022, 10, 1, 17, 0, 0, 0, time.UTC)
+		marks := []*models.RubricMark{
+			{Model: gorm.Model{ID: 1}, SubmissionID: 1, CriterionID: 1, LevelID: 12, Comment: "Needs more penguin", MarkedBy: "tutor@example.com"},
+		}
+
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil).Times(3)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{
+			Model:      gorm.Model{ID: 1},
+			DueDate:    due,
+			LatePolicy: models.LatePolicy{PenaltyPerDay: 10},
+		}, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{
+			{Model: gorm.Model{ID: 4, CreatedAt: due.Add(time.Hour)}, Number: 1, SubmissionID: 1},
+		}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(4)).Return([]*models.TestOutcome{{Model: gorm.Model{ID: 1}, Points: 8, TestID: 1}}, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(rubric, nil).Times(2)
+		mockDB.EXPECT().GetRubricMarks(uint(1)).Return(marks, nil).Times(2)
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return([]*models.LintRule{
+			{Model: gorm.Model{ID: 1}, Kind: models.LintMagicNumbers, Deduction: 0.5, MaxDeduction: 1},
+			{Model: gorm.Model{ID: 2}, Kind: models.LintRequireComments},
+		}, nil)
+		mockDB.EXPECT().GetLintFindings(uint(4)).Return([]*models.LintFinding{
+			{RuleID: 1, Line: 3}, {RuleID: 1, Line: 4}, {RuleID: 1, Line: 5}, {RuleID: 2, Line: 7},
+		}, nil)
+
+		var resp struct {
+			Submission struct {
+				RubricMarks []struct {
+					Criterion         struct{ Name string }
+					Level             struct{ Name string }
+					Comment, MarkedBy string
+				}
+				Grade struct {
+					Automated, AutomatedMax, Rubric, RubricMax float64
+					Deductions, Total, MaxScore                float64
+					LatePenalty, Final                         float64
+					Complete                                   bool
+				}
+			}
+		}
+		c.MustPost(`{ submission(id:"1") {
+			rubricMarks { criterion { name } level { name } comment markedBy }
+			grade { automated automatedMax rubric rubricMax deductions total maxScore latePenalty final complete }
+		} }`, &resp)
+
+		require.Len(t, resp.Submission.RubricMarks, 1)
+		assert.Equal(t, "Visuals", resp.Submission.RubricMarks[0].Criterion.Name)
+		assert.Equal(t, "Poor", resp.Submission.RubricMarks[0].Level.Name)
+		assert.Equal(t, "Needs more penguin", resp.Submission.RubricMarks[0].Comment)
+		assert.Equal(t, "tutor@example.com", resp.Submission.RubricMarks[0].MarkedBy)
+
+		grade := resp.Submission.Grade
+		assert.Equal(t, float64(8), grade.Automated)
+		assert.Equal(t, float64(10), grade.AutomatedMax)
+		assert.Equal(t, float64(2), grade.Rubric)
+		assert.Equal(t, float64(15), grade.RubricMax)
+		assert.Equal(t, float64(1), grade.Deductions)
+		assert.Equal(t, float64(9), grade.Total)
+		assert.Equal(t, float64(25), grade.MaxScore)
+		assert.Equal(t, float64(10), grade.LatePenalty)
+		assert.InDelta(t, 8.1, grade.Final, 1e-9)
+		assert.False(t, grade.Complete)
+	})
+}
+
+func TestLintRuleResolver(t *testing.T) {
+	t.Parallel()
+
+	rules := []*models.LintRule{
+		{Model: gorm.Model{ID: 1}, Kind: models.LintMaxFunctionLength, Severity: models.LintSeverityWarning, Limit: 30, Deduction: 0.5, MaxDeduction: 2, AssignmentID: 1},
+		{Model: gorm.Model{ID: 2}, Kind: models.LintRequiredClass, Severity: models.LintSeverityError, Names: []string{"Penguin"}, Message: "Model the penguin as a class", AssignmentID: 1},
+	}
+
+	t.Run("Set Lint Rules", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "MarchPenguin"}, nil)
+		mockDB.EXPECT().SetLintRules(uint(1), []models.LintRule{
+			{Kind: models.LintMaxFunctionLength, Severity: models.LintSeverityWarning, Limit: 30, Deduction: 0.5, MaxDeduction: 2},
+			{Kind: models.LintRequiredClass, Severity: models.LintSeverityError, Names: []string{"Penguin"}, Message: "Model the penguin as a class"},
+		}).Return(rules, nil)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(rules, nil)
+
+		var resp struct {
+			SetLintRules struct {
+				LintRules []struct {
+					ID, Kind, Severity string
+					Limit              *int
+					Names              []string
+					Message            *string
+					Deduction          float64
+					MaxDeduction       *float64
+				}
+			}
+		}
+		c.MustPost(`mutation { setLintRules(assignmentID: "1", rules: [
+			{kind: MAX_FUNCTION_LENGTH, limit: 30, deduction: 0.5, maxDeduction: 2},
+			{kind: REQUIRED_CLASS, severity: ERROR, names: [" Penguin ", ""], message: "Model the penguin as a class"}
+		]) { lintRules { id kind severity limit names message deduction maxDeduction } } }`, &resp)
+
+		require.Len(t, resp.SetLintRules.LintRules, 2)
+		length := resp.SetLintRules.LintRules[0]
+		assert.Equal(t, "MAX_FUNCTION_LENGTH", length.Kind)
+		assert.Equal(t, "WARNING", length.Severity)
+		assert.Equal(t, 30, *length.Limit)
+		assert.Empty(t, length.Names)
+		assert.Nil(t, length.Message)
+		assert.Equal(t, 2.0, *length.MaxDeduction)
+		class := resp.SetLintRules.LintRules[1]
+		assert.Equal(t, "ERROR", class.Severity)
+		assert.Nil(t, class.Limit)
+		assert.Equal(t, []string{"Penguin"}, class.Names)
+		assert.Equal(t, "Model the penguin as a class", *class.Message)
+		assert.Nil(t, class.MaxDeduction)
+	})
+
+	t.Run("Set Lint Rules - Missing Limit", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+
+		var resp struct {
+			SetLintRules struct{ ID string }
+		}
+		err := c.Post(`mutation { setLintRules(assignmentID: "1", rules: [{kind: MAX_COMPLEXITY}]) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "MAX_COMPLEXITY rules must have a limit of at least 1")
+	})
+
+	t.Run("Set Lint Rules - Negative Deduction", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+
+		var resp struct {
+			SetLintRules struct{ ID string }
+		}
+		err := c.Post(`mutation { setLintRules(assignmentID: "1", rules: [{kind: MAGIC_NUMBERS, deduction: -1}]) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "deductions must not be negative")
+	})
+
+	t.Run("Set Lint Rules - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		var resp struct {
+			SetLintRules struct{ ID string }
+		}
+		err := c.Post(`mutation { setLintRules(assignmentID: "1", rules: []) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+
+	t.Run("Get Lint Findings", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil).Times(2)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(rules, nil)
+		mockDB.EXPECT().GetLintFindings(uint(4)).Return([]*models.LintFinding{
+			{Severity: models.LintSeverityError, Message: "Model the penguin as a class", RuleID: 2, SubmissionVersionID: 4},
+			{Path: "MarchPenguin/MarchPenguin.pde", Line: 18, Severity: models.LintSeverityWarning, Message: "draw() is 42 lines long, more than 30", RuleID: 1, SubmissionVersionID: 4},
+			{Path: "MarchPenguin/MarchPenguin.pde", Line: 60, Severity: models.LintSeverityWarning, Message: "removed rule", RuleID: 9, SubmissionVersionID: 4},
+		}, nil)
+
+		var resp struct {
+			Submission struct {
+				LintFindings []struct {
+					Rule              struct{ ID, Kind string }
+					File              *string
+					Line              *int
+					Severity, Message string
+				}
+			}
+		}
+		c.MustPost(`{ submission(id:"1") { lintFindings { rule { id kind } file line severity message } } }`, &resp)
+
+		findings := resp.Submission.LintFindings
+		require.Len(t, findings, 2)
+		assert.Equal(t, "REQUIRED_CLASS", findings[0].Rule.Kind)
+		assert.Nil(t, findings[0].File)
+		assert.Nil(t, findings[0].Line)
+		assert.Equal(t, "ERROR", findings[0].Severity)
+		assert.Equal(t, "1", findings[1].Rule.ID)
+		assert.Equal(t, "MarchPenguin/MarchPenguin.pde", *findings[1].File)
+		assert.Equal(t, 18, *findings[1].Line)
+		assert.Equal(t, "draw() is 42 lines long, more than 30", findings[1].Message)
+	})
+}
+
+func TestCodeCommentResolver(t *testing.T) {
+	t.Parallel()
+
+	version := &models.SubmissionVersion{Model: gorm.Model{ID: 4}, Number: 2, SubmissionID: 1}
+	files := []*models.SubmissionFile{
+		{Path: "MarchPenguin/MarchPenguin.pde", Content: []byte("Penguin penguin;\n\nvoid setup() {\n  size(500, 500);\n}\n"), SubmissionVersionID: 4},
+	}
+
+	t.Run("Create Code Comment", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(version, nil)
+		mockDB.EXPECT().GetSubmissionFiles(uint(4)).Return(files, nil)
+		mockDB.EXPECT().CreateCodeComment(models.CodeComment{
+			Path:                "MarchPenguin/MarchPenguin.pde",
+			StartLine:           3,
+			EndLine:             5,
+			Author:              "user@example.com",
+			Body:                "setup() could use a comment",
+			SubmissionID:        1,
+			SubmissionVersionID: 4,
+		}).DoAndReturn(func(comment models.CodeComment) (*models.CodeComment, error) {
+			comment.ID = 7
+			return &comment, nil
+		})
+
+		var resp struct {
+			CreateCodeComment struct {
+				ID, Path, Author, Body string
+				StartLine, EndLine     int
+				Resolved               bool
+				Version                struct{ Number int }
+			}
+		}
+		c.MustPost(`mutation { createCodeComment(input: {versionID: "4", path: "MarchPenguin/MarchPenguin.pde", startLine: 3, endLine: 5, body: " setup() could use a comment "}) {
+			id path author body startLine endLine resolved version { number } } }`, &resp)
+
+		comment := resp.CreateCodeComment
+		assert.Equal(t, "7", comment.ID)
+		assert.Equal(t, "user@example.com", comment.Author)
+		assert.Equal(t, "setup() could use a comment", comment.Body)
+		assert.Equal(t, 3, comment.StartLine)
+		assert.Equal(t, 5, comment.EndLine)
+		assert.False(t, comment.Resolved)
+		assert.Equal(t, 2, comment.Version.Number)
+	})
+
+	t.Run("Create Code Comment - Past End Of File", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(version, nil)
+		mockDB.EXPECT().GetSubmissionFiles(uint(4)).Return(files, nil)
+
+		var resp struct {
+			CreateCodeComment struct{ ID string }
+		}
+		err := c.Post(`mutation { createCodeComment(input: {versionID: "4", path: "MarchPenguin/MarchPenguin.pde", startLine: 6, body: "Hi"}) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "line 6 is past the end of MarchPenguin/MarchPenguin.pde, which has 5 lines")
+	})
+
+	t.Run("Create Code Comment - Unknown File", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(version, nil)
+		mockDB.EXPECT().GetSubmissionFiles(uint(4)).Return(files, nil)
+
+		var resp struct {
+			CreateCodeComment struct{ ID string }
+		}
+		err := c.Post(`mutation { createCodeComment(input: {versionID: "4", path: "MarchPenguin/Walrus.pde", startLine: 1, body: "Hi"}) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "file MarchPenguin/Walrus.pde is not part of version 2")
+	})
+
+	t.Run("Resolve Another Tutor's Code Comment", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		existing := &models.CodeComment{Model: gorm.Model{ID: 7}, Path: "MarchPenguin/MarchPenguin.pde", StartLine: 3, EndLine: 5, Author: "tutor@example.com", Body: "Nice", SubmissionID: 1, SubmissionVersionID: 4}
+		resolved := *existing
+		resolved.Resolved = true
+
+		mockDB.EXPECT().GetCodeComment("7").Return(existing, nil)
+		mockDB.EXPECT().UpdateCodeComment(uint(7), "Nice", true).Return(&resolved, nil)
+		mockDB.EXPECT().GetSubmissionVersion("4").Return(version, nil)
+
+		var resp struct {
+			UpdateCodeComment struct {
+				Body     string
+				Resolved bool
+			}
+		}
+		c.MustPost(`mutation { updateCodeComment(id: "7", resolved: true) { body resolved } }`, &resp)
+
+		assert.Equal(t, "Nice", resp.UpdateCodeComment.Body)
+		assert.True(t, resp.UpdateCodeComment.Resolved)
+	})
+
+	t.Run("Edit Another Tutor's Code Comment", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetCodeComment("7").Return(&models.CodeComment{Model: gorm.Model{ID: 7}, Author: "tutor@example.com", Body: "Nice"}, nil)
+
+		var resp struct {
+			UpdateCodeComment struct{ ID string }
+		}
+		err := c.Post(`mutation { updateCodeComment(id: "7", body: "Not nice") { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "only the author of a comment can edit it")
+	})
+
+	t.Run("Delete Code Comment", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetCodeComment("7").Return(&models.CodeComment{Model: gorm.Model{ID: 7}, Author: "user@example.com"}, nil)
+		mockDB.EXPECT().DeleteCodeComment(uint(7)).Return(nil)
+
+		var resp struct{ DeleteCodeComment bool }
+		c.MustPost(`mutation { deleteCodeComment(id: "7") }`, &resp)
+
+		assert.True(t, resp.DeleteCodeComment)
+	})
+
+	t.Run("Get Submission Comments", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1}, nil).Times(2)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{
+			{Model: gorm.Model{ID: 3}, Number: 1, SubmissionID: 1},
+			version,
+		}, nil)
+		mockDB.EXPECT().GetCodeCommentsForSubmission(uint(1)).Return([]*models.CodeComment{
+			{Model: gorm.Model{ID: 6}, Path: "MarchPenguin/MarchPenguin.pde", StartLine: 1, EndLine: 1, Author: "tutor@example.com", Body: "Name this better", Resolved: true, SubmissionID: 1, SubmissionVersionID: 3},
+			{Model: gorm.Model{ID: 7}, Path: "MarchPenguin/MarchPenguin.pde", StartLine: 3, EndLine: 5, Author: "user@example.com", Body: "Nice", SubmissionID: 1, SubmissionVersionID: 4},
+		}, nil)
+
+		var resp struct {
+			Submission struct {
+				Comments []struct {
+					ID       string
+					Body     string
+					Resolved bool
+					Version  struct{ ID string }
+				}
+			}
+		}
+		c.MustPost(`{ submission(id:"1") { comments { id body resolved version { id } } } }`, &resp)
+
+		require.Len(t, resp.Submission.Comments, 2)
+		assert.Equal(t, "6", resp.Submission.Comments[0].ID)
+		assert.True(t, resp.Submission.Comments[0].Resolved)
+		assert.Equal(t, "3", resp.Submission.Comments[0].Version.ID)
+		assert.Equal(t, "4", resp.Submission.Comments[1].Version.ID)
+	})
+
+	t.Run("Create Code Comment - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		var resp struct {
+			CreateCodeComment struct{ ID string }
+		}
+		err := c.Post(`mutation { createCodeComment(input: {versionID: "4", path: "MarchPenguin/MarchPenguin.pde", startLine: 1, body: "Hi"}) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+}
+
+func TestFeedbackReleaseResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Schedule Feedback Release", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		releaseAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		release := models.FeedbackRelease{Status: models.FeedbackStatusReleased, ReleaseAt: &releaseAt}
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().GetModerationSamples(uint(1)).Return([]*models.ModerationSample{{SubmissionID: 1, Status: models.ModerationStatusAgreed}}, nil)
+		mockDB.EXPECT().UpdateFeedbackRelease(uint(1), release).Return(&models.Assignment{Model: gorm.Model{ID: 1}, Feedback: release}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Feedback: release}, nil)
+
+		var resp struct {
+			UpdateFeedbackRelease struct {
+				FeedbackRelease struct {
+					Status    string
+					ReleaseAt int
+					Released  bool
+				}
+			}
+		}
+		c.MustPost(fmt.Sprintf(`mutation { updateFeedbackRelease(assignmentID: "1", status: RELEASED, releaseAt: %d) { feedbackRelease { status releaseAt released } } }`, releaseAt.Unix()), &resp)
+
+		assert.Equal(t, "RELEASED", resp.UpdateFeedbackRelease.FeedbackRelease.Status)
+		assert.Equal(t, int(releaseAt.Unix()), resp.UpdateFeedbackRelease.FeedbackRelease.ReleaseAt)
+		assert.False(t, resp.UpdateFeedbackRelease.FeedbackRelease.Released)
+	})
+
+	t.Run("Schedule Draft Feedback", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		var resp struct {
+			UpdateFeedbackRelease struct{ ID string }
+		}
+		err := c.Post(`mutation { updateFeedbackRelease(assignmentID: "1", status: DRAFT, releaseAt: 1700000000) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "a release time can only be set when releasing feedback")
+	})
+
+	t.Run("Update Feedback Release - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		var resp struct {
+			UpdateFeedbackRelease struct{ ID string }
+		}
+		err := c.Post(`mutation { updateFeedbackRelease(assignmentID: "1", status: RELEASED) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+
+	// Feedback queried by a student is hidden until it is released.
+	for _, tt := range []struct {
+		name    string
+		release models.FeedbackRelease
+		visible bool
+	}{
+		{"Draft", models.FeedbackRelease{Status: models.FeedbackStatusDraft}, false},
+		{"Under Moderation", models.FeedbackRelease{Status: models.FeedbackStatusUnderModeration}, false},
+		{"Scheduled", models.FeedbackRelease{Status: models.FeedbackStatusReleased, ReleaseAt: &[]time.Time{time.Now().Add(time.Hour)}[0]}, false},
+		{"Released", models.FeedbackRelease{Status: models.FeedbackStatusReleased, ReleaseAt: &[]time.Time{time.Now().Add(-time.Hour)}[0]}, true},
+	} {
+		tt := tt
+		t.Run("Get Feedback - "+tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabase(ctrl)
+			studentID := uint(7)
+			c := newClientAs(mockDB, &models.User{Email: "alice@example.com", Role: models.UserRoleStudent, StudentID: &studentID})
+
+			submission := &models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1, StudentRecordID: &studentID}
+			version := &models.SubmissionVersion{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}
+			result := &models.Result{Model: gorm.Model{ID: 2}, Score: 7, SubmissionID: 1, SubmissionVersionID: &version.ID}
+
+			mockDB.EXPECT().GetStudent("7").Return(&models.Student{Model: gorm.Model{ID: 7}, StudentNumber: "s0001"}, nil)
+			mockDB.EXPECT().GetSubmissionsForStudent(uint(7)).Return([]*models.Submission{submission}, nil)
+			mockDB.EXPECT().GetSubmission("1").Return(submission, nil).AnyTimes()
+			mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, AttemptPolicy: models.AttemptPolicyBest, Feedback: tt.release}, nil).AnyTimes()
+			mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{version}, nil).AnyTimes()
+			mockDB.EXPECT().GetSubmissionVersion("4").Return(version, nil).AnyTimes()
+			if tt.visible {
+				mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return([]*models.Result{result}, nil).AnyTimes()
+				mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil)
+				mockDB.EXPECT().GetRubricMarks(uint(1)).Return(nil, nil)
+				mockDB.EXPECT().GetModerationSample(uint(1)).Return(nil, db.ErrRecordNotFound).AnyTimes()
+				mockDB.EXPECT().GetCodeCommentsForSubmission(uint(1)).Return([]*models.CodeComment{
+					{Model: gorm.Model{ID: 7}, Path: "MarchPenguin/MarchPenguin.pde", StartLine: 1, EndLine: 1, Body: "Nice", SubmissionID: 1, SubmissionVersionID: 4},
+				}, nil)
+			}
+
+			var resp struct {
+				CurrentStudent struct {
+					Submissions []struct {
+						Result         *struct{ Score float64 }
+						RubricMarks    []struct{ Comment string }
+						Comments       []struct{ Body string }
+						CountedVersion *struct{ ID string }
+						Versions       []struct{ Counted bool }
+					}
+				}
+			}
+			c.MustPost(`{ currentStudent { submissions { result { score } rubricMarks { comment } comments { body } countedVersion { id } versions { counted } } } }`, &resp)
+
+			require.Len(t, resp.CurrentStudent.Submissions, 1)
+			submissionResp := resp.CurrentStudent.Submissions[0]
+			require.Len(t, submissionResp.Versions, 1)
+			if !tt.visible {
+				assert.Nil(t, submissionResp.Result)
+				assert.Empty(t, submissionResp.Comments)
+				// Under the best attempt policy the counted version gives away which attempt
+				// scored highest.
+				assert.Nil(t, submissionResp.CountedVersion)
+				assert.False(t, submissionResp.Versions[0].Counted)
+				return
+			}
+
+			require.NotNil(t, submissionResp.Result)
+			assert.Equal(t, float64(7), submissionResp.Result.Score)
+			require.Len(t, submissionResp.Comments, 1)
+			assert.Equal(t, "Nice", submissionResp.Comments[0].Body)
+			require.NotNil(t, submissionResp.CountedVersion)
+			assert.Equal(t, "4", submissionResp.CountedVersion.ID)
+			assert.True(t, submissionResp.Versions[0].Counted)
+		})
+	}
+}
+
+// expectGroupSubmission sets up student 7's submission as one made by a group of the
+// given student and Bob.
+func expectGroupSubmission(mockDB *mocks.MockDatabase, member *models.Student) {
+	studentID, groupID := uint(7), uint(5)
+	submission := &models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 3, StudentRecordID: &studentID, GroupID: &groupID}
+
+	mockDB.EXPECT().GetSubmissionsForStudent(uint(7)).Return([]*models.Submission{submission}, nil).AnyTimes()
+	mockDB.EXPECT().GetSubmission("1").Return(submission, nil).AnyTimes()
+	mockDB.EXPECT().GetGroup("5").Return(&models.Group{Model: gorm.Model{ID: 5}, Name: "Group 1", ClassID: 2}, nil).AnyTimes()
+	mockDB.EXPECT().GetGroupMembers(uint(5)).Return([]*models.Student{
+		member,
+		{Model: gorm.Model{ID: 8}, StudentNumber: "s0003", Name: "Bob Eagle", Email: "bob@example.com"},
+	}, nil).AnyTimes()
+}
+
+func TestStudentPortalResolver(t *testing.T) {
+	t.Parallel()
+
+	studentID := uint(7)
+	studentUser := &models.User{Email: "alice@example.com", Role: models.UserRoleStudent, StudentID: &studentID}
+	student := &models.Student{Model: gorm.Model{ID: 7}, StudentNumber: "s0001", Name: "Alice Penguin", Email: "Alice@example.com"}
+
+	t.Run("Current Student", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, studentUser)
+
+		due := time.Date(2022, 10, 1, 17, 0, 0, 0, time.UTC)
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+		mockDB.EXPECT().GetClassesForStudent(uint(7)).Return([]*models.Class{{Model: gorm.Model{ID: 2}, Name: "Class 1"}}, nil)
+		mockDB.EXPECT().GetAssignmentsForClass(uint(2)).Return([]*models.Assignment{{Model: gorm.Model{ID: 3}, Name: "Assignment 1", DueDate: due}}, nil)
+
+		var resp struct {
+			CurrentStudent struct {
+				StudentNumber string
+				Classes       []struct {
+					Name        string
+					Assignments []struct {
+						Name    string
+						DueDate int
+					}
+				}
+			}
+		}
+		c.MustPost(`{ currentStudent { studentNumber classes { name assignments { name dueDate } } } }`, &resp)
+
+		assert.Equal(t, "s0001", resp.CurrentStudent.StudentNumber)
+		require.Len(t, resp.CurrentStudent.Classes, 1)
+		require.Len(t, resp.CurrentStudent.Classes[0].Assignments, 1)
+		assert.Equal(t, "Assignment 1", resp.CurrentStudent.Classes[0].Assignments[0].Name)
+		assert.Equal(t, int(due.Unix()), resp.CurrentStudent.Classes[0].Assignments[0].DueDate)
+	})
+
+	t.Run("Current Student - Staff", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		var resp struct {
+			CurrentStudent *struct{ ID string }
+		}
+		c.MustPost(`{ currentStudent { id } }`, &resp)
+
+		assert.Nil(t, resp.CurrentStudent)
+	})
+
+	t.Run("Current Student - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		var resp struct {
+			CurrentStudent *struct{ ID string }
+		}
+		err := c.Post(`{ currentStudent { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
+	})
+
+	// Students can't see anything outside of their own records.
+	offeringID := uint(4)
+	for _, query := range []string{
+		`{ units { id } }`,
+		`{ assignments { id } }`,
+		`{ submissions { id } }`,
+		`{ submission(id: "1") { id } }`,
+		`{ results { id } }`,
+		`{ result(id: "1") { id } }`,
+		`{ students { id } }`,
+		`{ student(id: "8") { id } }`,
+		`{ currentStudent { classes { students { id } } } }`,
+		`{ currentStudent { classes { assignments { submissions { id } } } } }`,
+		`{ currentStudent { classes { assignments { statistics { mean } } } } }`,
+		`{ currentStudent { classes { unit { classes { id } } } } }`,
+		`{ currentStudent { classes { unit { offerings { id } } } } }`,
+		`{ currentStudent { classes { offering { classes { id } } } } }`,
+		`{ currentStudent { classes { offering { term { offerings { id } } } } } }`,
+		`{ currentStudent { submissions { group { members { submissions { id } } } } } }`,
+		`{ currentStudent { submissions { group { members { classes { id } } } } } }`,
+		`mutation { createUnit(input: {name: "Unit 1"}) { id } }`,
+		`mutation { updateFeedbackRelease(assignmentID: "3", status: RELEASED) { id } }`,
+	} {
+		query := query
+		t.Run("Staff Only - "+query, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabase(ctrl)
+			c := newClientAs(mockDB, studentUser)
+
+			mockDB.EXPECT().GetStudent("7").Return(student, nil).AnyTimes()
+			mockDB.EXPECT().GetClassesForStudent(uint(7)).Return([]*models.Class{{Model: gorm.Model{ID: 2}, Name: "Class 1"}}, nil).AnyTimes()
+			mockDB.EXPECT().GetAssignmentsForClass(uint(2)).Return([]*models.Assignment{{Model: gorm.Model{ID: 3}, Name: "Assignment 1"}}, nil).AnyTimes()
+			mockDB.EXPECT().GetClass("2").Return(&models.Class{Model: gorm.Model{ID: 2}, Name: "Class 1", UnitID: 1, OfferingID: &offeringID}, nil).AnyTimes()
+			mockDB.EXPECT().GetUnitByID("1", false).Return(&models.Unit{Model: gorm.Model{ID: 1}, Name: "COMP1000"}, nil).AnyTimes()
+			mockDB.EXPECT().GetOffering("4").Return(&models.Offering{Model: gorm.Model{ID: 4}, UnitID: 1, TermID: 5, Term: models.Term{Model: gorm.Model{ID: 5}, Name: "S1 2022"}}, nil).AnyTimes()
+			expectGroupSubmission(mockDB, student)
+
+			var resp interface{}
+			err := c.Post(query, &resp)
+
+			assert.ErrorContains(t, err, "only staff can do this")
+		})
+	}
+
+	t.Run("Group Members - Names Only", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, studentUser)
+
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+		expectGroupSubmission(mockDB, student)
+
+		var resp struct {
+			CurrentStudent struct {
+				Submissions []struct {
+					Group struct {
+						Members []struct{ ID, Name, Email, StudentNumber string }
+					}
+				}
+			}
+		}
+		c.MustPost(`{ currentStudent { submissions { group { members { id name email studentNumber } } } } }`, &resp)
+
+		require.Len(t, resp.CurrentStudent.Submissions, 1)
+		members := resp.CurrentStudent.Submissions[0].Group.Members
+		require.Len(t, members, 2)
+		assert.Equal(t, "s0001", members[0].StudentNumber)
+		assert.Equal(t, "8", members[1].ID)
+		assert.Equal(t, "Bob Eagle", members[1].Name)
+		assert.Empty(t, members[1].Email)
+		assert.Empty(t, members[1].StudentNumber)
+	})
+
+	t.Run("Group Members - Not A Member", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, studentUser)
+
+		// Alice has since left the group that made her submission.
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+		expectGroupSubmission(mockDB, &models.Student{Model: gorm.Model{ID: 9}, StudentNumber: "s0005", Name: "Carol Turkey"})
+
+		var resp interface{}
+		err := c.Post(`{ currentStudent { submissions { group { members { name } } } } }`, &resp)
+
+		assert.ErrorContains(t, err, "only staff can do this")
+	})
+
+	t.Run("Invite Student", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		var codeHash string
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+		mockDB.EXPECT().SetStudentInvite(uint(7), gomock.Any(), gomock.Any()).DoAndReturn(func(studentID uint, hash string, expiresAt time.Time) (*models.StudentInvite, error) {
+			codeHash = hash
+			return &models.StudentInvite{StudentID: studentID, CodeHash: hash, ExpiresAt: expiresAt}, nil
+		})
+
+		var resp struct {
+			InviteStudent struct {
+				Student   struct{ StudentNumber string }
+				Code      string
+				ExpiresAt int64
+			}
+		}
+		c.MustPost(`mutation { inviteStudent(studentID: "7") { student { studentNumber } code expiresAt } }`, &resp)
+
+		assert.Equal(t, "s0001", resp.InviteStudent.Student.StudentNumber)
+		assert.NotEmpty(t, resp.InviteStudent.Code)
+		assert.NotEqual(t, resp.InviteStudent.Code, codeHash, "only the hash of the code should be stored")
+		invite := &models.StudentInvite{CodeHash: codeHash, ExpiresAt: time.Unix(resp.InviteStudent.ExpiresAt, 0)}
+		assert.True(t, invite.Check(resp.InviteStudent.Code, time.Now()))
+	})
+
+	t.Run("Invite Student - Not Staff", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, studentUser)
+
+		var resp struct {
+			InviteStudent struct{ Code string }
+		}
+		err := c.Post(`mutation { inviteStudent(studentID: "7") { code } }`, &resp)
+
+		assert.ErrorContains(t, err, "only staff can do this")
+	})
+
+	code, codeHash, err := models.NewInviteCode()
+	require.NoError(t, err)
+	invite := &models.StudentInvite{StudentID: 7, CodeHash: codeHash, ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("Register Student", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		mockDB.EXPECT().GetStudentByNumber("s0001").Return(student, nil)
+		mockDB.EXPECT().GetStudentInvite(uint(7)).Return(invite, nil)
+		mockDB.EXPECT().GetUserByEmail("Alice@example.com").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateStudentUser("Alice@example.com", gomock.Any(), uint(7)).Return(studentUser, nil)
+
+		var resp struct {
+			RegisterStudent string
+		}
+		c.MustPost(fmt.Sprintf(`mutation { registerStudent(studentNumber: "s0001", inviteCode: %q, password: "password") }`, code), &resp)
+
+		assert.NotEmpty(t, resp.RegisterStudent)
+	})
+
+	for _, tt := range []struct {
+		name    string
+		student *models.Student
+		err     error
+		invite  *models.StudentInvite
+		code    string
+	}{
+		{"Wrong Code", student, nil, invite, "0123456789abcdef0123456789abcdef"},
+		{"Expired Code", student, nil, &models.StudentInvite{StudentID: 7, CodeHash: codeHash, ExpiresAt: time.Now().Add(-time.Hour)}, code},
+		{"Not Invited", student, nil, nil, code},
+		{"Unknown Student", nil, db.ErrRecordNotFound, nil, code},
+	} {
+		tt := tt
+		t.Run("Register Student - "+tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockDB := mocks.NewMockDatabase(ctrl)
+			c := newClient(mockDB, false)
+
+			mockDB.EXPECT().GetStudentByNumber("s0001").Return(tt.student, tt.err)
+			if tt.student != nil {
+				if tt.invite != nil {
+					mockDB.EXPECT().GetStudentInvite(uint(7)).Return(tt.invite, nil)
+				} else {
+					mockDB.EXPECT().GetStudentInvite(uint(7)).Return(nil, db.ErrRecordNotFound)
+				}
+			}
+
+			var resp struct {
+				RegisterStudent string
+			}
+			err := c.Post(fmt.Sprintf(`mutation { registerStudent(studentNumber: "s0001", inviteCode: %q, password: "password") }`, tt.code), &resp)
+
+			assert.ErrorContains(t, err, "invalid student number or invite code")
+		})
+	}
+}
+
+func TestExtensionResolver(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2022, 10, 1, 17, 0, 0, 0, time.UTC)
+	extended := due.Add(72 * time.Hour)
+	student := &models.Student{Model: gorm.Model{ID: 7}, StudentNumber: "s0001", Name: "Alice Penguin"}
+	assignment := &models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", DueDate: due, ClassID: 3}
+
+	t.Run("Grant Extension", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(3)).Return([]*models.Student{student}, nil)
+		mockDB.EXPECT().GetExtensionForStudent(uint(7), uint(1)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().GrantExtension(models.Extension{StudentID: 7, AssignmentID: 1, DueDate: time.Unix(extended.Unix(), 0), Reason: "Medical certificate", ApprovedBy: "user@example.com"}).
+			Return(&models.Extension{Model: gorm.Model{ID: 3}, StudentID: 7, AssignmentID: 1, DueDate: extended, Reason: "Medical certificate"}, nil)
+		mockDB.EXPECT().GetExtension("3").Return(&models.Extension{Model: gorm.Model{ID: 3}, StudentID: 7, AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+
+		var resp struct {
+			GrantExtension struct {
+				ID, Reason string
+				DueDate    int
+				Student    struct{ Name string }
+			}
+		}
+		c.MustPost(fmt.Sprintf(`mutation { grantExtension(input: {studentID: "7", assignmentID: "1", dueDate: %d, reason: " Medical certificate "}) { id dueDate reason student { name } } }`, extended.Unix()), &resp)
+
+		assert.Equal(t, "3", resp.GrantExtension.ID)
+		assert.Equal(t, int(extended.Unix()), resp.GrantExtension.DueDate)
+		assert.Equal(t, "Medical certificate", resp.GrantExtension.Reason)
+		assert.Equal(t, "Alice Penguin", resp.GrantExtension.Student.Name)
+	})
+
+	t.Run("Grant Extension - Before Due Date", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+
+		var resp struct {
+			GrantExtension struct{ ID string }
+		}
+		err := c.Post(fmt.Sprintf(`mutation { grantExtension(input: {studentID: "7", assignmentID: "1", dueDate: %d, reason: "Illness"}) { id } }`, due.Unix()), &resp)
+
+		assert.ErrorContains(t, err, "extended due date must be after the assignment's due date")
+	})
+
+	t.Run("Grant Extension - Not Enrolled", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(3)).Return([]*models.Student{{Model: gorm.Model{ID: 8}, StudentNumber: "s0003"}}, nil)
+
+		var resp struct {
+			GrantExtension struct{ ID string }
+		}
+		err := c.Post(fmt.Sprintf(`mutation { grantExtension(input: {studentID: "7", assignmentID: "1", dueDate: %d, reason: "Illness"}) { id } }`, extended.Unix()), &resp)
+
+		assert.ErrorContains(t, err, "student s0001 is not enrolled in the class")
+	})
+
+	t.Run("Revoke Extension", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetStudent("7").Return(student, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetExtensionForStudent(uint(7), uint(1)).Return(&models.Extension{Model: gorm.Model{ID: 3}, StudentID: 7, AssignmentID: 1, DueDate: extended}, nil)
+		mockDB.EXPECT().RevokeExtension(uint(7), uint(1)).Return(nil)
+
+		var resp struct {
+			RevokeExtension bool
+		}
+		c.MustPost(`mutation { revokeExtension(studentID: "7", assignmentID: "1") }`, &resp)
+
+		assert.True(t, resp.RevokeExtension)
+	})
+
+	t.Run("Lateness Honours Extension", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		cutoff := due.Add(48 * time.Hour)
+		studentRecordID := uint(7)
+		submission := &models.Submission{Model: gorm.Model{ID: 1}, AssignmentID: 1, StudentRecordID: &studentRecordID}
+
+		mockDB.EXPECT().GetSubmission("1").Return(submission, nil).Times(2)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{
+			Model:      gorm.Model{ID: 1},
+			DueDate:    due,
+			LatePolicy: models.LatePolicy{PenaltyPerDay: 10, Cutoff: &cutoff},
+		}, nil).Times(2)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{
+			// Past the original cutoff, but only a day after the extended due date.
+			{Model: gorm.Model{ID: 4, CreatedAt: extended.Add(20 * time.Hour)}, Number: 1, SubmissionID: 1},
+		}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetExtensionForStudent(uint(7), uint(1)).Return(&models.Extension{Model: gorm.Model{ID: 3}, StudentID: 7, AssignmentID: 1, DueDate: extended}, nil)
+
+		var resp struct {
+			Submission struct {
+				Lateness struct {
+					DueDate                    int
+					Extended, Late, PastCutoff bool
+					Penalty                    float64
+				}
+			}
+		}
+		c.MustPost(`{ submission(id:"1") { lateness { dueDate extended late pastCutoff penalty } } }`, &resp)
+
+		assert.Equal(t, int(extended.Unix()), resp.Submission.Lateness.DueDate)
+		assert.True(t, resp.Submission.Lateness.Extended)
+		assert.True(t, resp.Submission.Lateness.Late)
+		assert.False(t, resp.Submission.Lateness.PastCutoff)
+		assert.Equal(t, float64(10), resp.Submission.Lateness.Penalty)
+	})
+}
+
+func TestMarkingResolver(t *testing.T) {
+	t.Parallel()
+
+	tutor := &models.User{Model: gorm.Model{ID: 2}, Email: "tutor@example.com", Role: models.UserRoleTutor}
+	otherTutor := &models.User{Model: gorm.Model{ID: 3}, Email: "other@example.com", Role: models.UserRoleTutor}
+	studentID := uint(7)
+	submissions := []*models.Submission{
+		{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1, StudentRecordID: &studentID},
+		{Model: gorm.Model{ID: 2}, StudentID: "s0002", AssignmentID: 1},
+		{Model: gorm.Model{ID: 3}, StudentID: "s0003", AssignmentID: 1},
+	}
+
+	// saveAllocations stands in for the database, numbering allocations by submission.
+	saveAllocations := func(allocations []models.MarkingAllocation) ([]*models.MarkingAllocation, error) {
+		saved := []*models.MarkingAllocation{}
+		for _, allocation := range allocations {
+			allocation := allocation
+			allocation.ID = allocation.SubmissionID
+			saved = append(saved, &allocation)
+		}
+		return saved, nil
+	}
+
+	t.Run("Allocate Submissions", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("tutor@example.com").Return(tutor, nil)
+		mockDB.EXPECT().GetSubmission("1").Return(submissions[0], nil)
+		mockDB.EXPECT().GetSubmission("2").Return(submissions[1], nil)
+		mockDB.EXPECT().AllocateSubmissions([]models.MarkingAllocation{
+			{SubmissionID: 1, Submission: *submissions[0], AssignmentID: 1, Marker: "tutor@example.com"},
+			{SubmissionID: 2, Submission: *submissions[1], AssignmentID: 1, Marker: "tutor@example.com"},
+		}).DoAndReturn(saveAllocations)
+
+		var resp struct {
+			AllocateSubmissions []struct {
+				Submission struct{ ID string }
+				Marker     string
+				Status     string
+			}
+		}
+		c.MustPost(`mutation { allocateSubmissions(submissionIDs: ["1", "2"], marker: "tutor@example.com") { submission { id } marker status } }`, &resp)
+
+		require.Len(t, resp.AllocateSubmissions, 2)
+		assert.Equal(t, "2", resp.AllocateSubmissions[1].Submission.ID)
+		assert.Equal(t, "tutor@example.com", resp.AllocateSubmissions[1].Marker)
+	})
+
+	t.Run("Allocate Submissions - Student Marker", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetUserByEmail("alice@example.com").Return(&models.User{Email: "alice@example.com", Role: models.UserRoleStudent, StudentID: &studentID}, nil)
+
+		var resp struct {
+			AllocateSubmissions []struct{ ID string }
+		}
+		err := c.Post(`mutation { allocateSubmissions(submissionIDs: ["1"], marker: "alice@example.com") { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "alice@example.com can't mark submissions")
+	})
+
+	t.Run("Allocate Markers - Round Robin", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().GetSubmissionsForAssignment("1").Return(submissions, nil)
+		// The first submission is already allocated to the other tutor, so the tutor
+		// gets the next one.
+		mockDB.EXPECT().GetMarkingAllocationsForAssignment(uint(1)).Return([]*models.MarkingAllocation{
+			{Model: gorm.Model{ID: 1}, SubmissionID: 1, AssignmentID: 1, Marker: "other@example.com"},
+		}, nil)
+		mockDB.EXPECT().GetUserByEmail("other@example.com").Return(otherTutor, nil)
+		mockDB.EXPECT().GetUserByEmail("tutor@example.com").Return(tutor, nil)
+		mockDB.EXPECT().AllocateSubmissions(gomock.Any()).DoAndReturn(saveAllocations)
+
+		var resp struct {
+			AllocateMarkers struct {
+				Allocations []struct {
+					Submission struct{ ID string }
+					Marker     string
+				}
+				Unallocated []struct{ ID string }
+			}
+		}
+		c.MustPost(`mutation { allocateMarkers(input: {assignmentID: "1", strategy: ROUND_ROBIN, markers: ["other@example.com", "tutor@example.com", "other@example.com"]}) { allocations { submission { id } marker } unallocated { id } } }`, &resp)
+
+		require.Len(t, resp.AllocateMarkers.Allocations, 2)
+		assert.Equal(t, "2", resp.AllocateMarkers.Allocations[0].Submission.ID)
+		assert.Equal(t, "tutor@example.com", resp.AllocateMarkers.Allocations[0].Marker)
+		assert.Equal(t, "3", resp.AllocateMarkers.Allocations[1].Submission.ID)
+		assert.Equal(t, "other@example.com", resp.AllocateMarkers.Allocations[1].Marker)
+		assert.Empty(t, resp.AllocateMarkers.Unallocated)
+	})
+
+	t.Run("Allocate Markers - By Class", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().GetSubmissionsForAssignment("1").Return(submissions[:2], nil)
+		mockDB.EXPECT().GetMarkingAllocationsForAssignment(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetUserByEmail("tutor@example.com").Return(tutor, nil)
+		mockDB.EXPECT().GetClass("2").Return(&models.Class{Model: gorm.Model{ID: 2}, Name: "Class 2"}, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(2)).Return([]*models.Student{{Model: gorm.Model{ID: 7}, StudentNumber: "s0001"}}, nil)
+		mockDB.EXPECT().AllocateSubmissions([]models.MarkingAllocation{
+			{SubmissionID: 1, Submission: *submissions[0], AssignmentID: 1, Marker: "tutor@example.com", Status: models.MarkingStatusPending},
+		}).DoAndReturn(saveAllocations)
+
+		var resp struct {
+			AllocateMarkers struct {
+				Allocations []struct{ Marker string }
+				Unallocated []struct{ ID string }
+			}
+		}
+		c.MustPost(`mutation { allocateMarkers(input: {assignmentID: "1", strategy: BY_CLASS, classMarkers: [{classID: "2", marker: "tutor@example.com"}]}) { allocations { marker } unallocated { id } } }`, &resp)
+
+		require.Len(t, resp.AllocateMarkers.Allocations, 1)
+		require.Len(t, resp.AllocateMarkers.Unallocated, 1)
+		assert.Equal(t, "2", resp.AllocateMarkers.Unallocated[0].ID)
+	})
+
+	t.Run("Update Marking Status", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, tutor)
+
+		mockDB.EXPECT().GetSubmission("1").Return(submissions[0], nil)
+		mockDB.EXPECT().GetMarkingAllocation(uint(1)).Return(&models.MarkingAllocation{Model: gorm.Model{ID: 4}, SubmissionID: 1, Marker: "tutor@example.com"}, nil)
+		mockDB.EXPECT().UpdateMarkingStatus(uint(1), models.MarkingStatusInProgress).Return(&models.MarkingAllocation{
+			Model: gorm.Model{ID: 4}, SubmissionID: 1, Submission: *submissions[0], Marker: "tutor@example.com", Status: models.MarkingStatusInProgress,
+		}, nil)
+
+		var resp struct {
+			UpdateMarkingStatus struct{ Status string }
+		}
+		c.MustPost(`mutation { updateMarkingStatus(submissionID: "1", status: IN_PROGRESS) { status } }`, &resp)
+
+		assert.Equal(t, "IN_PROGRESS", resp.UpdateMarkingStatus.Status)
+	})
+
+	t.Run("Update Marking Status - Other Marker", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, tutor)
+
+		mockDB.EXPECT().GetSubmission("1").Return(submissions[0], nil)
+		mockDB.EXPECT().GetMarkingAllocation(uint(1)).Return(&models.MarkingAllocation{Model: gorm.Model{ID: 4}, SubmissionID: 1, Marker: "other@example.com"}, nil)
+
+		var resp struct {
+			UpdateMarkingStatus struct{ Status string }
+		}
+		err := c.Post(`mutation { updateMarkingStatus(submissionID: "1", status: DONE) { status } }`, &resp)
+
+		assert.ErrorContains(t, err, "submission is allocated to another marker")
+	})
+
+	t.Run("My Marking Queue", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, tutor)
+
+		mockDB.EXPECT().GetMarkingAllocationsForMarker("tutor@example.com").Return([]*models.MarkingAllocation{
+			{SubmissionID: 1, Submission: *submissions[0], AssignmentID: 1, Marker: "tutor@example.com", Status: models.MarkingStatusDone},
+			{SubmissionID: 2, Submission: *submissions[1], AssignmentID: 1, Marker: "tutor@example.com", Status: models.MarkingStatusInProgress},
+			{SubmissionID: 3, Submission: *submissions[2], AssignmentID: 1, Marker: "tutor@example.com", Status: models.MarkingStatusPending},
+			{SubmissionID: 9, Submission: models.Submission{Model: gorm.Model{ID: 9}, StudentID: "s0001", AssignmentID: 2}, AssignmentID: 2, Marker: "tutor@example.com", Status: models.MarkingStatusPending},
+		}, nil)
+
+		var resp struct {
+			MyMarkingQueue struct {
+				Pending    []struct{ ID, StudentID string }
+				InProgress []struct{ ID string }
+				Done       []struct{ ID string }
+				Progress   struct{ Pending, InProgress, Done, Total int }
+			}
+		}
+		c.MustPost(`{ myMarkingQueue(assignmentID: "1") { pending { id studentID } inProgress { id } done { id } progress { pending inProgress done total } } }`, &resp)
+
+		require.Len(t, resp.MyMarkingQueue.Pending, 1)
+		assert.Equal(t, "s0003", resp.MyMarkingQueue.Pending[0].StudentID)
+		require.Len(t, resp.MyMarkingQueue.InProgress, 1)
+		assert.Equal(t, "2", resp.MyMarkingQueue.InProgress[0].ID)
+		require.Len(t, resp.MyMarkingQueue.Done, 1)
+		assert.Equal(t, struct{ Pending, InProgress, Done, Total int }{1, 1, 1, 3}, resp.MyMarkingQueue.Progress)
+	})
+}
+
+func TestModerationResolver(t *testing.T) {
+	t.Parallel()
+
+	tutor := &models.User{Model: gorm.Model{ID: 2}, Email: "tutor@example.com", Role: models.UserRoleTutor}
+	otherTutor := &models.User{Model: gorm.Model{ID: 3}, Email: "other@example.com", Role: models.UserRoleTutor}
+	submissions := []*models.Submission{
+		{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1},
+		{Model: gorm.Model{ID: 2}, StudentID: "s0002", AssignmentID: 1},
+		{Model: gorm.Model{ID: 3}, StudentID: "s0003", AssignmentID: 1},
+	}
+	assignment := &models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", ModerationThreshold: 1}
+	awaiting := &models.ModerationSample{Model: gorm.Model{ID: 5}, SubmissionID: 1, Submission: *submissions[0], AssignmentID: 1, SecondMarker: "tutor@example.com", Status: models.ModerationStatusAwaitingSecondMark}
+
+	// expectGrade sets up a grade of 8 out of 10 for the first submission.
+	expectGrade := func(mockDB *mocks.MockDatabase) {
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(4)).Return([]*models.TestOutcome{{Model: gorm.Model{ID: 1}, Points: 8, TestID: 1}}, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetRubricMarks(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(nil, db.ErrRecordNotFound)
+	}
+
+	t.Run("Sample For Moderation", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetModerationSamples(uint(1)).Return([]*models.ModerationSample{awaiting}, nil)
+		mockDB.EXPECT().GetUserByEmail("tutor@example.com").Return(tutor, nil)
+		mockDB.EXPECT().GetUserByEmail("other@example.com").Return(otherTutor, nil)
+		mockDB.EXPECT().GetSubmissionsForAssignment("1").Return(submissions, nil)
+		mockDB.EXPECT().GetMarkingAllocationsForAssignment(uint(1)).Return([]*models.MarkingAllocation{
+			{SubmissionID: 1, AssignmentID: 1, Marker: "tutor@example.com"},
+			{SubmissionID: 2, AssignmentID: 1, Marker: "other@example.com"},
+		}, nil)
+		// Nobody second marks a submission they were allocated.
+		samples := []models.ModerationSample{
+			{SubmissionID: 1, Submission: *submissions[0], AssignmentID: 1, SecondMarker: "other@example.com", Status: models.ModerationStatusAwaitingSecondMark},
+			{SubmissionID: 2, Submission: *submissions[1], AssignmentID: 1, SecondMarker: "tutor@example.com", Status: models.ModerationStatusAwaitingSecondMark},
+			{SubmissionID: 3, Submission: *submissions[2], AssignmentID: 1, SecondMarker: "other@example.com", Status: models.ModerationStatusAwaitingSecondMark},
+		}
+		mockDB.EXPECT().SetModerationSample(uint(1), 2.5, samples).DoAndReturn(func(assignmentID uint, threshold float64, samples []models.ModerationSample) ([]*models.ModerationSample, error) {
+			saved := []*models.ModerationSample{}
+			for _, sample := range samples {
+				sample := sample
+				saved = append(saved, &sample)
+			}
+			return saved, nil
+		})
+
+		var resp struct {
+			SampleForModeration struct {
+				Threshold float64
+				Samples   []struct {
+					Submission   struct{ StudentID string }
+					SecondMarker string
+					Status       string
+					FirstMark    *float64
+				}
+			}
+		}
+		c.MustPost(`mutation { sampleForModeration(input: {assignmentID: "1", percentage: 100, secondMarkers: ["tutor@example.com", "other@example.com"], threshold: 2.5}) {
+			threshold samples { submission { studentID } secondMarker status firstMark }
+		} }`, &resp)
+
+		assert.Equal(t, 2.5, resp.SampleForModeration.Threshold)
+		require.Len(t, resp.SampleForModeration.Samples, 3)
+		assert.Equal(t, "s0002", resp.SampleForModeration.Samples[1].Submission.StudentID)
+		assert.Equal(t, "tutor@example.com", resp.SampleForModeration.Samples[1].SecondMarker)
+		assert.Equal(t, "AWAITING_SECOND_MARK", resp.SampleForModeration.Samples[1].Status)
+		assert.Nil(t, resp.SampleForModeration.Samples[1].FirstMark)
+	})
+
+	t.Run("Sample For Moderation - Size And Percentage", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		var resp struct {
+			SampleForModeration struct{ Threshold float64 }
+		}
+		err := c.Post(`mutation { sampleForModeration(input: {assignmentID: "1", size: 2, percentage: 10, secondMarkers: ["tutor@example.com"], threshold: 2}) { threshold } }`, &resp)
+
+		assert.ErrorContains(t, err, "give either a sample size or a percentage")
+	})
+
+	t.Run("Sample For Moderation - Second Marks Entered", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetModerationSamples(uint(1)).Return([]*models.ModerationSample{
+			{SubmissionID: 1, SecondMarker: "tutor@example.com", Status: models.ModerationStatusAgreed},
+		}, nil)
+
+		var resp struct {
+			SampleForModeration struct{ Threshold float64 }
+		}
+		err := c.Post(`mutation { sampleForModeration(input: {assignmentID: "1", size: 2, secondMarkers: ["tutor@example.com"], threshold: 2}) { threshold } }`, &resp)
+
+		assert.ErrorContains(t, err, "the sample can't be replaced once second marks have been entered")
+	})
+
+	t.Run("Enter Second Mark - Discrepancy", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, tutor)
+
+		firstMark, secondMark := float64(8), float64(5)
+		mockDB.EXPECT().GetSubmission("1").Return(submissions[0], nil)
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(awaiting, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		expectGrade(mockDB)
+		mockDB.EXPECT().EnterSecondMark(uint(1), firstMark, secondMark, models.ModerationStatusDiscrepancy).Return(&models.ModerationSample{
+			Model: gorm.Model{ID: 5}, SubmissionID: 1, Submission: *submissions[0], SecondMarker: "tutor@example.com",
+			Status: models.ModerationStatusDiscrepancy, FirstMark: &firstMark, SecondMark: &secondMark,
+		}, nil)
+
+		var resp struct {
+			EnterSecondMark struct {
+				Status                string
+				FirstMark, SecondMark float64
+				AgreedMark            *float64
+			}
+		}
+		c.MustPost(`mutation { enterSecondMark(submissionID: "1", mark: 5) { status firstMark secondMark agreedMark } }`, &resp)
+
+		assert.Equal(t, "DISCREPANCY", resp.EnterSecondMark.Status)
+		assert.Equal(t, firstMark, resp.EnterSecondMark.FirstMark)
+		assert.Equal(t, secondMark, resp.EnterSecondMark.SecondMark)
+		assert.Nil(t, resp.EnterSecondMark.AgreedMark)
+	})
+
+	t.Run("Enter Second Mark - Other Marker", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, otherTutor)
+
+		mockDB.EXPECT().GetSubmission("1").Return(submissions[0], nil)
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(awaiting, nil)
+
+		var resp struct {
+			EnterSecondMark struct{ Status string }
+		}
+		err := c.Post(`mutation { enterSecondMark(submissionID: "1", mark: 5) { status } }`, &resp)
+
+		assert.ErrorContains(t, err, "submission is being second marked by another marker")
+	})
+
+	// The second marker can't see the first marker's grade until they've entered theirs.
+	t.Run("Blind Second Marking", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, tutor)
+
+		mockDB.EXPECT().GetSubmission("1").Return(submissions[0], nil).AnyTimes()
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(awaiting, nil).AnyTimes()
+
+		var resp struct {
+			Submission struct {
+				Grade       *struct{ Total float64 }
+				RubricMarks []struct{ Comment string }
+			}
+		}
+		c.MustPost(`{ submission(id: "1") { grade { total } rubricMarks { comment } } }`, &resp)
+
+		assert.Nil(t, resp.Submission.Grade)
+		assert.Empty(t, resp.Submission.RubricMarks)
+	})
+
+	t.Run("Blind Second Marking - Results", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, tutor)
+
+		blindResult := &models.Result{Model: gorm.Model{ID: 1}, Score: 8, SubmissionID: 1}
+		mockDB.EXPECT().GetSubmission("1").Return(submissions[0], nil).AnyTimes()
+		mockDB.EXPECT().GetSubmission("2").Return(submissions[1], nil).AnyTimes()
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(awaiting, nil).AnyTimes()
+		mockDB.EXPECT().GetModerationSample(uint(2)).Return(nil, db.ErrRecordNotFound).AnyTimes()
+		mockDB.EXPECT().GetAllResults(1).Return([]*models.Result{
+			blindResult,
+			{Model: gorm.Model{ID: 2}, Score: 5, SubmissionID: 2},
+		}, nil)
+		mockDB.EXPECT().GetResult("1").Return(blindResult, nil)
+
+		var resp struct {
+			Results []struct{ ID string }
+			Result  *struct{ ID string }
+		}
+		c.MustPost(`{ results { id } result(id: "1") { id } }`, &resp)
+
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "2", resp.Results[0].ID)
+		assert.Nil(t, resp.Result)
+	})
+
+	t.Run("Resolve Moderation", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		agreedMark := float64(7)
+		mockDB.EXPECT().GetSubmission("1").Return(submissions[0], nil)
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(&models.ModerationSample{Model: gorm.Model{ID: 5}, SubmissionID: 1, Status: models.ModerationStatusDiscrepancy}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().ResolveModeration(uint(1), agreedMark, "user@example.com", "Agreed after discussion").Return(&models.ModerationSample{
+			Model: gorm.Model{ID: 5}, SubmissionID: 1, Submission: *submissions[0], Status: models.ModerationStatusResolved,
+			AgreedMark: &agreedMark, ResolvedBy: "user@example.com", ResolutionNote: "Agreed after discussion",
+		}, nil)
+
+		var resp struct {
+			ResolveModeration struct {
+				Status         string
+				AgreedMark     float64
+				ResolvedBy     string
+				ResolutionNote string
+			}
+		}
+		c.MustPost(`mutation { resolveModeration(submissionID: "1", agreedMark: 7, note: " Agreed after discussion ") { status agreedMark resolvedBy resolutionNote } }`, &resp)
+
+		assert.Equal(t, "RESOLVED", resp.ResolveModeration.Status)
+		assert.Equal(t, agreedMark, resp.ResolveModeration.AgreedMark)
+		assert.Equal(t, "user@example.com", resp.ResolveModeration.ResolvedBy)
+	})
+
+	t.Run("Resolve Moderation - Tutor", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, tutor)
+
+		var resp struct {
+			ResolveModeration struct{ Status string }
+		}
+		err := c.Post(`mutation { resolveModeration(submissionID: "1", agreedMark: 7, note: "Agreed") { status } }`, &resp)
+
+		assert.ErrorContains(t, err, "you must be an admin to resolve moderation")
+	})
+
+	t.Run("Release Feedback - Unresolved Discrepancy", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetModerationSamples(uint(1)).Return([]*models.ModerationSample{
+			{SubmissionID: 1, Status: models.ModerationStatusResolved},
+			{SubmissionID: 2, Status: models.ModerationStatusDiscrepancy},
+		}, nil)
+
+		var resp struct {
+			UpdateFeedbackRelease struct{ ID string }
+		}
+		err := c.Post(`mutation { updateFeedbackRelease(assignmentID: "1", status: RELEASED) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "feedback can't be released until moderation is finished")
+	})
+
+	t.Run("Released Grade - Agreed Mark", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		studentID := uint(7)
+		c := newClientAs(mockDB, &models.User{Email: "alice@example.com", Role: models.UserRoleStudent, StudentID: &studentID})
+
+		submission := &models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1, StudentRecordID: &studentID}
+		released := &models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", Feedback: models.FeedbackRelease{Status: models.FeedbackStatusReleased}}
+		first, second, agreed := 8.0, 4.0, 6.0
+
+		mockDB.EXPECT().GetStudent("7").Return(&models.Student{Model: gorm.Model{ID: 7}, StudentNumber: "s0001"}, nil)
+		mockDB.EXPECT().GetSubmissionsForStudent(uint(7)).Return([]*models.Submission{submission}, nil)
+		mockDB.EXPECT().GetSubmission("1").Return(submission, nil).AnyTimes()
+		mockDB.EXPECT().GetAssignment("1").Return(released, nil).AnyTimes()
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(4)).Return([]*models.TestOutcome{{Model: gorm.Model{ID: 1}, Points: 8, TestID: 1}}, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetRubricMarks(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetExtensionForStudent(uint(7), uint(1)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(&models.ModerationSample{
+			SubmissionID: 1,
+			AssignmentID: 1,
+			Status:       models.ModerationStatusResolved,
+			FirstMark:    &first,
+			SecondMark:   &second,
+			AgreedMark:   &agreed,
+		}, nil).AnyTimes()
+
+		var resp struct {
+			CurrentStudent struct {
+				Submissions []struct {
+					Grade *struct{ Automated, Total, Final float64 }
+				}
+			}
+		}
+		c.MustPost(`{ currentStudent { submissions { grade { automated total final } } } }`, &resp)
+
+		require.Len(t, resp.CurrentStudent.Submissions, 1)
+		grade := resp.CurrentStudent.Submissions[0].Grade
+		require.NotNil(t, grade)
+		assert.Equal(t, float64(8), grade.Automated)
+		assert.Equal(t, float64(6), grade.Total)
+		assert.Equal(t, float64(6), grade.Final)
+	})
+}
+
+func TestGroupResolver(t *testing.T) {
+	t.Parallel()
+
+	groupID := uint(3)
+	alice := &models.Student{Model: gorm.Model{ID: 1}, StudentNumber: "s0001", Name: "Alice Penguin"}
+	bob := &models.Student{Model: gorm.Model{ID: 2}, StudentNumber: "s0002", Name: "Bob Penguin"}
+	group := &models.Group{Model: gorm.Model{ID: 3}, Name: "Team Rocket", ClassID: 1}
+	assignment := &models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", ClassID: 1, GroupWork: true}
+	submission := &models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1, GroupID: &groupID}
+
+	// expectGrade sets up a grade of 8 out of 10 for the submission.
+	expectGrade := func(mockDB *mocks.MockDatabase) {
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{{Model: gorm.Model{ID: 4}, Number: 1, SubmissionID: 1}}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(4)).Return([]*models.TestOutcome{{Model: gorm.Model{ID: 1}, Points: 8, TestID: 1}}, nil)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetRubricMarks(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(nil, db.ErrRecordNotFound)
+	}
+
+	t.Run("Create Group", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetClass("1").Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().GetGroupsForClass(uint(1)).Return([]*models.Group{{Model: gorm.Model{ID: 4}, Name: "Team Magma", ClassID: 1}}, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(1)).Return([]*models.Student{alice, bob}, nil)
+		mockDB.EXPECT().GetGroupForStudent(uint(1), uint(1)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().GetGroupForStudent(uint(2), uint(1)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateGroup("Team Rocket", uint(1)).Return(group, nil)
+		mockDB.EXPECT().SetGroupMembers(uint(3), uint(1), []uint{1, 2}).Return(nil)
+
+		var resp struct {
+			CreateGroup struct {
+				ID   string
+				Name string
+			}
+		}
+		c.MustPost(`mutation { createGroup(input: {classID: "1", name: " Team Rocket ", studentIDs: ["1", "2", "1"]}) { id name } }`, &resp)
+
+		assert.Equal(t, "3", resp.CreateGroup.ID)
+		assert.Equal(t, "Team Rocket", resp.CreateGroup.Name)
+	})
+
+	t.Run("Create Group - Duplicate Name", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetClass("1").Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().GetGroupsForClass(uint(1)).Return([]*models.Group{group}, nil)
+
+		var resp struct {
+			CreateGroup struct{ ID string }
+		}
+		err := c.Post(`mutation { createGroup(input: {classID: "1", name: "team rocket"}) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "class already has a group called Team Rocket")
+	})
+
+	t.Run("Set Group Members - Student In Another Group", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetGroup("3").Return(group, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(1)).Return([]*models.Student{alice, bob}, nil)
+		mockDB.EXPECT().GetGroupForStudent(uint(1), uint(1)).Return(group, nil)
+		mockDB.EXPECT().GetGroupForStudent(uint(2), uint(1)).Return(&models.Group{Model: gorm.Model{ID: 4}, Name: "Team Magma", ClassID: 1}, nil)
+
+		var resp struct {
+			SetGroupMembers struct{ ID string }
+		}
+		err := c.Post(`mutation { setGroupMembers(groupID: "3", studentIDs: ["1", "2"]) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "s0002 is already in group Team Magma")
+	})
+
+	t.Run("Set Group Members - Student Not Enrolled", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetGroup("3").Return(group, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(1)).Return([]*models.Student{alice}, nil)
+
+		var resp struct {
+			SetGroupMembers struct{ ID string }
+		}
+		err := c.Post(`mutation { setGroupMembers(groupID: "3", studentIDs: ["2"]) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "student 2 is not enrolled in the class")
+	})
+
+	t.Run("Set Group Members - Has Submitted", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetGroup("3").Return(group, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(1)).Return([]*models.Student{alice, bob}, nil)
+		mockDB.EXPECT().GetGroupForStudent(uint(1), uint(1)).Return(group, nil)
+		mockDB.EXPECT().GetAssignmentsForClass(uint(1)).Return([]*models.Assignment{assignment}, nil)
+		mockDB.EXPECT().GetSubmissionForGroup(uint(1), uint(3)).Return(submission, nil)
+
+		var resp struct {
+			SetGroupMembers struct{ ID string }
+		}
+		err := c.Post(`mutation { setGroupMembers(groupID: "3", studentIDs: ["1"]) { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "group Team Rocket has submitted Assignment 1")
+	})
+
+	t.Run("Delete Group - Has Submitted", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetGroup("3").Return(group, nil)
+		mockDB.EXPECT().GetAssignmentsForClass(uint(1)).Return([]*models.Assignment{assignment}, nil)
+		mockDB.EXPECT().GetSubmissionForGroup(uint(1), uint(3)).Return(submission, nil)
+
+		var resp struct {
+			DeleteGroup bool
+		}
+		err := c.Post(`mutation { deleteGroup(id: "3") }`, &resp)
+
+		assert.ErrorContains(t, err, "group Team Rocket has submitted Assignment 1")
+	})
+
+	t.Run("Set Submission Group", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetGroup("3").Return(group, nil)
+		mockDB.EXPECT().GetSubmissionForGroup(uint(1), uint(3)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().SetSubmissionGroup(uint(1), &groupID).Return(submission, nil)
+
+		var resp struct {
+			SetSubmissionGroup struct{ ID string }
+		}
+		c.MustPost(`mutation { setSubmissionGroup(submissionID: "1", groupID: "3") { id } }`, &resp)
+
+		assert.Equal(t, "1", resp.SetSubmissionGroup.ID)
+	})
+
+	t.Run("Set Submission Group - Not Group Work", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(&models.Submission{Model: gorm.Model{ID: 1}, StudentID: "s0001", AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", ClassID: 1}, nil)
+
+		var resp struct {
+			SetSubmissionGroup struct{ ID string }
+		}
+		err := c.Post(`mutation { setSubmissionGroup(submissionID: "1", groupID: "3") { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "assignment is not group work")
+	})
+
+	t.Run("Set Submission Group - Group Already Submitted", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("2").Return(&models.Submission{Model: gorm.Model{ID: 2}, StudentID: "s0002", AssignmentID: 1}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetGroup("3").Return(group, nil)
+		mockDB.EXPECT().GetSubmissionForGroup(uint(1), uint(3)).Return(submission, nil)
+
+		var resp struct {
+			SetSubmissionGroup struct{ ID string }
+		}
+		err := c.Post(`mutation { setSubmissionGroup(submissionID: "2", groupID: "3") { id } }`, &resp)
+
+		assert.ErrorContains(t, err, "group Team Rocket already has a submission for the assignment")
+	})
+
+	t.Run("Adjust Member Grade", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(submission, nil)
+		mockDB.EXPECT().GetStudent("2").Return(bob, nil)
+		mockDB.EXPECT().GetGroupMembers(uint(3)).Return([]*models.Student{alice, bob}, nil)
+		mockDB.EXPECT().GetMemberAdjustment(uint(1), uint(2)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().AdjustMemberGrade(models.MemberAdjustment{SubmissionID: 1, StudentID: 2, Marks: -3, Reason: "Didn't contribute", AdjustedBy: "user@example.com"}).
+			Return(&models.MemberAdjustment{Model: gorm.Model{ID: 1}, SubmissionID: 1, StudentID: 2, Marks: -3, Reason: "Didn't contribute", AdjustedBy: "user@example.com"}, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		expectGrade(mockDB)
+
+		var resp struct {
+			AdjustMemberGrade struct {
+				Student    struct{ StudentNumber string }
+				Adjustment float64
+				Reason     *string
+				Total      float64
+			}
+		}
+		c.MustPost(`mutation { adjustMemberGrade(submissionID: "1", studentID: "2", marks: -3, reason: "Didn't contribute") {
+			student { studentNumber } adjustment reason total
+		} }`, &resp)
+
+		assert.Equal(t, "s0002", resp.AdjustMemberGrade.Student.StudentNumber)
+		assert.Equal(t, -3.0, resp.AdjustMemberGrade.Adjustment)
+		require.NotNil(t, resp.AdjustMemberGrade.Reason)
+		assert.Equal(t, "Didn't contribute", *resp.AdjustMemberGrade.Reason)
+		assert.Equal(t, 5.0, resp.AdjustMemberGrade.Total)
+	})
+
+	t.Run("Adjust Member Grade - Reason Required", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		var resp struct {
+			AdjustMemberGrade struct{ Adjustment float64 }
+		}
+		err := c.Post(`mutation { adjustMemberGrade(submissionID: "1", studentID: "2", marks: 2, reason: " ") { adjustment } }`, &resp)
+
+		assert.ErrorContains(t, err, "reason is required")
+	})
+
+	t.Run("Adjust Member Grade - Not A Member", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(submission, nil)
+		mockDB.EXPECT().GetStudent("2").Return(bob, nil)
+		mockDB.EXPECT().GetGroupMembers(uint(3)).Return([]*models.Student{alice}, nil)
+
+		var resp struct {
+			AdjustMemberGrade struct{ Adjustment float64 }
+		}
+		err := c.Post(`mutation { adjustMemberGrade(submissionID: "1", studentID: "2", marks: 0, reason: "") { adjustment } }`, &resp)
+
+		assert.ErrorContains(t, err, "student is not a member of the group")
+	})
+
+	t.Run("Member Grades", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetSubmission("1").Return(submission, nil).Times(3)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		expectGrade(mockDB)
+		mockDB.EXPECT().GetGroupMembers(uint(3)).Return([]*models.Student{alice, bob}, nil)
+		mockDB.EXPECT().GetMemberAdjustments(uint(1)).Return([]*models.MemberAdjustment{{SubmissionID: 1, StudentID: 1, Marks: 4, Reason: "Led the team"}}, nil)
+		mockDB.EXPECT().GetGroup("3").Return(group, nil)
+
+		var resp struct {
+			Submission struct {
+				Group        struct{ Name string }
+				MemberGrades []struct {
+					Student    struct{ StudentNumber string }
+					Adjustment float64
+					Total      float64
+				}
+			}
+		}
+		c.MustPost(`query { submission(id: "1") { group { name } memberGrades { student { studentNumber } adjustment total } } }`, &resp)
+
+		assert.Equal(t, "Team Rocket", resp.Submission.Group.Name)
+		require.Len(t, resp.Submission.MemberGrades, 2)
+		// Adjustments can't take a member past the maximum.
+		assert.Equal(t, "s0001", resp.Submission.MemberGrades[0].Student.StudentNumber)
+		assert.Equal(t, 4.0, resp.Submission.MemberGrades[0].Adjustment)
+		assert.Equal(t, 10.0, resp.Submission.MemberGrades[0].Total)
+		assert.Equal(t, 8.0, resp.Submission.MemberGrades[1].Total)
 	})
+}
 
-	t.Run("No Email", func(t *testing.T) {
+func TestTermResolver(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, time.February, 23, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, time.June, 26, 0, 0, 0, 0, time.UTC)
+	unit := &models.Unit{Model: gorm.Model{ID: 1}, Name: "COMP4050"}
+	term := &models.Term{Model: gorm.Model{ID: 2}, Name: "2026 S1", StartDate: start, EndDate: end}
+	offering := &models.Offering{Model: gorm.Model{ID: 3}, UnitID: 1, Unit: *unit, TermID: 2, Term: *term}
+
+	t.Run("Create Term", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		err := c.Post(`mutation { register(email:"", password: "password") }`, &resp)
-		assert.Error(t, err)
+		mockDB.EXPECT().GetTermByName("2026 S1").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateTerm("2026 S1", start.Local(), end.Local()).Return(term, nil)
+
+		var resp struct {
+			CreateTerm struct {
+				ID, Name           string
+				StartDate, EndDate int
+			}
+		}
+		c.MustPost(fmt.Sprintf(`mutation { createTerm(input: {name: "2026 S1", startDate: %d, endDate: %d}) { id name startDate endDate } }`, start.Unix(), end.Unix()), &resp)
+
+		assert.Equal(t, "2", resp.CreateTerm.ID)
+		assert.Equal(t, "2026 S1", resp.CreateTerm.Name)
+		assert.Equal(t, int(start.Unix()), resp.CreateTerm.StartDate)
+		assert.Equal(t, int(end.Unix()), resp.CreateTerm.EndDate)
 	})
 
-	t.Run("No Password", func(t *testing.T) {
+	t.Run("Create Term - Ends Before It Starts", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		err := c.Post(`mutation { register(email:"a@b.com", password: "") }`, &resp)
-		assert.Error(t, err)
+		var resp struct {
+			CreateTerm struct{ ID string }
+		}
+		err := c.Post(fmt.Sprintf(`mutation { createTerm(input: {name: "2026 S1", startDate: %d, endDate: %d}) { id } }`, end.Unix(), start.Unix()), &resp)
+
+		assert.ErrorContains(t, err, "term must end after it starts")
 	})
 
-	t.Run("Error Getting User", func(t *testing.T) {
+	t.Run("Create Offering", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		customErr := errors.New("my cool error")
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, customErr)
+		mockDB.EXPECT().GetUnitByID("1", false).Return(unit, nil)
+		mockDB.EXPECT().GetTerm("2").Return(term, nil)
+		mockDB.EXPECT().GetOfferingForUnit(uint(1), uint(2)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateOffering(uint(1), uint(2)).Return(&models.Offering{Model: gorm.Model{ID: 3}, UnitID: 1, TermID: 2}, nil)
+		mockDB.EXPECT().GetOffering("3").Return(offering, nil).Times(2)
 
-		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+		var resp struct {
+			CreateOffering struct {
+				ID   string
+				Unit struct{ Name string }
+				Term struct{ Name string }
+			}
+		}
+		c.MustPost(`mutation { createOffering(input: {unitID: "1", termID: "2"}) { id unit { name } term { name } } }`, &resp)
 
-		assert.ErrorContains(t, err, customErr.Error())
+		assert.Equal(t, "3", resp.CreateOffering.ID)
+		assert.Equal(t, "COMP4050", resp.CreateOffering.Unit.Name)
+		assert.Equal(t, "2026 S1", resp.CreateOffering.Term.Name)
 	})
 
-	t.Run("Error Creating User - error", func(t *testing.T) {
+	t.Run("Create Offering - Already Offered", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		customErr := errors.New("my cool error")
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
-		mockDB.EXPECT().CreateUser("a@b.com", gomock.Any(), models.UserRoleAdmin).Return(nil, customErr)
+		mockDB.EXPECT().GetUnitByID("1", false).Return(unit, nil)
+		mockDB.EXPECT().GetTerm("2").Return(term, nil)
+		mockDB.EXPECT().GetOfferingForUnit(uint(1), uint(2)).Return(offering, nil)
 
-		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+		var resp struct {
+			CreateOffering struct{ ID string }
+		}
+		err := c.Post(`mutation { createOffering(input: {unitID: "1", termID: "2"}) { id } }`, &resp)
 
-		assert.ErrorContains(t, err, customErr.Error())
+		assert.ErrorContains(t, err, "COMP4050 is already offered in 2026 S1")
 	})
 
-	t.Run("Error Creating User - nil user", func(t *testing.T) {
+	t.Run("Create Class - Offering Of Another Unit", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
-		mockDB.EXPECT().CreateUser("a@b.com", gomock.Any(), models.UserRoleAdmin).Return(nil, nil)
+		mockDB.EXPECT().GetUnitByID("4", false).Return(&models.Unit{Model: gorm.Model{ID: 4}, Name: "COMP1000"}, nil)
+		mockDB.EXPECT().GetOffering("3").Return(offering, nil)
 
-		err := c.Post(`mutation { register(email:"a@b.com", password: "password") }`, &resp)
+		var resp struct {
+			CreateClass struct{ ID string }
+		}
+		err := c.Post(`mutation { createClass(input: {name: "Class 1", unitID: "4", offeringID: "3"}) { id } }`, &resp)
 
-		assert.ErrorContains(t, err, "error creating user")
+		assert.ErrorContains(t, err, "offering is not of unit COMP1000")
 	})
-}
 
-func TestLoginResolver(t *testing.T) {
-	t.Parallel()
+	t.Run("Get Units For Term", func(t *testing.T) {
+		t.Parallel()
 
-	var resp struct {
-		Login string
-	}
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
 
-	user := &models.User{Model: gorm.Model{ID: 1}, Email: "a@b.com", PasswordHash: "$2y$10$iVyaKJWb4LzkbCMNKl6biuNQNdBG1WSsn3/cMkg3VHg5RSpQTJW0K"}
+		mockDB.EXPECT().GetTerm("2").Return(term, nil)
+		mockDB.EXPECT().GetUnitsForTerm(uint(2), 1).Return([]*models.Unit{unit}, nil)
+		mockDB.EXPECT().GetOfferingsForUnit(uint(1)).Return([]*models.Offering{offering}, nil)
+		mockDB.EXPECT().GetOffering("3").Return(offering, nil)
 
-	t.Run("User", func(t *testing.T) {
+		var resp struct {
+			Units []struct {
+				Name      string
+				Offerings []struct {
+					Term struct{ Name string }
+				}
+			}
+		}
+		c.MustPost(`{ units(termID: "2") { name offerings { term { name } } } }`, &resp)
+
+		require.Len(t, resp.Units, 1)
+		assert.Equal(t, "COMP4050", resp.Units[0].Name)
+		require.Len(t, resp.Units[0].Offerings, 1)
+		assert.Equal(t, "2026 S1", resp.Units[0].Offerings[0].Term.Name)
+	})
+
+	t.Run("Get Classes For Term", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(user, nil)
+		offeringID := uint(3)
+		mockDB.EXPECT().GetTerm("2").Return(term, nil)
+		mockDB.EXPECT().GetClassesForTerm(uint(2), 1).Return([]*models.Class{{Model: gorm.Model{ID: 5}, Name: "Class 1", UnitID: 1, OfferingID: &offeringID}}, nil)
+		mockDB.EXPECT().GetClass("5").Return(&models.Class{Model: gorm.Model{ID: 5}, Name: "Class 1", UnitID: 1, OfferingID: &offeringID}, nil)
 
-		c.MustPost(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
+		var resp struct {
+			Classes []struct {
+				Name     string
+				Offering *struct{ ID string }
+			}
+		}
+		c.MustPost(`{ classes(termID: "2") { name offering { id } } }`, &resp)
 
-		assert.NotEmpty(t, resp.Login)
+		require.Len(t, resp.Classes, 1)
+		require.NotNil(t, resp.Classes[0].Offering)
+		assert.Equal(t, "3", resp.Classes[0].Offering.ID)
 	})
 
-	t.Run("No Email", func(t *testing.T) {
+	t.Run("Current Term - Between Terms", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		err := c.Post(`mutation { login(email:"", password: "password") }`, &resp)
-		assert.Error(t, err)
+		mockDB.EXPECT().GetTermAt(gomock.Any()).Return(nil, db.ErrRecordNotFound)
+
+		var resp struct {
+			CurrentTerm *struct{ ID string }
+		}
+		c.MustPost(`{ currentTerm { id } }`, &resp)
+
+		assert.Nil(t, resp.CurrentTerm)
 	})
 
-	t.Run("No Password", func(t *testing.T) {
+	t.Run("Clone Offering", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		err := c.Post(`mutation { login(email:"a@b.com", password: "") }`, &resp)
-		assert.Error(t, err)
+		next := &models.Term{Model: gorm.Model{ID: 4}, Name: "2027 S1", StartDate: start.AddDate(0, 0, 364), EndDate: end.AddDate(0, 0, 364)}
+		mockDB.EXPECT().GetOffering("3").Return(offering, nil)
+		mockDB.EXPECT().GetTerm("4").Return(next, nil)
+		mockDB.EXPECT().GetOfferingForUnit(uint(1), uint(4)).Return(nil, db.ErrRecordNotFound)
+		// Dates move by the time between the terms' start dates.
+		mockDB.EXPECT().CloneOffering(uint(3), uint(4), 364*24*time.Hour).Return(&models.OfferingClone{
+			Offering:       &models.Offering{Model: gorm.Model{ID: 5}, UnitID: 1, TermID: 4},
+			Classes:        2,
+			Assignments:    3,
+			Tests:          6,
+			RubricCriteria: 4,
+			LintRules:      1,
+			StarterFiles:   2,
+		}, nil)
+
+		var resp struct {
+			CloneOffering struct {
+				Offering       struct{ ID string }
+				Classes        int
+				Assignments    int
+				Tests          int
+				RubricCriteria int
+				LintRules      int
+				StarterFiles   int
+			}
+		}
+		c.MustPost(`mutation { cloneOffering(input: {offeringID: "3", termID: "4"}) {
+			offering { id } classes assignments tests rubricCriteria lintRules starterFiles
+		} }`, &resp)
+
+		assert.Equal(t, "5", resp.CloneOffering.Offering.ID)
+		assert.Equal(t, 2, resp.CloneOffering.Classes)
+		assert.Equal(t, 3, resp.CloneOffering.Assignments)
+		assert.Equal(t, 6, resp.CloneOffering.Tests)
+		assert.Equal(t, 4, resp.CloneOffering.RubricCriteria)
+		assert.Equal(t, 1, resp.CloneOffering.LintRules)
+		assert.Equal(t, 2, resp.CloneOffering.StarterFiles)
 	})
 
-	t.Run("User Does Not Exist - not found error", func(t *testing.T) {
+	t.Run("Clone Offering - Offset", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, db.ErrRecordNotFound)
+		next := &models.Term{Model: gorm.Model{ID: 4}, Name: "2027 S1", StartDate: start.AddDate(0, 0, 364), EndDate: end.AddDate(0, 0, 364)}
+		mockDB.EXPECT().GetOffering("3").Return(offering, nil)
+		mockDB.EXPECT().GetTerm("4").Return(next, nil)
+		mockDB.EXPECT().GetOfferingForUnit(uint(1), uint(4)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CloneOffering(uint(3), uint(4), 7*24*time.Hour).Return(&models.OfferingClone{
+			Offering: &models.Offering{Model: gorm.Model{ID: 5}, UnitID: 1, TermID: 4},
+		}, nil)
 
-		err := c.Post(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
-		assert.Error(t, err)
+		var resp struct {
+			CloneOffering struct{ Offering struct{ ID string } }
+		}
+		c.MustPost(`mutation { cloneOffering(input: {offeringID: "3", termID: "4", offset: 604800}) { offering { id } } }`, &resp)
+
+		assert.Equal(t, "5", resp.CloneOffering.Offering.ID)
 	})
 
-	t.Run("User Does Not Exist - nil user", func(t *testing.T) {
+	t.Run("Clone Offering - Already Offered", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(nil, nil)
+		mockDB.EXPECT().GetOffering("3").Return(offering, nil)
+		mockDB.EXPECT().GetTerm("2").Return(term, nil)
+		mockDB.EXPECT().GetOfferingForUnit(uint(1), uint(2)).Return(offering, nil)
 
-		err := c.Post(`mutation { login(email:"a@b.com", password: "password") }`, &resp)
-		assert.ErrorContains(t, err, "user with email: a@b.com does not exist")
+		var resp struct {
+			CloneOffering struct{ Classes int }
+		}
+		err := c.Post(`mutation { cloneOffering(input: {offeringID: "3", termID: "2"}) { classes } }`, &resp)
+
+		assert.ErrorContains(t, err, "COMP4050 is already offered in 2026 S1")
 	})
+}
 
-	t.Run("User Incorrect Password", func(t *testing.T) {
+func TestExportGradesMutation(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2022, 10, 1, 17, 0, 0, 0, time.UTC)
+	aliceID := uint(7)
+	assignment := &models.Assignment{
+		Model:      gorm.Model{ID: 1},
+		Name:       "Assignment 1",
+		DueDate:    due,
+		ClassID:    2,
+		LatePolicy: models.LatePolicy{PenaltyPerDay: 10},
+	}
+	students := []*models.Student{
+		{Model: gorm.Model{ID: 8}, StudentNumber: "s0002", Name: "Bob Seal", Email: "bob@example.com"},
+		{Model: gorm.Model{ID: 7}, StudentNumber: "s0001", Name: "Alice Penguin", Email: "alice@example.com"},
+	}
+	submissions := []*models.Submission{
+		{Model: gorm.Model{ID: 1}, StudentID: "s0001_Alice_Penguin", AssignmentID: 1, StudentRecordID: &aliceID},
+		{Model: gorm.Model{ID: 2}, StudentID: "s0003_Carol_Seal", AssignmentID: 1},
+	}
+
+	// Alice submits an hour late and scores 8 out of 10, unless her submission was
+	// moderated, Bob doesn't submit and Carol submits on time under an identifier that
+	// doesn't match a student.
+	expectGradebook := func(mockDB *mocks.MockDatabase, aliceSample *models.ModerationSample) {
+		mockDB.EXPECT().GetStudentsForClass(uint(2)).Return(students, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil).Times(3)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil).Times(3)
+		mockDB.EXPECT().GetSubmissionsForAssignment("1").Return(submissions, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(1)).Return([]*models.SubmissionVersion{
+			{Model: gorm.Model{ID: 4, CreatedAt: due.Add(time.Hour)}, Number: 1, SubmissionID: 1},
+		}, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(2)).Return([]*models.SubmissionVersion{
+			{Model: gorm.Model{ID: 5, CreatedAt: due.Add(-time.Hour)}, Number: 1, SubmissionID: 2},
+		}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(gomock.Any()).Return(nil, nil).Times(2)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(4)).Return([]*models.TestOutcome{{Model: gorm.Model{ID: 1}, Points: 8, TestID: 1}}, nil)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(5)).Return([]*models.TestOutcome{{Model: gorm.Model{ID: 2}, Points: 5, TestID: 1}}, nil)
+		mockDB.EXPECT().GetRubricMarks(gomock.Any()).Return(nil, nil).Times(2)
+		mockDB.EXPECT().GetModerationSample(uint(1)).Return(aliceSample, nil)
+		mockDB.EXPECT().GetModerationSample(uint(2)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(nil, nil).Times(2)
+		mockDB.EXPECT().GetExtensionForStudent(uint(7), uint(1)).Return(nil, db.ErrRecordNotFound)
+	}
+
+	type gradeExport struct {
+		Filename, ContentType, Content string
+	}
+
+	t.Run("Export Assignment Grades", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetUserByEmail("a@b.com").Return(user, nil)
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetClass("2").Return(&models.Class{Model: gorm.Model{ID: 2}, Name: "Class 1"}, nil)
+		expectGradebook(mockDB, nil)
 
-		err := c.Post(`mutation { login(email:"a@b.com", password: "wrong_password") }`, &resp)
-		assert.Error(t, err)
+		var resp struct{ ExportAssignmentGrades gradeExport }
+		c.MustPost(`mutation { exportAssignmentGrades(assignmentID: "1", format: CSV) { filename contentType content } }`, &resp)
+
+		assert.Equal(t, "assignment-1-csv.csv", resp.ExportAssignmentGrades.Filename)
+		assert.Equal(t, "text/csv", resp.ExportAssignmentGrades.ContentType)
+		assert.Equal(t, "Student ID,Name,Email,Assignment 1 Raw,Assignment 1 Penalty,Assignment 1 Final\n"+
+			"s0001,Alice Penguin,alice@example.com,8,0.8,7.2\n"+
+			"s0002,Bob Seal,bob@example.com,,,\n"+
+			"s0003,s0003_Carol_Seal,,5,0,5\n", resp.ExportAssignmentGrades.Content)
+	})
+
+	t.Run("Export Assignment Grades - Resolved Moderation", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		first, second, agreed := 8.0, 4.0, 6.0
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetClass("2").Return(&models.Class{Model: gorm.Model{ID: 2}, Name: "Class 1"}, nil)
+		expectGradebook(mockDB, &models.ModerationSample{
+			SubmissionID: 1,
+			AssignmentID: 1,
+			Status:       models.ModerationStatusResolved,
+			FirstMark:    &first,
+			SecondMark:   &second,
+			AgreedMark:   &agreed,
+		})
+
+		var resp struct{ ExportAssignmentGrades gradeExport }
+		c.MustPost(`mutation { exportAssignmentGrades(assignmentID: "1", format: CSV) { filename contentType content } }`, &resp)
+
+		// The agreed mark replaces Alice's first mark, before her late penalty.
+		assert.Equal(t, "Student ID,Name,Email,Assignment 1 Raw,Assignment 1 Penalty,Assignment 1 Final\n"+
+			"s0001,Alice Penguin,alice@example.com,6,0.6,5.4\n"+
+			"s0002,Bob Seal,bob@example.com,,,\n"+
+			"s0003,s0003_Carol_Seal,,5,0,5\n", resp.ExportAssignmentGrades.Content)
+	})
+
+	t.Run("Export Assignment Grades - Blind Second Marker", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClientAs(mockDB, &models.User{Email: "tutor@example.com", Role: models.UserRoleTutor})
+
+		mockDB.EXPECT().GetAssignment("1").Return(assignment, nil)
+		mockDB.EXPECT().GetClass("2").Return(&models.Class{Model: gorm.Model{ID: 2}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(2)).Return(students, nil)
+		mockDB.EXPECT().GetTestsForAssignment("1").Return([]*models.Test{{Model: gorm.Model{ID: 1}, MaxPoints: 10, Weight: 1}}, nil).Times(2)
+		mockDB.EXPECT().GetRubric(uint(1)).Return(nil, nil).Times(2)
+		mockDB.EXPECT().GetSubmissionsForAssignment("1").Return(submissions, nil)
+		mockDB.EXPECT().GetModerationSamples(uint(1)).Return([]*models.ModerationSample{
+			{SubmissionID: 1, AssignmentID: 1, SecondMarker: "tutor@example.com", Status: models.ModerationStatusAwaitingSecondMark},
+		}, nil)
+		mockDB.EXPECT().GetSubmissionVersions(uint(2)).Return([]*models.SubmissionVersion{
+			{Model: gorm.Model{ID: 5, CreatedAt: due.Add(-time.Hour)}, Number: 1, SubmissionID: 2},
+		}, nil)
+		mockDB.EXPECT().GetResultsForSubmission(uint(2)).Return(nil, nil)
+		mockDB.EXPECT().GetTestOutcomesForVersion(uint(5)).Return([]*models.TestOutcome{{Model: gorm.Model{ID: 2}, Points: 5, TestID: 1}}, nil)
+		mockDB.EXPECT().GetRubricMarks(uint(2)).Return(nil, nil)
+		mockDB.EXPECT().GetModerationSample(uint(2)).Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(nil, nil)
+
+		var resp struct{ ExportAssignmentGrades gradeExport }
+		c.MustPost(`mutation { exportAssignmentGrades(assignmentID: "1", format: CSV) { filename contentType content } }`, &resp)
+
+		// Alice's submission is waiting for the tutor's second mark, so is left blank.
+		assert.Equal(t, "Student ID,Name,Email,Assignment 1 Raw,Assignment 1 Penalty,Assignment 1 Final\n"+
+			"s0001,Alice Penguin,alice@example.com,,,\n"+
+			"s0002,Bob Seal,bob@example.com,,,\n"+
+			"s0003,s0003_Carol_Seal,,5,0,5\n", resp.ExportAssignmentGrades.Content)
+	})
+
+	t.Run("Export Class Grades - Canvas", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		mockDB.EXPECT().GetClass("2").Return(&models.Class{Model: gorm.Model{ID: 2}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().GetAssignmentsForClass(uint(2)).Return([]*models.Assignment{assignment}, nil)
+		expectGradebook(mockDB, nil)
+
+		var resp struct{ ExportClassGrades gradeExport }
+		c.MustPost(`mutation { exportClassGrades(classID: "2", format: CANVAS) { filename content } }`, &resp)
+
+		assert.Equal(t, "class-1-canvas.csv", resp.ExportClassGrades.Filename)
+		assert.Equal(t, "Student,ID,SIS User ID,SIS Login ID,Section,Assignment 1\n"+
+			"\"    Points Possible\",,,,,10\n"+
+			"\"Penguin, Alice\",,s0001,alice@example.com,Class 1,7.2\n"+
+			"\"Seal, Bob\",,s0002,bob@example.com,Class 1,\n"+
+			"s0003_Carol_Seal,,s0003,,Class 1,5\n", resp.ExportClassGrades.Content)
+	})
+
+	t.Run("Export Assignment Grades - Unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, false)
+
+		var resp struct{ ExportAssignmentGrades gradeExport }
+		err := c.Post(`mutation { exportAssignmentGrades(assignmentID: "1", format: MOODLE) { content } }`, &resp)
+
+		assert.ErrorContains(t, err, "user not authenticated")
 	})
 }
 
-func TestResetDBMutation(t *testing.T) {
+func TestImportRosterMutation(t *testing.T) {
 	t.Parallel()
 
 	var resp struct {
-		ResetDB bool
+		ImportRoster struct {
+			Created, Updated, Skipped, Errored int
+			Rows                               []struct {
+				Line          int
+				StudentNumber string
+				Status        string
+				Message       *string
+			}
+		}
 	}
 
-	t.Run("Reset DB", func(t *testing.T) {
+	writeRoster := func(t *testing.T, contents string) *os.File {
+		file, err := os.CreateTemp(t.TempDir(), "roster*.csv")
+		require.NoError(t, err)
+		_, err = file.WriteString(contents)
+		require.NoError(t, err)
+		_, err = file.Seek(0, 0)
+		require.NoError(t, err)
+
+		return file
+	}
+
+	t.Run("Import Roster", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().ResetDB().Return(mockDB, nil)
+		file := writeRoster(t, "\ufeffStudent ID,Surname,Given Name,Email Address\n"+
+			"s0001,Penguin,Alice,alice@example.com\n"+
+			"s0003,Eagle,Bob,bob@example.com\n"+
+			"s0005,Turkey,Carol,carol@example.com\n"+
+			"s0001,Penguin,Alice,alice@example.com\n"+
+			",Raven,Dave,dave@example.com\n")
 
-		c.MustPost(`mutation { resetDB() }`, &resp)
+		mockDB.EXPECT().GetClass("1").Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1"}, nil)
+		expectTransaction(mockDB)
+
+		mockDB.EXPECT().GetStudentByNumber("s0001").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateStudent("s0001", "Alice Penguin", "alice@example.com").Return(&models.Student{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().EnrolStudent(uint(1), uint(1)).Return(nil)
+
+		mockDB.EXPECT().GetStudentByNumber("s0003").Return(&models.Student{Model: gorm.Model{ID: 2}, StudentNumber: "s0003", Name: "Robert Eagle", Email: "bob@example.com"}, nil)
+		mockDB.EXPECT().UpdateStudent(uint(2), "Bob Eagle", "bob@example.com").Return(&models.Student{Model: gorm.Model{ID: 2}}, nil)
+		mockDB.EXPECT().EnrolStudent(uint(2), uint(1)).Return(nil)
+
+		mockDB.EXPECT().GetStudentByNumber("s0005").Return(&models.Student{Model: gorm.Model{ID: 3}, StudentNumber: "s0005", Name: "Carol Turkey", Email: "carol@example.com"}, nil)
+		mockDB.EXPECT().EnrolStudent(uint(3), uint(1)).Return(nil)
+
+		c.MustPost(`mutation($file: Upload!) { importRoster(classID: "1", file: $file) { created updated skipped errored rows { line studentNumber status message } } }`, &resp,
+			client.Var("file", file), client.WithFiles())
+
+		assert.Equal(t, 1, resp.ImportRoster.Created)
+		assert.Equal(t, 1, resp.ImportRoster.Updated)
+		assert.Equal(t, 2, resp.ImportRoster.Skipped)
+		assert.Equal(t, 1, resp.ImportRoster.Errored)
+		require.Len(t, resp.ImportRoster.Rows, 5)
+		assert.Equal(t, 2, resp.ImportRoster.Rows[0].Line)
+		assert.Equal(t, "CREATED", resp.ImportRoster.Rows[0].Status)
+		assert.Equal(t, "UPDATED", resp.ImportRoster.Rows[1].Status)
+		assert.Equal(t, "SKIPPED", resp.ImportRoster.Rows[2].Status)
+		assert.Nil(t, resp.ImportRoster.Rows[2].Message)
+		assert.Equal(t, "SKIPPED", resp.ImportRoster.Rows[3].Status)
+		assert.Equal(t, "duplicate of line 2", *resp.ImportRoster.Rows[3].Message)
+		assert.Equal(t, "ERRORED", resp.ImportRoster.Rows[4].Status)
+		assert.Equal(t, "missing student number", *resp.ImportRoster.Rows[4].Message)
+	})
 
-		assert.True(t, resp.ResetDB)
+	t.Run("Import Roster - Database Error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		file := writeRoster(t, "Student ID,Name\ns0001,Alice Penguin\ns0003,Bob Eagle\n")
+
+		mockDB.EXPECT().GetClass("1").Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1"}, nil)
+		mockDB.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(db.Database) error) error {
+			err := fn(mockDB)
+			assert.Error(t, err, "the import should roll back")
+			return err
+		})
+		mockDB.EXPECT().GetStudentByNumber("s0001").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateStudent("s0001", "Alice Penguin", "").Return(&models.Student{Model: gorm.Model{ID: 1}}, nil)
+		mockDB.EXPECT().EnrolStudent(uint(1), uint(1)).Return(nil)
+		mockDB.EXPECT().GetStudentByNumber("s0003").Return(nil, db.ErrRecordNotFound)
+		mockDB.EXPECT().CreateStudent("s0003", "Bob Eagle", "").Return(nil, errors.New("database is locked"))
+
+		var resp struct {
+			ImportRoster struct{ Created int }
+		}
+		err := c.Post(`mutation($file: Upload!) { importRoster(classID: "1", file: $file) { created } }`, &resp,
+			client.Var("file", file), client.WithFiles())
+
+		assert.ErrorContains(t, err, "line 3: error creating student: database is locked")
 	})
 
-	t.Run("Reset DB - Unauthenticated", func(t *testing.T) {
+	t.Run("Import Roster - Missing Columns", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		file := writeRoster(t, "Name,Email\nAlice Penguin,alice@example.com\n")
+
+		mockDB.EXPECT().GetClass("1").Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1"}, nil)
+
+		err := c.Post(`mutation($file: Upload!) { importRoster(classID: "1", file: $file) { created } }`, &resp,
+			client.Var("file", file), client.WithFiles())
+
+		assert.ErrorContains(t, err, "roster is missing a student number column")
+	})
+
+	t.Run("Import Roster - Unauthenticated", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, false)
 
-		err := c.Post(`mutation { resetDB() }`, &resp)
+		file := writeRoster(t, "Student ID,Name\ns0001,Alice Penguin\n")
 
-		require.Error(t, err)
+		err := c.Post(`mutation($file: Upload!) { importRoster(classID: "1", file: $file) { created } }`, &resp,
+			client.Var("file", file), client.WithFiles())
 
-		assert.False(t, resp.ResetDB)
+		assert.ErrorContains(t, err, "user not authenticated")
 	})
 }
 
-func TestRunTestMutation(t *testing.T) {
+func TestImportSubmissionsMutation(t *testing.T) {
+	t.Parallel()
+
 	var resp struct {
-		RunTest bool
+		ImportSubmissions struct {
+			Created, Resubmitted, Duplicates, Unmatched, Errored int
+			Rows                                                 []struct {
+				Folder, StudentNumber, Status string
+				Files                         int
+				Submission                    *struct{ ID string }
+				Message                       *string
+			}
+		}
 	}
 
-	t.Run("Run Test", func(t *testing.T) {
+	// zipSampleSubmissions zips the sample submissions under an enclosing folder, the
+	// way an LMS bulk download is usually shared.
+	zipSampleSubmissions := func(t *testing.T) *os.File {
+		file, err := os.CreateTemp(t.TempDir(), "submissions*.zip")
+		require.NoError(t, err)
+
+		w := zip.NewWriter(file)
+		root := filepath.Join("..", "scripts", "data", "submissions")
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			f, err := w.Create("submissions/" + filepath.ToSlash(rel))
+			if err != nil {
+				return err
+			}
+			_, err = f.Write(content)
+			return err
+		})
+		require.NoError(t, err)
+
+		_, err = w.Create("__MACOSX/submissions/._s0001_Alice_Penguin")
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		_, err = file.Seek(0, 0)
+		require.NoError(t, err)
+
+		return file
+	}
+
+	t.Run("Import Submissions", func(t *testing.T) {
 		t.Parallel()
 
 		ctrl := gomock.NewController(t)
 		mockDB := mocks.NewMockDatabase(ctrl)
 		c := newClient(mockDB, true)
 
-		mockDB.EXPECT().GetTest("1").Return(&models.Test{Model: gorm.Model{ID: 1}, Name: "Test 1", AssignmentID: 1}, nil)
-		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "Assignment 1", ClassID: 1}, nil)
-		mockDB.EXPECT().GetClass("1").Return(&models.Class{Model: gorm.Model{ID: 1}, Name: "Class 1", UnitID: 1}, nil)
-		mockDB.EXPECT().GetUnitByID("1", false).Return(&models.Unit{Model: gorm.Model{ID: 1}, Name: "COMP1000"}, nil)
+		file := zipSampleSubmissions(t)
+
+		alice := uint(1)
+		bob := uint(2)
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "MarchPenguin", ClassID: 3}, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(3)).Return([]*models.Student{
+			{Model: gorm.Model{ID: alice}, StudentNumber: "s0001"},
+			{Model: gorm.Model{ID: bob}, StudentNumber: "s0003"},
+		}, nil)
+		newVersion := func(submissionID uint, files []models.SubmissionFile) *models.SubmissionVersion {
+			require.Len(t, files, 4)
+			assert.Equal(t, "MarchPenguin/Beak.pde", files[0].Path)
+			assert.NotEmpty(t, files[0].Content)
 
-		c.MustPost(`mutation { runTest(testID: "1") }`, &resp)
+			return &models.SubmissionVersion{Model: gorm.Model{ID: submissionID + 100}, SubmissionID: submissionID, Files: files}
+		}
+		// Carol has already submitted, so gets a new version of submission 9.
+		for id, submitted := range map[uint]struct {
+			name      string
+			studentID *uint
+			created   bool
+		}{9: {"s0005_Carol_Turkey", nil, false}, 10: {"s0001_Alice_Penguin", &alice, true}, 11: {"s0003_Bob_Eagle", &bob, true}, 12: {"s0007_Dave_Raven", nil, true}} {
+			id, name, created := id, submitted.name, submitted.created
+			mockDB.EXPECT().SubmitVersion(uint(1), name, submitted.studentID, gomock.Any()).DoAndReturn(func(assignmentID uint, studentID string, studentRecordID *uint, files []models.SubmissionFile) (*models.Submission, *models.SubmissionVersion, bool, error) {
+				return &models.Submission{Model: gorm.Model{ID: id}, StudentID: name}, newVersion(id, files), created, nil
+			})
+		}
 
-		assert.True(t, resp.RunTest)
+		// Every student was given the same Landscape.pde, which is marked as starter code
+		// when the new versions are analysed.
+		landscape, err := os.ReadFile(filepath.Join("..", "scripts", "data", "submissions", "s0001_Alice_Penguin", "MarchPenguin", "Landscape.pde"))
+		require.NoError(t, err)
+		mockDB.EXPECT().GetStarterFiles(uint(1)).Return([]*models.StarterFile{{Path: "MarchPenguin/Landscape.pde", Content: landscape}}, nil)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return([]*models.LintRule{
+			{Model: gorm.Model{ID: 1}, Kind: models.LintRequiredClass, Severity: models.LintSeverityError, Names: []string{"Penguin", "Walrus"}},
+			{Model: gorm.Model{ID: 2}, Kind: models.LintRequireComments, Severity: models.LintSeverityInfo},
+		}, nil)
+		mockDB.EXPECT().SaveCodeMetrics(gomock.Any(), gomock.Any()).DoAndReturn(func(versionID uint, metrics []models.CodeMetrics) error {
+			require.Len(t, metrics, 4)
+			assert.Equal(t, "MarchPenguin/Landscape.pde", metrics[1].Path)
+			assert.True(t, metrics[1].Starter)
+			assert.False(t, metrics[0].Starter)
+
+			return nil
+		}).Times(4)
+		mockDB.EXPECT().SaveLintFindings(gomock.Any(), gomock.Any()).DoAndReturn(func(versionID uint, findings []models.LintFinding) error {
+			require.NotEmpty(t, findings)
+			assert.Equal(t, models.LintFinding{Severity: models.LintSeverityError, Message: "class Walrus is not declared", RuleID: 1}, findings[0])
+			for _, finding := range findings[1:] {
+				assert.Equal(t, uint(2), finding.RuleID)
+				assert.NotEqual(t, "MarchPenguin/Landscape.pde", finding.Path)
+			}
+
+			return nil
+		}).Times(4)
+
+		c.MustPost(`mutation($file: Upload!) { importSubmissions(assignmentID: "1", file: $file) { created resubmitted duplicates unmatched errored rows { folder studentNumber status files submission { id } message } } }`, &resp,
+			client.Var("file", file), client.WithFiles())
+
+		assert.Equal(t, 2, resp.ImportSubmissions.Created)
+		assert.Equal(t, 1, resp.ImportSubmissions.Resubmitted)
+		assert.Equal(t, 0, resp.ImportSubmissions.Duplicates)
+		assert.Equal(t, 1, resp.ImportSubmissions.Unmatched)
+		assert.Equal(t, 0, resp.ImportSubmissions.Errored)
+		require.Len(t, resp.ImportSubmissions.Rows, 4)
+		assert.Equal(t, "s0001_Alice_Penguin", resp.ImportSubmissions.Rows[0].Folder)
+		assert.Equal(t, "s0001", resp.ImportSubmissions.Rows[0].StudentNumber)
+		assert.Equal(t, "CREATED", resp.ImportSubmissions.Rows[0].Status)
+		assert.Equal(t, 4, resp.ImportSubmissions.Rows[0].Files)
+		assert.Equal(t, "10", resp.ImportSubmissions.Rows[0].Submission.ID)
+		assert.Equal(t, "RESUBMITTED", resp.ImportSubmissions.Rows[2].Status)
+		assert.Equal(t, "9", resp.ImportSubmissions.Rows[2].Submission.ID)
+		assert.Equal(t, "UNMATCHED", resp.ImportSubmissions.Rows[3].Status)
+		assert.Equal(t, "12", resp.ImportSubmissions.Rows[3].Submission.ID)
+	})
+
+	t.Run("Import Submissions - Analysis Fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		file := zipSampleSubmissions(t)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "MarchPenguin", ClassID: 3}, nil)
+		mockDB.EXPECT().GetStudentsForClass(uint(3)).Return(nil, nil)
+		submissionIDs := map[string]uint{"s0001_Alice_Penguin": 9, "s0003_Bob_Eagle": 10, "s0005_Carol_Turkey": 11, "s0007_Dave_Raven": 12}
+		mockDB.EXPECT().SubmitVersion(uint(1), gomock.Any(), nil, gomock.Any()).DoAndReturn(func(assignmentID uint, studentID string, studentRecordID *uint, files []models.SubmissionFile) (*models.Submission, *models.SubmissionVersion, bool, error) {
+			submissionID := submissionIDs[studentID]
+			return &models.Submission{Model: gorm.Model{ID: submissionID}, StudentID: studentID}, &models.SubmissionVersion{Model: gorm.Model{ID: submissionID + 100}, SubmissionID: submissionID, Files: files}, false, nil
+		}).Times(4)
+		mockDB.EXPECT().GetStarterFiles(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().GetLintRules(uint(1)).Return(nil, nil)
+		mockDB.EXPECT().SaveCodeMetrics(uint(109), gomock.Any()).Return(errors.New("database is locked"))
+
+		var resp struct {
+			ImportSubmissions struct {
+				Resubmitted int
+				Rows        []struct{ Status string }
+			}
+		}
+		err := c.Post(`mutation($file: Upload!) { importSubmissions(assignmentID: "1", file: $file) { resubmitted rows { status } } }`, &resp,
+			client.Var("file", file), client.WithFiles())
+
+		// The files were stored, so the report is still returned.
+		assert.ErrorContains(t, err, "error analysing submissions: error saving code metrics: database is locked")
+		assert.Equal(t, 4, resp.ImportSubmissions.Resubmitted)
+		assert.Len(t, resp.ImportSubmissions.Rows, 4)
+	})
+
+	t.Run("Import Submissions - Not A Zip", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(ctrl)
+		c := newClient(mockDB, true)
+
+		file, err := os.CreateTemp(t.TempDir(), "submissions*.zip")
+		require.NoError(t, err)
+		_, err = file.WriteString("not a zip")
+		require.NoError(t, err)
+		_, err = file.Seek(0, 0)
+		require.NoError(t, err)
+
+		mockDB.EXPECT().GetAssignment("1").Return(&models.Assignment{Model: gorm.Model{ID: 1}, Name: "MarchPenguin", ClassID: 3}, nil)
+
+		err = c.Post(`mutation($file: Upload!) { importSubmissions(assignmentID: "1", file: $file) { created } }`, &resp,
+			client.Var("file", file), client.WithFiles())
+
+		assert.ErrorContains(t, err, "error opening archive")
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"log"
 	"os"
+	"strings"
 )
 
 type Config struct {
@@ -11,6 +12,9 @@ type Config struct {
 	JWTSecret            string
 	DBFilePath           string
 	TestExecutorEndpoint string
+	// TrustedProxies are the addresses allowed to set the client IP with headers such
+	// as X-Forwarded-For. With none, the client IP is the connection's address.
+	TrustedProxies []string
 }
 
 func NewConfig() Config {
@@ -20,9 +24,16 @@ func NewConfig() Config {
 	flag.IntVar(&c.Port, "port", 8080, "The port to listen on. Default is 8080")
 	flag.StringVar(&c.DBFilePath, "db-path", "db.sqlite", "The path to the sqlite3 database. Default is db.sqlite")
 	flag.StringVar(&c.TestExecutorEndpoint, "test-executor-endpoint", "http://localhost:8080/", "The endpoint to the test executor. Default is http://localhost:8080/")
+	trustedProxies := flag.String("trusted-proxies", os.Getenv("TRUSTED_PROXIES"), "Comma separated IPs or CIDRs of proxies in front of the API. Default is none")
 
 	flag.Parse()
 
+	for _, proxy := range strings.Split(*trustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			c.TrustedProxies = append(c.TrustedProxies, proxy)
+		}
+	}
+
 	if c.JWTSecret == "" {
 		log.Fatal("The JWT secret is required")
 	}
@@ -1,47 +1,165 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Database interface {
 	ResetDB() (Database, error)
 
 	CreateUser(email, passwordHash string, role models.UserRole) (*models.User, error)
+	CreateStudentUser(email, passwordHash string, studentID uint) (*models.User, error)
+	SetStudentInvite(studentID uint, codeHash string, expiresAt time.Time) (*models.StudentInvite, error)
+	GetStudentInvite(studentID uint) (*models.StudentInvite, error)
 	GetUserByEmail(email string) (*models.User, error)
+	UpdateLoginFailures(userID uint, failedAttempts int, lockedUntil time.Time) error
+	RecordLoginFailure(userID uint) (int, error)
+	LockUser(userID uint, lockedUntil time.Time) error
 
 	CreateUnit(name string) (*models.Unit, error)
 	GetAllUnits(from int) ([]*models.Unit, error)
 	GetUnitByID(id string, fetchClasses bool) (*models.Unit, error)
 	GetUnitByName(name string) (*models.Unit, error)
-
-	CreateClass(name string, unitID uint) (*models.Class, error)
+	GetUnitsForTerm(termID uint, from int) ([]*models.Unit, error)
+
+	CreateTerm(name string, startDate, endDate time.Time) (*models.Term, error)
+	GetTerm(id string) (*models.Term, error)
+	GetTermByName(name string) (*models.Term, error)
+	GetTerms() ([]*models.Term, error)
+	GetTermAt(t time.Time) (*models.Term, error)
+
+	CreateOffering(unitID, termID uint) (*models.Offering, error)
+	GetOffering(id string) (*models.Offering, error)
+	GetOfferingForUnit(unitID, termID uint) (*models.Offering, error)
+	GetOfferingsForUnit(unitID uint) ([]*models.Offering, error)
+	GetOfferingsForTerm(termID uint) ([]*models.Offering, error)
+	CloneOffering(offeringID, termID uint, offset time.Duration) (*models.OfferingClone, error)
+
+	CreateClass(name string, unitID uint, offeringID *uint) (*models.Class, error)
 	GetAllClasses(from int) ([]*models.Class, error)
 	GetClass(id string) (*models.Class, error)
+	GetClassesForOffering(offeringID uint) ([]*models.Class, error)
+	GetClassesForTerm(termID uint, from int) ([]*models.Class, error)
 
 	CreateAssignment(name string, dueDate int, classID uint) (*models.Assignment, error)
 	GetAllAssignments(from int) ([]*models.Assignment, error)
+	GetAssignmentsForTerm(termID uint, from int) ([]*models.Assignment, error)
 	GetAssignment(id string) (*models.Assignment, error)
 	GetAssignmentsForClass(classID uint) ([]*models.Assignment, error)
-
-	CreateTest(name string, assignmentID uint) (*models.Test, error)
+	UpdateAttemptPolicy(assignmentID uint, policy models.AttemptPolicy) (*models.Assignment, error)
+	UpdateLatePolicy(assignmentID uint, policy models.LatePolicy) (*models.Assignment, error)
+	UpdateFeedbackRelease(assignmentID uint, release models.FeedbackRelease) (*models.Assignment, error)
+	UpdateGroupWork(assignmentID uint, groupWork bool) (*models.Assignment, error)
+	GetAssignmentStatistics(assignmentID uint) (*models.AssignmentStatistics, error)
+	SetStarterFiles(assignmentID uint, files []models.StarterFile) ([]*models.StarterFile, error)
+	GetStarterFiles(assignmentID uint) ([]*models.StarterFile, error)
+
+	CreateTest(name string, assignmentID uint, maxPoints, weight float64) (*models.Test, error)
 	GetAllTests(from int) ([]*models.Test, error)
 	GetTest(id string) (*models.Test, error)
 	GetTestsForAssignment(assignmentID string) ([]*models.Test, error)
+	UpdateTestScoring(testID uint, maxPoints, weight float64) (*models.Test, error)
 
-	CreateSubmission(studentID string, assignmentID uint) (*models.Submission, error)
+	CreateTestOutcomes(outcomes []models.TestOutcome) error
+	GetTestOutcomesForVersion(submissionVersionID uint) ([]*models.TestOutcome, error)
+	GetTestOutcomesForAssignment(assignmentID uint) ([]*models.TestOutcome, error)
+
+	CreateSubmission(studentID string, assignmentID uint, studentRecordID *uint, files []models.SubmissionFile) (*models.Submission, *models.SubmissionVersion, error)
 	GetAllSubmissions(from int) ([]*models.Submission, error)
 	GetSubmission(id string) (*models.Submission, error)
 	GetSubmissionsForAssignment(assignmentID string) ([]*models.Submission, error)
-
-	CreateResult(score float64, submissionID uint) (*models.Result, error)
+	GetSubmissionsForStudent(studentID uint) ([]*models.Submission, error)
+	GetSubmissionForStudent(assignmentID uint, studentID string, studentRecordID *uint) (*models.Submission, error)
+	SubmitVersion(assignmentID uint, studentID string, studentRecordID *uint, files []models.SubmissionFile) (*models.Submission, *models.SubmissionVersion, bool, error)
+	GetSubmissionForGroup(assignmentID, groupID uint) (*models.Submission, error)
+	SetSubmissionGroup(submissionID uint, groupID *uint) (*models.Submission, error)
+
+	CreateSubmissionVersion(submissionID uint, files []models.SubmissionFile) (*models.SubmissionVersion, error)
+	GetSubmissionVersion(id string) (*models.SubmissionVersion, error)
+	GetSubmissionVersions(submissionID uint) ([]*models.SubmissionVersion, error)
+	GetSubmissionVersionsForAssignment(assignmentID uint) ([]*models.SubmissionVersion, error)
+	GetSubmissionFiles(submissionVersionID uint) ([]*models.SubmissionFile, error)
+	SaveCodeMetrics(submissionVersionID uint, metrics []models.CodeMetrics) error
+	GetCodeMetrics(submissionVersionID uint) ([]*models.CodeMetrics, error)
+	SaveLintFindings(submissionVersionID uint, findings []models.LintFinding) error
+	GetLintFindings(submissionVersionID uint) ([]*models.LintFinding, error)
+	GetLintFindingsForAssignment(assignmentID uint) ([]*models.LintFinding, error)
+
+	CreateResult(score float64, submissionID, submissionVersionID uint) (*models.Result, error)
 	GetAllResults(from int) ([]*models.Result, error)
 	GetResult(id string) (*models.Result, error)
+	GetResultsForSubmission(submissionID uint) ([]*models.Result, error)
+	GetResultsForAssignment(assignmentID uint) ([]*models.Result, error)
+	OverrideResult(resultID uint, score float64, reason, overriddenBy string) (*models.Result, error)
+	GetResultOverrides(resultID uint) ([]*models.ResultOverride, error)
+
+	CreateStudent(studentNumber, name, email string) (*models.Student, error)
+	GetAllStudents(from int) ([]*models.Student, error)
+	UpdateStudent(id uint, name, email string) (*models.Student, error)
+	GetStudent(id string) (*models.Student, error)
+	GetStudentByNumber(studentNumber string) (*models.Student, error)
+	GetStudentsForClass(classID uint) ([]*models.Student, error)
+	GetStudentsWithoutSubmission(assignmentID string) ([]*models.Student, error)
+	GetClassesForStudent(studentID uint) ([]*models.Class, error)
+	EnrolStudent(studentID, classID uint) error
+	UnenrolStudent(studentID, classID uint) error
+
+	CreateGroup(name string, classID uint) (*models.Group, error)
+	GetGroup(id string) (*models.Group, error)
+	GetGroupsForClass(classID uint) ([]*models.Group, error)
+	DeleteGroup(id uint) error
+	SetGroupMembers(groupID, classID uint, studentIDs []uint) error
+	GetGroupMembers(groupID uint) ([]*models.Student, error)
+	GetGroupForStudent(studentID, classID uint) (*models.Group, error)
+	AdjustMemberGrade(adjustment models.MemberAdjustment) (*models.MemberAdjustment, error)
+	GetMemberAdjustment(submissionID, studentID uint) (*models.MemberAdjustment, error)
+	GetMemberAdjustments(submissionID uint) ([]*models.MemberAdjustment, error)
+
+	SetRubric(assignmentID uint, criteria []models.RubricCriterion) ([]*models.RubricCriterion, error)
+	GetRubric(assignmentID uint) ([]*models.RubricCriterion, error)
+	HasRubricMarks(assignmentID uint) (bool, error)
+	SaveRubricMarks(marks []models.RubricMark) error
+	GetRubricMarks(submissionID uint) ([]*models.RubricMark, error)
+	GetRubricMarksForAssignment(assignmentID uint) ([]*models.RubricMark, error)
+
+	SetLintRules(assignmentID uint, rules []models.LintRule) ([]*models.LintRule, error)
+	GetLintRules(assignmentID uint) ([]*models.LintRule, error)
+
+	CreateCodeComment(comment models.CodeComment) (*models.CodeComment, error)
+	GetCodeComment(id string) (*models.CodeComment, error)
+	GetCodeCommentsForSubmission(submissionID uint) ([]*models.CodeComment, error)
+	UpdateCodeComment(id uint, body string, resolved bool) (*models.CodeComment, error)
+	DeleteCodeComment(id uint) error
+
+	AllocateSubmissions(allocations []models.MarkingAllocation) ([]*models.MarkingAllocation, error)
+	GetMarkingAllocation(submissionID uint) (*models.MarkingAllocation, error)
+	GetMarkingAllocationsForAssignment(assignmentID uint) ([]*models.MarkingAllocation, error)
+	GetMarkingAllocationsForMarker(marker string) ([]*models.MarkingAllocation, error)
+	UpdateMarkingStatus(submissionID uint, status models.MarkingStatus) (*models.MarkingAllocation, error)
+
+	SetModerationSample(assignmentID uint, threshold float64, samples []models.ModerationSample) ([]*models.ModerationSample, error)
+	GetModerationSamples(assignmentID uint) ([]*models.ModerationSample, error)
+	GetModerationSample(submissionID uint) (*models.ModerationSample, error)
+	EnterSecondMark(submissionID uint, firstMark, secondMark float64, status models.ModerationStatus) (*models.ModerationSample, error)
+	ResolveModeration(submissionID uint, agreedMark float64, resolvedBy, note string) (*models.ModerationSample, error)
+
+	GrantExtension(extension models.Extension) (*models.Extension, error)
+	RevokeExtension(studentID, assignmentID uint) error
+	GetExtension(id string) (*models.Extension, error)
+	GetExtensionForStudent(studentID, assignmentID uint) (*models.Extension, error)
+	GetExtensionsForAssignment(assignmentID uint) ([]*models.Extension, error)
+
+	CreateAuditEvent(event models.AuditEvent) (*models.AuditEvent, error)
+	GetAuditEvents(filter models.AuditEventFilter, from int) ([]*models.AuditEvent, error)
+
+	Transaction(fn func(tx Database) error) error
 }
 
 type database struct {
@@ -56,11 +174,40 @@ var (
 
 	allModels = []interface{}{
 		&models.Unit{},
+		&models.Term{},
+		&models.Offering{},
 		&models.Class{},
 		&models.Assignment{},
 		&models.Test{},
+		&models.TestOutcome{},
 		&models.Submission{},
+		&models.SubmissionVersion{},
+		&models.Result{},
+		&models.ResultOverride{},
 		&models.User{},
+		&models.Student{},
+		&models.StudentInvite{},
+		&models.Enrolment{},
+		&models.SubmissionFile{},
+		&models.StarterFile{},
+		&models.CodeMetrics{},
+		&models.LintRule{},
+		&models.LintFinding{},
+		&models.CodeComment{},
+		&models.MarkingAllocation{},
+		&models.ModerationSample{},
+		&models.Group{},
+		&models.GroupMember{},
+		&models.MemberAdjustment{},
+		&models.Extension{},
+		&models.RubricCriterion{},
+		&models.RubricLevel{},
+		&models.RubricMark{},
+	}
+
+	// persistentModels are migrated alongside allModels but survive ResetDB.
+	persistentModels = []interface{}{
+		&models.AuditEvent{},
 	}
 )
 
@@ -71,7 +218,7 @@ func NewDB(dbFilePath string) Database {
 	}
 
 	// Migrate the schema
-	for _, model := range allModels {
+	for _, model := range append(allModels, persistentModels...) {
 		err = db.AutoMigrate(model)
 		if err != nil {
 			panic("failed to migrate database")
@@ -102,6 +249,55 @@ func (db *database) CreateUser(email, passwordHash string, role models.UserRole)
 	return &user, nil
 }
 
+// CreateStudentUser creates an account for a student to sign in to the student portal,
+// using up their invite. Each student can only have one account.
+func (db *database) CreateStudentUser(email, passwordHash string, studentID uint) (*models.User, error) {
+	user := models.User{Email: email, PasswordHash: passwordHash, Role: models.UserRoleStudent, StudentID: &studentID}
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&user).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("student_id = ?", studentID).Delete(&models.StudentInvite{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// SetStudentInvite replaces any invite the student already has, so only the latest
+// code works.
+func (db *database) SetStudentInvite(studentID uint, codeHash string, expiresAt time.Time) (*models.StudentInvite, error) {
+	invite := models.StudentInvite{StudentID: studentID, CodeHash: codeHash, ExpiresAt: expiresAt}
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("student_id = ?", studentID).Delete(&models.StudentInvite{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&invite).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (db *database) GetStudentInvite(studentID uint) (*models.StudentInvite, error) {
+	var invite models.StudentInvite
+
+	tx := db.client.Where("student_id = ?", studentID).First(&invite)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &invite, nil
+}
+
 func (db *database) GetUserByEmail(email string) (*models.User, error) {
 	var user models.User
 
@@ -113,6 +309,50 @@ func (db *database) GetUserByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+func (db *database) UpdateLoginFailures(userID uint, failedAttempts int, lockedUntil time.Time) error {
+	tx := db.client.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"failed_login_attempts": failedAttempts,
+		"locked_until":          lockedUntil,
+	})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+// RecordLoginFailure adds one to the user's failed login attempts, returning the new
+// count. The count is incremented in the database so concurrent failures all count.
+func (db *database) RecordLoginFailure(userID uint) (int, error) {
+	var user models.User
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", userID).
+			UpdateColumn("failed_login_attempts", gorm.Expr("failed_login_attempts + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Select("failed_login_attempts").First(&user, userID).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return user.FailedLoginAttempts, nil
+}
+
+// LockUser stops the user signing in until the given time. An existing lock that ends
+// later is kept.
+func (db *database) LockUser(userID uint, lockedUntil time.Time) error {
+	tx := db.client.Model(&models.User{}).Where("id = ? AND locked_until < ?", userID, lockedUntil).
+		UpdateColumn("locked_until", lockedUntil)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
 func (db *database) CreateUnit(name string) (*models.Unit, error) {
 	unit := models.Unit{Name: name}
 	tx := db.client.Create(&unit)
@@ -162,8 +402,299 @@ func (db *database) GetUnitByName(name string) (*models.Unit, error) {
 	return &unit, nil
 }
 
-func (db *database) CreateClass(name string, unitID uint) (*models.Class, error) {
-	class := models.Class{Name: name, UnitID: unitID}
+// GetUnitsForTerm returns the units offered in the term.
+func (db *database) GetUnitsForTerm(termID uint, from int) ([]*models.Unit, error) {
+	var units []*models.Unit
+	tx := db.client.
+		Joins("JOIN offerings ON offerings.unit_id = units.id").
+		Where("offerings.term_id = ? AND units.id >= ? AND units.id < ?", termID, from, from+PAGE_SIZE).
+		Find(&units)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return units, nil
+}
+
+func (db *database) CreateTerm(name string, startDate, endDate time.Time) (*models.Term, error) {
+	term := models.Term{Name: name, StartDate: startDate, EndDate: endDate}
+	tx := db.client.Create(&term)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &term, nil
+}
+
+func (db *database) GetTerm(id string) (*models.Term, error) {
+	var term models.Term
+	tx := db.client.First(&term, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &term, nil
+}
+
+func (db *database) GetTermByName(name string) (*models.Term, error) {
+	var term models.Term
+	tx := db.client.Where("name = ?", name).First(&term)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &term, nil
+}
+
+func (db *database) GetTerms() ([]*models.Term, error) {
+	var terms []*models.Term
+	tx := db.client.Order("start_date").Find(&terms)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return terms, nil
+}
+
+// GetTermAt returns the term running at the given time, the latest to start if terms
+// overlap.
+func (db *database) GetTermAt(t time.Time) (*models.Term, error) {
+	var term models.Term
+	tx := db.client.Where("start_date <= ? AND end_date > ?", t, t).Order("start_date DESC").First(&term)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &term, nil
+}
+
+func (db *database) CreateOffering(unitID, termID uint) (*models.Offering, error) {
+	offering := models.Offering{UnitID: unitID, TermID: termID}
+	tx := db.client.Omit(clause.Associations).Create(&offering)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &offering, nil
+}
+
+func (db *database) GetOffering(id string) (*models.Offering, error) {
+	var offering models.Offering
+	tx := db.client.Preload("Unit").Preload("Term").First(&offering, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &offering, nil
+}
+
+func (db *database) GetOfferingForUnit(unitID, termID uint) (*models.Offering, error) {
+	var offering models.Offering
+	tx := db.client.Preload("Unit").Preload("Term").Where("unit_id = ? AND term_id = ?", unitID, termID).First(&offering)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &offering, nil
+}
+
+func (db *database) GetOfferingsForUnit(unitID uint) ([]*models.Offering, error) {
+	var offerings []*models.Offering
+	tx := db.client.
+		Preload("Unit").
+		Preload("Term").
+		Joins("JOIN terms ON terms.id = offerings.term_id").
+		Where("offerings.unit_id = ?", unitID).
+		Order("terms.start_date").
+		Find(&offerings)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return offerings, nil
+}
+
+func (db *database) GetOfferingsForTerm(termID uint) ([]*models.Offering, error) {
+	var offerings []*models.Offering
+	tx := db.client.
+		Preload("Unit").
+		Preload("Term").
+		Joins("JOIN units ON units.id = offerings.unit_id").
+		Where("offerings.term_id = ?", termID).
+		Order("units.name").
+		Find(&offerings)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return offerings, nil
+}
+
+// CloneOffering copies the offering's classes and their assignments into a new offering
+// of the unit in the term. Due dates, cutoffs and release dates move by the offset, and
+// each assignment keeps its tests, rubric, lint rules and starter code. Submissions,
+// enrolments and groups aren't copied, and feedback starts as a draft.
+func (db *database) CloneOffering(offeringID, termID uint, offset time.Duration) (*models.OfferingClone, error) {
+	var clone models.OfferingClone
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		var source models.Offering
+		err := tx.First(&source, offeringID).Error
+		if err != nil {
+			return err
+		}
+
+		offering := models.Offering{UnitID: source.UnitID, TermID: termID}
+		err = tx.Omit(clause.Associations).Create(&offering).Error
+		if err != nil {
+			return err
+		}
+		clone.Offering = &offering
+
+		var classes []models.Class
+		err = tx.Where("offering_id = ?", source.ID).Order("id").Find(&classes).Error
+		if err != nil {
+			return err
+		}
+
+		for _, class := range classes {
+			copied := models.Class{Name: class.Name, UnitID: class.UnitID, OfferingID: &offering.ID}
+			err = tx.Create(&copied).Error
+			if err != nil {
+				return err
+			}
+			clone.Classes++
+
+			var assignments []models.Assignment
+			err = tx.Where("class_id = ?", class.ID).Order("id").Find(&assignments).Error
+			if err != nil {
+				return err
+			}
+
+			for _, assignment := range assignments {
+				err = cloneAssignment(tx, assignment, copied.ID, offset, &clone)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &clone, nil
+}
+
+func cloneAssignment(tx *gorm.DB, assignment models.Assignment, classID uint, offset time.Duration, clone *models.OfferingClone) error {
+	latePolicy := assignment.LatePolicy
+	if latePolicy.Cutoff != nil {
+		cutoff := latePolicy.Cutoff.Add(offset)
+		latePolicy.Cutoff = &cutoff
+	}
+
+	var releaseAt *time.Time
+	if assignment.Feedback.ReleaseAt != nil {
+		at := assignment.Feedback.ReleaseAt.Add(offset)
+		releaseAt = &at
+	}
+
+	copied := models.Assignment{
+		Name:                assignment.Name,
+		DueDate:             assignment.DueDate.Add(offset),
+		AttemptPolicy:       assignment.AttemptPolicy,
+		LatePolicy:          latePolicy,
+		Feedback:            models.FeedbackRelease{Status: models.FeedbackStatusDraft, ReleaseAt: releaseAt},
+		ClassID:             classID,
+		ModerationThreshold: assignment.ModerationThreshold,
+		GroupWork:           assignment.GroupWork,
+	}
+	err := tx.Omit(clause.Associations).Create(&copied).Error
+	if err != nil {
+		return err
+	}
+	clone.Assignments++
+
+	var tests []models.Test
+	err = tx.Where("assignment_id = ?", assignment.ID).Order("id").Find(&tests).Error
+	if err != nil {
+		return err
+	}
+	for i := range tests {
+		// The copy runs the same source, which stays where it was uploaded.
+		tests[i].Source = tests[i].SourceKey()
+		tests[i].Model = gorm.Model{}
+		tests[i].AssignmentID = copied.ID
+	}
+	if len(tests) > 0 {
+		err = tx.Create(&tests).Error
+		if err != nil {
+			return err
+		}
+	}
+	clone.Tests += len(tests)
+
+	var criteria []models.RubricCriterion
+	err = tx.Preload("Levels").Where("assignment_id = ?", assignment.ID).Order("position").Find(&criteria).Error
+	if err != nil {
+		return err
+	}
+	for i := range criteria {
+		criteria[i].Model = gorm.Model{}
+		criteria[i].AssignmentID = copied.ID
+		for j := range criteria[i].Levels {
+			criteria[i].Levels[j].Model = gorm.Model{}
+			criteria[i].Levels[j].CriterionID = 0
+		}
+	}
+	if len(criteria) > 0 {
+		err = tx.Create(&criteria).Error
+		if err != nil {
+			return err
+		}
+	}
+	clone.RubricCriteria += len(criteria)
+
+	var rules []models.LintRule
+	err = tx.Where("assignment_id = ?", assignment.ID).Order("position").Find(&rules).Error
+	if err != nil {
+		return err
+	}
+	for i := range rules {
+		rules[i].Model = gorm.Model{}
+		rules[i].AssignmentID = copied.ID
+	}
+	if len(rules) > 0 {
+		err = tx.Create(&rules).Error
+		if err != nil {
+			return err
+		}
+	}
+	clone.LintRules += len(rules)
+
+	var files []models.StarterFile
+	err = tx.Where("assignment_id = ?", assignment.ID).Order("path").Find(&files).Error
+	if err != nil {
+		return err
+	}
+	for i := range files {
+		files[i].Model = gorm.Model{}
+		files[i].AssignmentID = copied.ID
+	}
+	if len(files) > 0 {
+		err = tx.Create(&files).Error
+		if err != nil {
+			return err
+		}
+	}
+	clone.StarterFiles += len(files)
+
+	return nil
+}
+
+func (db *database) CreateClass(name string, unitID uint, offeringID *uint) (*models.Class, error) {
+	class := models.Class{Name: name, UnitID: unitID, OfferingID: offeringID}
 	tx := db.client.Create(&class)
 	if tx.Error != nil {
 		return nil, tx.Error
@@ -192,6 +723,30 @@ func (db *database) GetClass(id string) (*models.Class, error) {
 	return &class, nil
 }
 
+func (db *database) GetClassesForOffering(offeringID uint) ([]*models.Class, error) {
+	var classes []*models.Class
+	tx := db.client.Where("offering_id = ?", offeringID).Find(&classes)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return classes, nil
+}
+
+// GetClassesForTerm returns the classes of the units offered in the term.
+func (db *database) GetClassesForTerm(termID uint, from int) ([]*models.Class, error) {
+	var classes []*models.Class
+	tx := db.client.
+		Joins("JOIN offerings ON offerings.id = classes.offering_id").
+		Where("offerings.term_id = ? AND classes.id >= ? AND classes.id < ?", termID, from, from+PAGE_SIZE).
+		Find(&classes)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return classes, nil
+}
+
 func (db *database) CreateAssignment(name string, dueDate int, classID uint) (*models.Assignment, error) {
 	assignment := models.Assignment{Name: name, DueDate: time.Unix(int64(dueDate), 0), ClassID: classID}
 	tx := db.client.Create(&assignment)
@@ -212,6 +767,22 @@ func (db *database) GetAllAssignments(from int) ([]*models.Assignment, error) {
 	return assignments, nil
 }
 
+// GetAssignmentsForTerm returns the assignments for the classes of the units offered in
+// the term.
+func (db *database) GetAssignmentsForTerm(termID uint, from int) ([]*models.Assignment, error) {
+	var assignments []*models.Assignment
+	tx := db.client.
+		Joins("JOIN classes ON classes.id = assignments.class_id").
+		Joins("JOIN offerings ON offerings.id = classes.offering_id").
+		Where("offerings.term_id = ? AND assignments.id >= ? AND assignments.id < ?", termID, from, from+PAGE_SIZE).
+		Find(&assignments)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return assignments, nil
+}
+
 func (db *database) GetAssignment(id string) (*models.Assignment, error) {
 	var assignment models.Assignment
 	tx := db.client.First(&assignment, id)
@@ -232,112 +803,1531 @@ func (db *database) GetAssignmentsForClass(classID uint) ([]*models.Assignment,
 	return assignments, nil
 }
 
-func (db *database) CreateTest(name string, assignmentID uint) (*models.Test, error) {
-	test := models.Test{Name: name, AssignmentID: assignmentID}
-	tx := db.client.Create(&test)
+func (db *database) UpdateAttemptPolicy(assignmentID uint, policy models.AttemptPolicy) (*models.Assignment, error) {
+	var assignment models.Assignment
+	tx := db.client.First(&assignment, assignmentID)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return &test, nil
-}
-
-func (db *database) GetAllTests(from int) ([]*models.Test, error) {
-	var tests []*models.Test
-	tx := db.client.Where("id >= ? AND id < ?", from, from+PAGE_SIZE).Find(&tests)
+	assignment.AttemptPolicy = policy
+	tx = db.client.Save(&assignment)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return tests, nil
+	return &assignment, nil
 }
 
-func (db *database) GetTest(id string) (*models.Test, error) {
-	var test models.Test
-	tx := db.client.First(&test, id)
+func (db *database) UpdateGroupWork(assignmentID uint, groupWork bool) (*models.Assignment, error) {
+	var assignment models.Assignment
+	tx := db.client.First(&assignment, assignmentID)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return &test, nil
-}
-
-func (db *database) GetTestsForAssignment(assignmentID string) ([]*models.Test, error) {
-	var tests []*models.Test
-	tx := db.client.Where("assignment_id = ?", assignmentID).Find(&tests)
+	assignment.GroupWork = groupWork
+	tx = db.client.Save(&assignment)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return tests, nil
+	return &assignment, nil
 }
 
-func (db *database) CreateSubmission(studentID string, assignmentID uint) (*models.Submission, error) {
-	submission := models.Submission{StudentID: studentID, AssignmentID: assignmentID}
-	tx := db.client.Create(&submission)
+func (db *database) UpdateLatePolicy(assignmentID uint, policy models.LatePolicy) (*models.Assignment, error) {
+	var assignment models.Assignment
+	tx := db.client.First(&assignment, assignmentID)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return &submission, nil
-}
-
-func (db *database) GetAllSubmissions(from int) ([]*models.Submission, error) {
-	var submissions []*models.Submission
-	tx := db.client.Where("id >= ? AND id < ?", from, from+PAGE_SIZE).Find(&submissions)
+	assignment.LatePolicy = policy
+	tx = db.client.Save(&assignment)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return submissions, nil
+	return &assignment, nil
 }
 
-func (db *database) GetSubmission(id string) (*models.Submission, error) {
-	var submission models.Submission
-	tx := db.client.First(&submission, id)
+func (db *database) UpdateFeedbackRelease(assignmentID uint, release models.FeedbackRelease) (*models.Assignment, error) {
+	var assignment models.Assignment
+	tx := db.client.First(&assignment, assignmentID)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return &submission, nil
-}
-
-func (db *database) GetSubmissionsForAssignment(assignmentID string) ([]*models.Submission, error) {
-	var submissions []*models.Submission
-	tx := db.client.Where("assignment_id = ?", assignmentID).Find(&submissions)
+	assignment.Feedback = release
+	tx = db.client.Save(&assignment)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return submissions, nil
+	return &assignment, nil
 }
 
-func (db *database) CreateResult(score float64, submissionID uint) (*models.Result, error) {
-	result := models.Result{Score: score, SubmissionID: submissionID}
-	tx := db.client.Create(&result)
-	if tx.Error != nil {
-		return nil, tx.Error
-	}
+// countedVersionsSQL selects the counted version of each of an assignment's
+// submissions, with the score of its latest result, as the "counted" table. The
+// ordering of each submission's versions for the attempt policy is substituted in,
+// matching grading.CountedVersion.
+const countedVersionsSQL = `
+WITH latest_results AS (
+	SELECT submission_version_id, score,
+		ROW_NUMBER() OVER (PARTITION BY submission_version_id ORDER BY created_at DESC, id DESC) AS position
+	FROM results
+	WHERE deleted_at IS NULL AND submission_version_id IS NOT NULL
+), ranked_versions AS (
+	SELECT submission_versions.id AS version_id, submission_versions.submission_id, latest_results.score,
+		ROW_NUMBER() OVER (PARTITION BY submission_versions.submission_id ORDER BY %s) AS position
+	FROM submission_versions
+	JOIN submissions ON submissions.id = submission_versions.submission_id
+	LEFT JOIN latest_results ON latest_results.submission_version_id = submission_versions.id AND latest_results.position = 1
+	WHERE submissions.assignment_id = @assignment AND submissions.deleted_at IS NULL AND submission_versions.deleted_at IS NULL
+), counted AS (
+	SELECT version_id, submission_id, score FROM ranked_versions WHERE position = 1
+)
+`
 
-	return &result, nil
+var countedVersionOrders = map[models.AttemptPolicy]string{
+	models.AttemptPolicyLatest: "number DESC",
+	models.AttemptPolicyFirst:  "number ASC",
+	// The best scoring version, earliest first, or the latest version if none has a result.
+	models.AttemptPolicyBest: "score IS NULL, score DESC, CASE WHEN score IS NULL THEN -number ELSE number END",
 }
 
-func (db *database) GetAllResults(from int) ([]*models.Result, error) {
-	var results []*models.Result
-	tx := db.client.Where("id >= ? AND id < ?", from, from+PAGE_SIZE).Find(&results)
-	if tx.Error != nil {
-		return nil, tx.Error
+// GetAssignmentStatistics counts the assignment's submissions and summarises each
+// test's outcomes across the counted versions. Score aggregates depend on the whole
+// grade, so are left for grading.LoadStatistics.
+func (db *database) GetAssignmentStatistics(assignmentID uint) (*models.AssignmentStatistics, error) {
+	var assignment models.Assignment
+	err := db.client.First(&assignment, assignmentID).Error
+	if err != nil {
+		return nil, err
 	}
 
-	return results, nil
-}
+	order, ok := countedVersionOrders[assignment.AttemptPolicy]
+	if !ok {
+		order = countedVersionOrders[models.AttemptPolicyLatest]
+	}
+	counted := fmt.Sprintf(countedVersionsSQL, order)
+	params := map[string]interface{}{"assignment": assignmentID}
 
-func (db *database) GetResult(id string) (*models.Result, error) {
+	stats := &models.AssignmentStatistics{}
+
+	var submissions, missing int64
+	err = db.client.Model(&models.Submission{}).Where("assignment_id = ?", assignmentID).Count(&submissions).Error
+	if err != nil {
+		return nil, err
+	}
+	err = db.studentsWithoutSubmission(assignmentID).Count(&missing).Error
+	if err != nil {
+		return nil, err
+	}
+	stats.Submissions, stats.Missing = int(submissions), int(missing)
+
+	var tests []*models.Test
+	err = db.client.Where("assignment_id = ?", assignmentID).Order("id").Find(&tests).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var outcomes []struct {
+		TestID     uint
+		Attempts   int
+		Passed     int
+		MeanPoints *float64
+	}
+	err = db.client.Raw(counted+`, latest_outcomes AS (
+			SELECT test_outcomes.test_id, test_outcomes.points,
+				ROW_NUMBER() OVER (PARTITION BY test_outcomes.submission_version_id, test_outcomes.test_id ORDER BY test_outcomes.created_at DESC, test_outcomes.id DESC) AS position
+			FROM test_outcomes
+			JOIN counted ON counted.version_id = test_outcomes.submission_version_id
+			WHERE test_outcomes.deleted_at IS NULL
+		)
+		SELECT tests.id AS test_id, COUNT(latest_outcomes.test_id) AS attempts,
+			COUNT(CASE WHEN latest_outcomes.points >= tests.max_points THEN 1 END) AS passed,
+			AVG(latest_outcomes.points) AS mean_points
+		FROM tests
+		JOIN latest_outcomes ON latest_outcomes.test_id = tests.id AND latest_outcomes.position = 1
+		WHERE tests.assignment_id = @assignment AND tests.deleted_at IS NULL
+		GROUP BY tests.id`, params).Scan(&outcomes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byTest := map[uint]models.TestStatistics{}
+	for _, outcome := range outcomes {
+		byTest[outcome.TestID] = models.TestStatistics{Attempts: outcome.Attempts, Passed: outcome.Passed, MeanPoints: outcome.MeanPoints}
+	}
+	for _, test := range tests {
+		testStats := byTest[test.ID]
+		testStats.Test = test
+		stats.Tests = append(stats.Tests, testStats)
+	}
+
+	return stats, nil
+}
+
+// SetStarterFiles replaces the assignment's starter code with the files.
+func (db *database) SetStarterFiles(assignmentID uint, files []models.StarterFile) ([]*models.StarterFile, error) {
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("assignment_id = ?", assignmentID).Delete(&models.StarterFile{}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range files {
+			files[i].AssignmentID = assignmentID
+		}
+
+		if len(files) == 0 {
+			return nil
+		}
+
+		return tx.Create(&files).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetStarterFiles(assignmentID)
+}
+
+func (db *database) GetStarterFiles(assignmentID uint) ([]*models.StarterFile, error) {
+	var files []*models.StarterFile
+	tx := db.client.Where("assignment_id = ?", assignmentID).Order("path").Find(&files)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return files, nil
+}
+
+func (db *database) CreateTest(name string, assignmentID uint, maxPoints, weight float64) (*models.Test, error) {
+	test := models.Test{Name: name, MaxPoints: maxPoints, Weight: weight, AssignmentID: assignmentID}
+	tx := db.client.Create(&test)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &test, nil
+}
+
+func (db *database) GetAllTests(from int) ([]*models.Test, error) {
+	var tests []*models.Test
+	tx := db.client.Where("id >= ? AND id < ?", from, from+PAGE_SIZE).Find(&tests)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return tests, nil
+}
+
+func (db *database) GetTest(id string) (*models.Test, error) {
+	var test models.Test
+	tx := db.client.First(&test, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &test, nil
+}
+
+func (db *database) GetTestsForAssignment(assignmentID string) ([]*models.Test, error) {
+	var tests []*models.Test
+	tx := db.client.Where("assignment_id = ?", assignmentID).Find(&tests)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return tests, nil
+}
+
+func (db *database) UpdateTestScoring(testID uint, maxPoints, weight float64) (*models.Test, error) {
+	var test models.Test
+	tx := db.client.First(&test, testID)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	test.MaxPoints = maxPoints
+	test.Weight = weight
+	tx = db.client.Save(&test)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &test, nil
+}
+
+func (db *database) CreateTestOutcomes(outcomes []models.TestOutcome) error {
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	tx := db.client.Create(&outcomes)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (db *database) GetTestOutcomesForVersion(submissionVersionID uint) ([]*models.TestOutcome, error) {
+	var outcomes []*models.TestOutcome
+	tx := db.client.Where("submission_version_id = ?", submissionVersionID).Find(&outcomes)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return outcomes, nil
+}
+
+func (db *database) GetTestOutcomesForAssignment(assignmentID uint) ([]*models.TestOutcome, error) {
+	var outcomes []*models.TestOutcome
+	tx := db.client.Where("submission_version_id IN (?)", db.assignmentVersionIDs(assignmentID)).Find(&outcomes)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return outcomes, nil
+}
+
+// CreateSubmission creates a submission along with its first version holding the
+// files, so a submission is never left without a version if storing the files fails.
+func (db *database) CreateSubmission(studentID string, assignmentID uint, studentRecordID *uint, files []models.SubmissionFile) (*models.Submission, *models.SubmissionVersion, error) {
+	submission := models.Submission{StudentID: studentID, AssignmentID: assignmentID, StudentRecordID: studentRecordID}
+	var version *models.SubmissionVersion
+
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&submission).Error
+		if err != nil {
+			return err
+		}
+
+		version, err = createSubmissionVersion(tx, submission.ID, files)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &submission, version, nil
+}
+
+func (db *database) GetAllSubmissions(from int) ([]*models.Submission, error) {
+	var submissions []*models.Submission
+	tx := db.client.Where("id >= ? AND id < ?", from, from+PAGE_SIZE).Find(&submissions)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return submissions, nil
+}
+
+func (db *database) GetSubmission(id string) (*models.Submission, error) {
+	var submission models.Submission
+	tx := db.client.First(&submission, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &submission, nil
+}
+
+func (db *database) GetSubmissionsForAssignment(assignmentID string) ([]*models.Submission, error) {
+	var submissions []*models.Submission
+	tx := db.client.Where("assignment_id = ?", assignmentID).Find(&submissions)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return submissions, nil
+}
+
+// GetSubmissionsForStudent returns the student's own submissions along with those of
+// the groups they're in.
+func (db *database) GetSubmissionsForStudent(studentID uint) ([]*models.Submission, error) {
+	var submissions []*models.Submission
+	tx := db.client.
+		Where("student_record_id = ?", studentID).
+		Or("group_id IN (?)", db.client.Model(&models.GroupMember{}).Select("group_id").Where("student_id = ?", studentID)).
+		Find(&submissions)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return submissions, nil
+}
+
+func (db *database) GetSubmissionForStudent(assignmentID uint, studentID string, studentRecordID *uint) (*models.Submission, error) {
+	return submissionForStudent(db.client, assignmentID, studentID, studentRecordID)
+}
+
+// submissionForStudent finds the student's submission for the assignment, by their
+// student record if they have one, or else by the identifier they submitted as.
+func submissionForStudent(tx *gorm.DB, assignmentID uint, studentID string, studentRecordID *uint) (*models.Submission, error) {
+	var submission models.Submission
+
+	tx = tx.Where("assignment_id = ?", assignmentID)
+	if studentRecordID != nil {
+		tx = tx.Where("student_record_id = ?", *studentRecordID)
+	} else {
+		tx = tx.Where("student_id = ?", studentID)
+	}
+
+	tx = tx.First(&submission)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &submission, nil
+}
+
+// SubmitVersion adds a version holding the files to the student's submission for the
+// assignment, creating the submission if they haven't submitted before. The lookup and
+// the insert share a transaction, so concurrent submissions by the same student can't
+// create two submissions. The returned flag is true if the submission was created.
+func (db *database) SubmitVersion(assignmentID uint, studentID string, studentRecordID *uint, files []models.SubmissionFile) (*models.Submission, *models.SubmissionVersion, bool, error) {
+	var submission *models.Submission
+	var version *models.SubmissionVersion
+	created := false
+
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		var err error
+		submission, err = submissionForStudent(tx, assignmentID, studentID, studentRecordID)
+		if errors.Is(err, ErrRecordNotFound) {
+			submission = &models.Submission{StudentID: studentID, AssignmentID: assignmentID, StudentRecordID: studentRecordID}
+			err = tx.Create(submission).Error
+			created = true
+		}
+		if err != nil {
+			return err
+		}
+
+		version, err = createSubmissionVersion(tx, submission.ID, files)
+		return err
+	})
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return submission, version, created, nil
+}
+
+func (db *database) CreateSubmissionVersion(submissionID uint, files []models.SubmissionFile) (*models.SubmissionVersion, error) {
+	var version *models.SubmissionVersion
+
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		var err error
+		version, err = createSubmissionVersion(tx, submissionID, files)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// createSubmissionVersion numbers a new version after the submission's latest and
+// stores its files, within the caller's transaction.
+func createSubmissionVersion(tx *gorm.DB, submissionID uint, files []models.SubmissionFile) (*models.SubmissionVersion, error) {
+	version := models.SubmissionVersion{SubmissionID: submissionID}
+
+	var latest int
+	err := tx.Model(&models.SubmissionVersion{}).
+		Where("submission_id = ?", submissionID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		return nil, err
+	}
+
+	version.Number = latest + 1
+	err = tx.Create(&version).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) > 0 {
+		for i := range files {
+			files[i].SubmissionVersionID = version.ID
+		}
+
+		err = tx.Create(&files).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	version.Files = files
+
+	return &version, nil
+}
+
+func (db *database) GetSubmissionVersion(id string) (*models.SubmissionVersion, error) {
+	var version models.SubmissionVersion
+	tx := db.client.First(&version, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &version, nil
+}
+
+func (db *database) GetSubmissionVersions(submissionID uint) ([]*models.SubmissionVersion, error) {
+	var versions []*models.SubmissionVersion
+	tx := db.client.Where("submission_id = ?", submissionID).Order("number").Find(&versions)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return versions, nil
+}
+
+func (db *database) GetSubmissionVersionsForAssignment(assignmentID uint) ([]*models.SubmissionVersion, error) {
+	var versions []*models.SubmissionVersion
+	tx := db.client.Where("submission_id IN (?)", db.assignmentSubmissionIDs(assignmentID)).Order("submission_id, number").Find(&versions)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return versions, nil
+}
+
+// assignmentSubmissionIDs returns a query for the IDs of the assignment's submissions.
+func (db *database) assignmentSubmissionIDs(assignmentID uint) *gorm.DB {
+	return db.client.Model(&models.Submission{}).Select("id").Where("assignment_id = ?", assignmentID)
+}
+
+// assignmentVersionIDs returns a query for the IDs of the versions of the assignment's
+// submissions.
+func (db *database) assignmentVersionIDs(assignmentID uint) *gorm.DB {
+	return db.client.Model(&models.SubmissionVersion{}).Select("id").Where("submission_id IN (?)", db.assignmentSubmissionIDs(assignmentID))
+}
+
+func (db *database) GetSubmissionFiles(submissionVersionID uint) ([]*models.SubmissionFile, error) {
+	var files []*models.SubmissionFile
+	tx := db.client.Where("submission_version_id = ?", submissionVersionID).Order("path").Find(&files)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return files, nil
+}
+
+// SaveCodeMetrics replaces the code metrics of a submission version.
+func (db *database) SaveCodeMetrics(submissionVersionID uint, metrics []models.CodeMetrics) error {
+	return db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("submission_version_id = ?", submissionVersionID).Delete(&models.CodeMetrics{}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range metrics {
+			metrics[i].SubmissionVersionID = submissionVersionID
+		}
+
+		if len(metrics) == 0 {
+			return nil
+		}
+
+		return tx.Create(&metrics).Error
+	})
+}
+
+func (db *database) GetCodeMetrics(submissionVersionID uint) ([]*models.CodeMetrics, error) {
+	var metrics []*models.CodeMetrics
+	tx := db.client.Where("submission_version_id = ?", submissionVersionID).Order("path").Find(&metrics)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return metrics, nil
+}
+
+// SaveLintFindings replaces the lint findings of the submission version.
+func (db *database) SaveLintFindings(submissionVersionID uint, findings []models.LintFinding) error {
+	return db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("submission_version_id = ?", submissionVersionID).Delete(&models.LintFinding{}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range findings {
+			findings[i].SubmissionVersionID = submissionVersionID
+		}
+
+		if len(findings) == 0 {
+			return nil
+		}
+
+		return tx.Create(&findings).Error
+	})
+}
+
+func (db *database) GetLintFindings(submissionVersionID uint) ([]*models.LintFinding, error) {
+	var findings []*models.LintFinding
+	tx := db.client.Where("submission_version_id = ?", submissionVersionID).Order("path, line, id").Find(&findings)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return findings, nil
+}
+
+func (db *database) GetLintFindingsForAssignment(assignmentID uint) ([]*models.LintFinding, error) {
+	var findings []*models.LintFinding
+	tx := db.client.Where("submission_version_id IN (?)", db.assignmentVersionIDs(assignmentID)).Order("submission_version_id, path, line, id").Find(&findings)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return findings, nil
+}
+
+// CreateResult records a new score for a submission version. A tutor's override of the
+// version's previous result carries forward, so re-running the tests doesn't undo it:
+// the new result keeps the overridden score, with the new score as its original, and
+// a copy of the override history.
+func (db *database) CreateResult(score float64, submissionID, submissionVersionID uint) (*models.Result, error) {
+	result := models.Result{Score: score, SubmissionID: submissionID, SubmissionVersionID: &submissionVersionID}
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		var previous models.Result
+		err := tx.Where("submission_version_id = ?", submissionVersionID).Order("id desc").First(&previous).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		overridden := err == nil && previous.OriginalScore != nil
+		if overridden {
+			result.OriginalScore = &score
+			result.Score = previous.Score
+		}
+
+		err = tx.Create(&result).Error
+		if err != nil || !overridden {
+			return err
+		}
+
+		var overrides []models.ResultOverride
+		err = tx.Where("result_id = ?", previous.ID).Order("created_at, id").Find(&overrides).Error
+		if err != nil || len(overrides) == 0 {
+			return err
+		}
+		for i := range overrides {
+			overrides[i].Model = gorm.Model{CreatedAt: overrides[i].CreatedAt}
+			overrides[i].ResultID = result.ID
+		}
+
+		return tx.Create(&overrides).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (db *database) GetAllResults(from int) ([]*models.Result, error) {
+	var results []*models.Result
+	tx := db.client.Where("id >= ? AND id < ?", from, from+PAGE_SIZE).Find(&results)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return results, nil
+}
+
+func (db *database) GetResult(id string) (*models.Result, error) {
 	var result models.Result
 	tx := db.client.First(&result, id)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
-	return &result, nil
+	return &result, nil
+}
+
+func (db *database) GetResultsForSubmission(submissionID uint) ([]*models.Result, error) {
+	var results []*models.Result
+	tx := db.client.Where("submission_id = ?", submissionID).Find(&results)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return results, nil
+}
+
+func (db *database) GetResultsForAssignment(assignmentID uint) ([]*models.Result, error) {
+	var results []*models.Result
+	tx := db.client.Where("submission_id IN (?)", db.assignmentSubmissionIDs(assignmentID)).Find(&results)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return results, nil
+}
+
+// OverrideResult replaces a result's score, keeping the original score and recording
+// the change in the result's override history.
+func (db *database) OverrideResult(resultID uint, score float64, reason, overriddenBy string) (*models.Result, error) {
+	var result models.Result
+
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.First(&result, resultID).Error
+		if err != nil {
+			return err
+		}
+
+		override := models.ResultOverride{
+			PreviousScore: result.Score,
+			Score:         score,
+			Reason:        reason,
+			OverriddenBy:  overriddenBy,
+			ResultID:      result.ID,
+		}
+		err = tx.Create(&override).Error
+		if err != nil {
+			return err
+		}
+
+		if result.OriginalScore == nil {
+			original := result.Score
+			result.OriginalScore = &original
+		}
+		result.Score = score
+
+		return tx.Save(&result).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (db *database) GetResultOverrides(resultID uint) ([]*models.ResultOverride, error) {
+	var overrides []*models.ResultOverride
+	tx := db.client.Where("result_id = ?", resultID).Order("created_at, id").Find(&overrides)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return overrides, nil
+}
+
+func (db *database) CreateStudent(studentNumber, name, email string) (*models.Student, error) {
+	student := models.Student{StudentNumber: studentNumber, Name: name, Email: email}
+	tx := db.client.Create(&student)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &student, nil
+}
+
+func (db *database) GetAllStudents(from int) ([]*models.Student, error) {
+	var students []*models.Student
+	tx := db.client.Where("id >= ? AND id < ?", from, from+PAGE_SIZE).Find(&students)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return students, nil
+}
+
+func (db *database) UpdateStudent(id uint, name, email string) (*models.Student, error) {
+	var student models.Student
+	tx := db.client.First(&student, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	student.Name = name
+	student.Email = email
+	tx = db.client.Save(&student)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &student, nil
+}
+
+func (db *database) GetStudent(id string) (*models.Student, error) {
+	var student models.Student
+	tx := db.client.First(&student, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &student, nil
+}
+
+func (db *database) GetStudentByNumber(studentNumber string) (*models.Student, error) {
+	var student models.Student
+	tx := db.client.Where("student_number = ?", studentNumber).First(&student)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &student, nil
+}
+
+func (db *database) GetStudentsForClass(classID uint) ([]*models.Student, error) {
+	var students []*models.Student
+	tx := db.client.
+		Joins("JOIN enrolments ON enrolments.student_id = students.id").
+		Where("enrolments.class_id = ?", classID).
+		Order("students.student_number").
+		Find(&students)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return students, nil
+}
+
+func (db *database) GetStudentsWithoutSubmission(assignmentID string) ([]*models.Student, error) {
+	var students []*models.Student
+	tx := db.studentsWithoutSubmission(assignmentID).
+		Order("students.student_number").
+		Find(&students)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return students, nil
+}
+
+// studentsWithoutSubmission returns a query for the students enrolled in the
+// assignment's class who haven't submitted it, either themselves or as part of a
+// group.
+func (db *database) studentsWithoutSubmission(assignmentID interface{}) *gorm.DB {
+	return db.client.Model(&models.Student{}).
+		Joins("JOIN enrolments ON enrolments.student_id = students.id").
+		Joins("JOIN assignments ON assignments.class_id = enrolments.class_id").
+		Where("assignments.id = ?", assignmentID).
+		Where("students.id NOT IN (?)", db.client.Model(&models.Submission{}).
+			Select("student_record_id").
+			Where("assignment_id = ? AND student_record_id IS NOT NULL", assignmentID)).
+		Where("students.id NOT IN (?)", db.client.Model(&models.GroupMember{}).
+			Select("student_id").
+			Where("group_id IN (?)", db.client.Model(&models.Submission{}).
+				Select("group_id").
+				Where("assignment_id = ? AND group_id IS NOT NULL", assignmentID)))
+}
+
+func (db *database) GetSubmissionForGroup(assignmentID, groupID uint) (*models.Submission, error) {
+	var submission models.Submission
+	tx := db.client.Where("assignment_id = ? AND group_id = ?", assignmentID, groupID).First(&submission)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &submission, nil
+}
+
+func (db *database) SetSubmissionGroup(submissionID uint, groupID *uint) (*models.Submission, error) {
+	var submission models.Submission
+	tx := db.client.First(&submission, submissionID)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	submission.GroupID = groupID
+	tx = db.client.Save(&submission)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &submission, nil
+}
+
+func (db *database) GetClassesForStudent(studentID uint) ([]*models.Class, error) {
+	var classes []*models.Class
+	tx := db.client.
+		Joins("JOIN enrolments ON enrolments.class_id = classes.id").
+		Where("enrolments.student_id = ?", studentID).
+		Find(&classes)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return classes, nil
+}
+
+func (db *database) EnrolStudent(studentID, classID uint) error {
+	enrolment := models.Enrolment{StudentID: studentID, ClassID: classID}
+	tx := db.client.Clauses(clause.OnConflict{DoNothing: true}).Create(&enrolment)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (db *database) UnenrolStudent(studentID, classID uint) error {
+	tx := db.client.Where("student_id = ? AND class_id = ?", studentID, classID).Delete(&models.Enrolment{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return nil
+}
+
+func (db *database) CreateGroup(name string, classID uint) (*models.Group, error) {
+	group := models.Group{Name: name, ClassID: classID}
+	tx := db.client.Create(&group)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &group, nil
+}
+
+func (db *database) GetGroup(id string) (*models.Group, error) {
+	var group models.Group
+	tx := db.client.First(&group, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &group, nil
+}
+
+func (db *database) GetGroupsForClass(classID uint) ([]*models.Group, error) {
+	var groups []*models.Group
+	tx := db.client.Where("class_id = ?", classID).Order("name").Find(&groups)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return groups, nil
+}
+
+// DeleteGroup deletes the group and its members permanently, so the name can be used
+// again and the members can join other groups.
+func (db *database) DeleteGroup(id uint) error {
+	return db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("group_id = ?", id).Delete(&models.GroupMember{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&models.Group{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+// SetGroupMembers replaces the members of the group, which belongs to the class.
+func (db *database) SetGroupMembers(groupID, classID uint, studentIDs []uint) error {
+	return db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("group_id = ?", groupID).Delete(&models.GroupMember{}).Error
+		if err != nil {
+			return err
+		}
+
+		for _, studentID := range studentIDs {
+			member := models.GroupMember{GroupID: groupID, StudentID: studentID, ClassID: classID}
+			if err := tx.Create(&member).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (db *database) GetGroupMembers(groupID uint) ([]*models.Student, error) {
+	var students []*models.Student
+	tx := db.client.
+		Joins("JOIN group_members ON group_members.student_id = students.id").
+		Where("group_members.group_id = ?", groupID).
+		Order("students.student_number").
+		Find(&students)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return students, nil
+}
+
+func (db *database) GetGroupForStudent(studentID, classID uint) (*models.Group, error) {
+	var group models.Group
+	tx := db.client.
+		Joins("JOIN group_members ON group_members.group_id = groups.id").
+		Where("group_members.student_id = ? AND group_members.class_id = ?", studentID, classID).
+		First(&group)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &group, nil
+}
+
+// AdjustMemberGrade creates the member's adjustment for the submission, or replaces it
+// if they already have one.
+func (db *database) AdjustMemberGrade(adjustment models.MemberAdjustment) (*models.MemberAdjustment, error) {
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		var existing models.MemberAdjustment
+		err := tx.Where("submission_id = ? AND student_id = ?", adjustment.SubmissionID, adjustment.StudentID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err == nil {
+			adjustment.Model = existing.Model
+		}
+
+		return tx.Save(&adjustment).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &adjustment, nil
+}
+
+func (db *database) GetMemberAdjustment(submissionID, studentID uint) (*models.MemberAdjustment, error) {
+	var adjustment models.MemberAdjustment
+	tx := db.client.Where("submission_id = ? AND student_id = ?", submissionID, studentID).First(&adjustment)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &adjustment, nil
+}
+
+func (db *database) GetMemberAdjustments(submissionID uint) ([]*models.MemberAdjustment, error) {
+	var adjustments []*models.MemberAdjustment
+	tx := db.client.Where("submission_id = ?", submissionID).Find(&adjustments)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return adjustments, nil
+}
+
+// SetRubric replaces the assignment's rubric with the criteria, which are created along
+// with their levels in the order given.
+func (db *database) SetRubric(assignmentID uint, criteria []models.RubricCriterion) ([]*models.RubricCriterion, error) {
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		var existing []uint
+		err := tx.Model(&models.RubricCriterion{}).Where("assignment_id = ?", assignmentID).Pluck("id", &existing).Error
+		if err != nil {
+			return err
+		}
+
+		if len(existing) > 0 {
+			err = tx.Unscoped().Where("criterion_id IN ?", existing).Delete(&models.RubricLevel{}).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Unscoped().Where("id IN ?", existing).Delete(&models.RubricCriterion{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := range criteria {
+			criteria[i].AssignmentID = assignmentID
+			criteria[i].Position = i
+		}
+
+		if len(criteria) == 0 {
+			return nil
+		}
+
+		return tx.Create(&criteria).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetRubric(assignmentID)
+}
+
+// SetLintRules replaces the assignment's lint rules, in the order given. Findings of
+// the replaced rules are removed along with them.
+func (db *database) SetLintRules(assignmentID uint, rules []models.LintRule) ([]*models.LintRule, error) {
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		var existing []uint
+		err := tx.Model(&models.LintRule{}).Where("assignment_id = ?", assignmentID).Pluck("id", &existing).Error
+		if err != nil {
+			return err
+		}
+
+		if len(existing) > 0 {
+			err = tx.Unscoped().Where("rule_id IN ?", existing).Delete(&models.LintFinding{}).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Unscoped().Where("id IN ?", existing).Delete(&models.LintRule{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := range rules {
+			rules[i].AssignmentID = assignmentID
+			rules[i].Position = i
+		}
+
+		if len(rules) == 0 {
+			return nil
+		}
+
+		return tx.Create(&rules).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetLintRules(assignmentID)
+}
+
+func (db *database) GetLintRules(assignmentID uint) ([]*models.LintRule, error) {
+	var rules []*models.LintRule
+	tx := db.client.Where("assignment_id = ?", assignmentID).Order("position").Find(&rules)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return rules, nil
+}
+
+func (db *database) GetRubric(assignmentID uint) ([]*models.RubricCriterion, error) {
+	var criteria []*models.RubricCriterion
+	tx := db.client.Preload("Levels", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("points DESC")
+	}).Where("assignment_id = ?", assignmentID).Order("position").Find(&criteria)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return criteria, nil
+}
+
+func (db *database) HasRubricMarks(assignmentID uint) (bool, error) {
+	var count int64
+	criteria := db.client.Model(&models.RubricCriterion{}).Select("id").Where("assignment_id = ?", assignmentID)
+	tx := db.client.Model(&models.RubricMark{}).Where("criterion_id IN (?)", criteria).Count(&count)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	return count > 0, nil
+}
+
+// SaveRubricMarks creates or replaces the mark for each submission and criterion.
+func (db *database) SaveRubricMarks(marks []models.RubricMark) error {
+	return db.client.Transaction(func(tx *gorm.DB) error {
+		for _, mark := range marks {
+			var existing models.RubricMark
+			err := tx.Where("submission_id = ? AND criterion_id = ?", mark.SubmissionID, mark.CriterionID).First(&existing).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if err == nil {
+				mark.Model = existing.Model
+			}
+
+			err = tx.Save(&mark).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (db *database) GetRubricMarks(submissionID uint) ([]*models.RubricMark, error) {
+	var marks []*models.RubricMark
+	tx := db.client.Where("submission_id = ?", submissionID).Find(&marks)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return marks, nil
+}
+
+func (db *database) GetRubricMarksForAssignment(assignmentID uint) ([]*models.RubricMark, error) {
+	var marks []*models.RubricMark
+	tx := db.client.Where("submission_id IN (?)", db.assignmentSubmissionIDs(assignmentID)).Find(&marks)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return marks, nil
+}
+
+func (db *database) CreateCodeComment(comment models.CodeComment) (*models.CodeComment, error) {
+	tx := db.client.Create(&comment)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &comment, nil
+}
+
+func (db *database) GetCodeComment(id string) (*models.CodeComment, error) {
+	var comment models.CodeComment
+	tx := db.client.First(&comment, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &comment, nil
+}
+
+// GetCodeCommentsForSubmission returns the comments on every version of the
+// submission, ordered by file and line.
+func (db *database) GetCodeCommentsForSubmission(submissionID uint) ([]*models.CodeComment, error) {
+	var comments []*models.CodeComment
+	tx := db.client.Where("submission_id = ?", submissionID).Order("path, start_line, created_at, id").Find(&comments)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return comments, nil
+}
+
+func (db *database) UpdateCodeComment(id uint, body string, resolved bool) (*models.CodeComment, error) {
+	var comment models.CodeComment
+	tx := db.client.First(&comment, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	comment.Body = body
+	comment.Resolved = resolved
+	tx = db.client.Save(&comment)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &comment, nil
+}
+
+func (db *database) DeleteCodeComment(id uint) error {
+	tx := db.client.Delete(&models.CodeComment{}, id)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AllocateSubmissions gives each submission to its marker. Submissions that already
+// have a marker are handed over, keeping how far marking has got.
+func (db *database) AllocateSubmissions(allocations []models.MarkingAllocation) ([]*models.MarkingAllocation, error) {
+	saved := []*models.MarkingAllocation{}
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		for _, allocation := range allocations {
+			allocation := allocation
+
+			var existing models.MarkingAllocation
+			err := tx.Where("submission_id = ?", allocation.SubmissionID).First(&existing).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if err == nil {
+				allocation.Model = existing.Model
+				allocation.Status = existing.Status
+			}
+			if allocation.Status == "" {
+				allocation.Status = models.MarkingStatusPending
+			}
+
+			if err := tx.Omit(clause.Associations).Save(&allocation).Error; err != nil {
+				return err
+			}
+			saved = append(saved, &allocation)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (db *database) GetMarkingAllocation(submissionID uint) (*models.MarkingAllocation, error) {
+	var allocation models.MarkingAllocation
+	tx := db.client.Where("submission_id = ?", submissionID).First(&allocation)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &allocation, nil
+}
+
+func (db *database) GetMarkingAllocationsForAssignment(assignmentID uint) ([]*models.MarkingAllocation, error) {
+	var allocations []*models.MarkingAllocation
+	tx := db.client.Preload("Submission").Where("assignment_id = ?", assignmentID).Order("submission_id").Find(&allocations)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return allocations, nil
+}
+
+func (db *database) GetMarkingAllocationsForMarker(marker string) ([]*models.MarkingAllocation, error) {
+	var allocations []*models.MarkingAllocation
+	tx := db.client.Preload("Submission").Where("marker = ?", marker).Order("assignment_id, submission_id").Find(&allocations)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return allocations, nil
+}
+
+func (db *database) UpdateMarkingStatus(submissionID uint, status models.MarkingStatus) (*models.MarkingAllocation, error) {
+	var allocation models.MarkingAllocation
+	tx := db.client.Preload("Submission").Where("submission_id = ?", submissionID).First(&allocation)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	allocation.Status = status
+	tx = db.client.Omit(clause.Associations).Save(&allocation)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &allocation, nil
+}
+
+// SetModerationSample sets the assignment's moderation threshold and replaces its
+// moderation sample.
+func (db *database) SetModerationSample(assignmentID uint, threshold float64, samples []models.ModerationSample) ([]*models.ModerationSample, error) {
+	saved := []*models.ModerationSample{}
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Assignment{}).Where("id = ?", assignmentID).Update("moderation_threshold", threshold).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("assignment_id = ?", assignmentID).Delete(&models.ModerationSample{}).Error
+		if err != nil {
+			return err
+		}
+
+		for _, sample := range samples {
+			sample := sample
+			sample.AssignmentID = assignmentID
+			if err := tx.Omit(clause.Associations).Create(&sample).Error; err != nil {
+				return err
+			}
+			saved = append(saved, &sample)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (db *database) GetModerationSamples(assignmentID uint) ([]*models.ModerationSample, error) {
+	var samples []*models.ModerationSample
+	tx := db.client.Preload("Submission").Where("assignment_id = ?", assignmentID).Order("submission_id").Find(&samples)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return samples, nil
+}
+
+func (db *database) GetModerationSample(submissionID uint) (*models.ModerationSample, error) {
+	var sample models.ModerationSample
+	tx := db.client.Preload("Submission").Where("submission_id = ?", submissionID).First(&sample)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &sample, nil
+}
+
+// EnterSecondMark records the second mark of a sampled submission along with the first
+// mark it was compared against. Agreed marks settle on the first mark.
+func (db *database) EnterSecondMark(submissionID uint, firstMark, secondMark float64, status models.ModerationStatus) (*models.ModerationSample, error) {
+	var sample models.ModerationSample
+	tx := db.client.Preload("Submission").Where("submission_id = ?", submissionID).First(&sample)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	sample.FirstMark = &firstMark
+	sample.SecondMark = &secondMark
+	sample.Status = status
+	sample.AgreedMark = nil
+	if status == models.ModerationStatusAgreed {
+		sample.AgreedMark = &firstMark
+	}
+	tx = db.client.Omit(clause.Associations).Save(&sample)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &sample, nil
+}
+
+func (db *database) ResolveModeration(submissionID uint, agreedMark float64, resolvedBy, note string) (*models.ModerationSample, error) {
+	var sample models.ModerationSample
+	tx := db.client.Preload("Submission").Where("submission_id = ?", submissionID).First(&sample)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	sample.Status = models.ModerationStatusResolved
+	sample.AgreedMark = &agreedMark
+	sample.ResolvedBy = resolvedBy
+	sample.ResolutionNote = note
+	tx = db.client.Omit(clause.Associations).Save(&sample)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &sample, nil
+}
+
+// GrantExtension creates the student's extension for the assignment, or replaces it if
+// they already have one.
+func (db *database) GrantExtension(extension models.Extension) (*models.Extension, error) {
+	err := db.client.Transaction(func(tx *gorm.DB) error {
+		var existing models.Extension
+		err := tx.Where("student_id = ? AND assignment_id = ?", extension.StudentID, extension.AssignmentID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err == nil {
+			extension.Model = existing.Model
+		}
+
+		return tx.Save(&extension).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &extension, nil
+}
+
+func (db *database) RevokeExtension(studentID, assignmentID uint) error {
+	// Extensions are deleted permanently so the student can be granted a new one, the
+	// audit log keeps a record of the revoked extension.
+	tx := db.client.Unscoped().Where("student_id = ? AND assignment_id = ?", studentID, assignmentID).Delete(&models.Extension{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (db *database) GetExtension(id string) (*models.Extension, error) {
+	var extension models.Extension
+	tx := db.client.First(&extension, id)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &extension, nil
+}
+
+func (db *database) GetExtensionForStudent(studentID, assignmentID uint) (*models.Extension, error) {
+	var extension models.Extension
+	tx := db.client.Where("student_id = ? AND assignment_id = ?", studentID, assignmentID).First(&extension)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &extension, nil
+}
+
+func (db *database) GetExtensionsForAssignment(assignmentID uint) ([]*models.Extension, error) {
+	var extensions []*models.Extension
+	tx := db.client.Where("assignment_id = ?", assignmentID).Order("student_id").Find(&extensions)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return extensions, nil
+}
+
+func (db *database) CreateAuditEvent(event models.AuditEvent) (*models.AuditEvent, error) {
+	tx := db.client.Create(&event)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return &event, nil
+}
+
+func (db *database) GetAuditEvents(filter models.AuditEventFilter, from int) ([]*models.AuditEvent, error) {
+	var events []*models.AuditEvent
+
+	tx := db.client.Where("id >= ?", from)
+	if filter.Actor != "" {
+		tx = tx.Where("actor = ?", filter.Actor)
+	}
+	if filter.AttemptedEmail != "" {
+		tx = tx.Where("attempted_email = ?", filter.AttemptedEmail)
+	}
+	if filter.Action != "" {
+		tx = tx.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		tx = tx.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		tx = tx.Where("entity_id = ?", filter.EntityID)
+	}
+	if !filter.Since.IsZero() {
+		tx = tx.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		tx = tx.Where("created_at < ?", filter.Until)
+	}
+
+	tx = tx.Order("id").Limit(PAGE_SIZE).Find(&events)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return events, nil
+}
+
+// Transaction runs fn with a Database whose changes are committed together if fn
+// returns nil, and rolled back otherwise.
+func (db *database) Transaction(fn func(tx Database) error) error {
+	return db.client.Transaction(func(tx *gorm.DB) error {
+		return fn(&database{client: tx, filePath: db.filePath})
+	})
 }
@@ -0,0 +1,340 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+func newTestDB(t *testing.T) Database {
+	t.Helper()
+
+	return NewDB(filepath.Join(t.TempDir(), "test.sqlite3"))
+}
+
+func TestCloneOffering(t *testing.T) {
+	t.Parallel()
+
+	database := newTestDB(t)
+
+	start := time.Date(2022, 2, 21, 0, 0, 0, 0, time.UTC)
+	offset := 26 * 7 * 24 * time.Hour
+	unit, err := database.CreateUnit("COMP1000")
+	require.NoError(t, err)
+	term, err := database.CreateTerm("S1 2022", start, start.Add(15*7*24*time.Hour))
+	require.NoError(t, err)
+	nextTerm, err := database.CreateTerm("S2 2022", start.Add(offset), start.Add(offset+15*7*24*time.Hour))
+	require.NoError(t, err)
+	offering, err := database.CreateOffering(unit.ID, term.ID)
+	require.NoError(t, err)
+
+	class, err := database.CreateClass("Class 1", unit.ID, &offering.ID)
+	require.NoError(t, err)
+	due := start.Add(6 * 7 * 24 * time.Hour)
+	assignment, err := database.CreateAssignment("Assignment 1", int(due.Unix()), class.ID)
+	require.NoError(t, err)
+	test, err := database.CreateTest("Test 1", assignment.ID, 10, 2)
+	require.NoError(t, err)
+	_, err = database.SetRubric(assignment.ID, []models.RubricCriterion{{
+		Name: "Visual design",
+		Levels: []models.RubricLevel{
+			{Name: "Excellent", Points: 5},
+			{Name: "Poor", Points: 1},
+		},
+	}})
+	require.NoError(t, err)
+	_, err = database.SetLintRules(assignment.ID, []models.LintRule{{Kind: models.LintBannedAPI, Names: []string{"delay"}, Deduction: 1}})
+	require.NoError(t, err)
+	_, err = database.SetStarterFiles(assignment.ID, []models.StarterFile{{Path: "Main/Main.pde", Content: []byte("void setup() {}")}})
+	require.NoError(t, err)
+
+	// Work that belongs to the old term isn't copied.
+	_, _, err = database.CreateSubmission("s0001", assignment.ID, nil, nil)
+	require.NoError(t, err)
+
+	clone, err := database.CloneOffering(offering.ID, nextTerm.ID, offset)
+	require.NoError(t, err)
+
+	assert.Equal(t, unit.ID, clone.Offering.UnitID)
+	assert.Equal(t, nextTerm.ID, clone.Offering.TermID)
+	assert.Equal(t, 1, clone.Classes)
+	assert.Equal(t, 1, clone.Assignments)
+	assert.Equal(t, 1, clone.Tests)
+	assert.Equal(t, 1, clone.RubricCriteria)
+	assert.Equal(t, 1, clone.LintRules)
+	assert.Equal(t, 1, clone.StarterFiles)
+
+	classes, err := database.GetClassesForOffering(clone.Offering.ID)
+	require.NoError(t, err)
+	require.Len(t, classes, 1)
+	assert.NotEqual(t, class.ID, classes[0].ID)
+	assert.Equal(t, "Class 1", classes[0].Name)
+
+	assignments, err := database.GetAssignmentsForClass(classes[0].ID)
+	require.NoError(t, err)
+	require.Len(t, assignments, 1)
+	copied := assignments[0]
+	assert.Equal(t, "Assignment 1", copied.Name)
+	assert.True(t, due.Add(offset).Equal(copied.DueDate), "due date %s should move to %s", copied.DueDate, due.Add(offset))
+	assert.Equal(t, models.FeedbackStatusDraft, copied.Feedback.Status)
+
+	tests, err := database.GetTestsForAssignment(fmt.Sprintf("%d", copied.ID))
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+	assert.NotEqual(t, test.ID, tests[0].ID)
+	assert.Equal(t, 10.0, tests[0].MaxPoints)
+	assert.Equal(t, 2.0, tests[0].Weight)
+	// The copy runs the original's source rather than looking under its own ID.
+	assert.Equal(t, test.SourceKey(), tests[0].SourceKey())
+
+	criteria, err := database.GetRubric(copied.ID)
+	require.NoError(t, err)
+	require.Len(t, criteria, 1)
+	assert.Equal(t, "Visual design", criteria[0].Name)
+	require.Len(t, criteria[0].Levels, 2)
+	assert.ElementsMatch(t, []string{"Excellent", "Poor"}, []string{criteria[0].Levels[0].Name, criteria[0].Levels[1].Name})
+	assert.Equal(t, 5.0, criteria[0].MaxPoints())
+
+	original, err := database.GetRubric(assignment.ID)
+	require.NoError(t, err)
+	require.Len(t, original, 1)
+	require.Len(t, original[0].Levels, 2, "the original's levels shouldn't move to the copy")
+
+	rules, err := database.GetLintRules(copied.ID)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, []string{"delay"}, rules[0].Names)
+
+	files, err := database.GetStarterFiles(copied.ID)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "void setup() {}", string(files[0].Content))
+
+	submissions, err := database.GetSubmissionsForAssignment(fmt.Sprintf("%d", copied.ID))
+	require.NoError(t, err)
+	assert.Empty(t, submissions)
+
+	// Cloning the copy keeps pointing at the first source.
+	lastTerm, err := database.CreateTerm("S1 2023", start.Add(2*offset), start.Add(2*offset+15*7*24*time.Hour))
+	require.NoError(t, err)
+	again, err := database.CloneOffering(clone.Offering.ID, lastTerm.ID, offset)
+	require.NoError(t, err)
+	classes, err = database.GetClassesForOffering(again.Offering.ID)
+	require.NoError(t, err)
+	require.Len(t, classes, 1)
+	assignments, err = database.GetAssignmentsForClass(classes[0].ID)
+	require.NoError(t, err)
+	require.Len(t, assignments, 1)
+	tests, err = database.GetTestsForAssignment(fmt.Sprintf("%d", assignments[0].ID))
+	require.NoError(t, err)
+	require.Len(t, tests, 1)
+	assert.Equal(t, test.SourceKey(), tests[0].SourceKey())
+}
+
+func TestStudentInvite(t *testing.T) {
+	t.Parallel()
+
+	database := newTestDB(t)
+
+	student, err := database.CreateStudent("s0001", "Alice Penguin", "alice@example.com")
+	require.NoError(t, err)
+
+	_, oldHash, err := models.NewInviteCode()
+	require.NoError(t, err)
+	_, err = database.SetStudentInvite(student.ID, oldHash, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// Inviting the student again replaces their code.
+	code, hash, err := models.NewInviteCode()
+	require.NoError(t, err)
+	_, err = database.SetStudentInvite(student.ID, hash, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	invite, err := database.GetStudentInvite(student.ID)
+	require.NoError(t, err)
+	assert.True(t, invite.Check(code, time.Now()))
+	assert.False(t, invite.Check(code, time.Now().Add(2*time.Hour)))
+
+	// Creating the account uses up the invite.
+	_, err = database.CreateStudentUser(student.Email, "hash", student.ID)
+	require.NoError(t, err)
+	_, err = database.GetStudentInvite(student.ID)
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestCreateSubmission(t *testing.T) {
+	t.Parallel()
+
+	database := newTestDB(t)
+
+	submission, version, err := database.CreateSubmission("s0001", 1, nil, []models.SubmissionFile{{Path: "Main/Main.pde", Content: []byte("void setup() {}")}})
+	require.NoError(t, err)
+	assert.Equal(t, submission.ID, version.SubmissionID)
+	assert.Equal(t, 1, version.Number)
+
+	versions, err := database.GetSubmissionVersions(submission.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+
+	files, err := database.GetSubmissionFiles(version.ID)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "Main/Main.pde", files[0].Path)
+}
+
+func TestSubmitVersion(t *testing.T) {
+	t.Parallel()
+
+	database := newTestDB(t)
+
+	studentRecordID := uint(7)
+	submission, version, created, err := database.SubmitVersion(1, "s0001_Alice_Penguin", &studentRecordID, nil)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, 1, version.Number)
+
+	// Resubmitting, even under a different identifier, adds a version to the same
+	// submission.
+	resubmission, version, created, err := database.SubmitVersion(1, "s0001_Alice", &studentRecordID, nil)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, submission.ID, resubmission.ID)
+	assert.Equal(t, 2, version.Number)
+
+	// The student can't end up with two submissions for the assignment.
+	_, _, err = database.CreateSubmission("s0001_Alice", 1, &studentRecordID, nil)
+	assert.Error(t, err)
+}
+
+func TestGradingInputsForAssignment(t *testing.T) {
+	t.Parallel()
+
+	database := newTestDB(t)
+
+	submission, version, err := database.CreateSubmission("s0001", 1, nil, nil)
+	require.NoError(t, err)
+	_, otherVersion, err := database.CreateSubmission("s0001", 2, nil, nil)
+	require.NoError(t, err)
+
+	for _, v := range []*models.SubmissionVersion{version, otherVersion} {
+		_, err = database.CreateResult(4, v.SubmissionID, v.ID)
+		require.NoError(t, err)
+		require.NoError(t, database.CreateTestOutcomes([]models.TestOutcome{{Points: 1, TestID: 1, SubmissionVersionID: v.ID}}))
+	}
+
+	// Only the inputs of the assignment's own submissions are loaded.
+	versions, err := database.GetSubmissionVersionsForAssignment(1)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, version.ID, versions[0].ID)
+
+	results, err := database.GetResultsForAssignment(1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, submission.ID, results[0].SubmissionID)
+
+	outcomes, err := database.GetTestOutcomesForAssignment(2)
+	require.NoError(t, err)
+	require.Len(t, outcomes, 1)
+	assert.Equal(t, otherVersion.ID, outcomes[0].SubmissionVersionID)
+}
+
+func TestTransaction(t *testing.T) {
+	t.Parallel()
+
+	database := newTestDB(t)
+
+	err := database.Transaction(func(tx Database) error {
+		_, err := tx.CreateStudent("s0001", "Alice Penguin", "alice@example.com")
+		require.NoError(t, err)
+
+		return errors.New("abort")
+	})
+	assert.EqualError(t, err, "abort")
+
+	_, err = database.GetStudentByNumber("s0001")
+	assert.ErrorIs(t, err, ErrRecordNotFound, "the student should be rolled back")
+}
+
+func TestCreateResultKeepsOverride(t *testing.T) {
+	t.Parallel()
+
+	database := newTestDB(t)
+
+	submission, version, err := database.CreateSubmission("s0001", 1, nil, nil)
+	require.NoError(t, err)
+	nextVersion, err := database.CreateSubmissionVersion(submission.ID, nil)
+	require.NoError(t, err)
+
+	result, err := database.CreateResult(4, submission.ID, version.ID)
+	require.NoError(t, err)
+	_, err = database.OverrideResult(result.ID, 7, "Marked by hand", "tutor@example.com")
+	require.NoError(t, err)
+
+	// Re-running the tests on the same version keeps the tutor's score.
+	rerun, err := database.CreateResult(5, submission.ID, version.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 7.0, rerun.Score)
+	require.NotNil(t, rerun.OriginalScore)
+	assert.Equal(t, 5.0, *rerun.OriginalScore)
+
+	overrides, err := database.GetResultOverrides(rerun.ID)
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, "Marked by hand", overrides[0].Reason)
+	assert.Equal(t, 7.0, overrides[0].Score)
+
+	// A new version is marked afresh.
+	resubmitted, err := database.CreateResult(6, submission.ID, nextVersion.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, resubmitted.Score)
+	assert.Nil(t, resubmitted.OriginalScore)
+}
+
+func TestRecordLoginFailure(t *testing.T) {
+	t.Parallel()
+
+	database := newTestDB(t)
+
+	user, err := database.CreateUser("tutor@example.com", "hash", models.UserRoleTutor)
+	require.NoError(t, err)
+
+	// Every failure counts, even when they race.
+	var wg sync.WaitGroup
+	counts := make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count, err := database.RecordLoginFailure(user.ID)
+			assert.NoError(t, err)
+			counts <- count
+		}()
+	}
+	wg.Wait()
+	close(counts)
+
+	seen := []int{}
+	for count := range counts {
+		seen = append(seen, count)
+	}
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, seen)
+
+	// A shorter lock doesn't cut an existing one short.
+	later := time.Now().Add(time.Hour).UTC()
+	require.NoError(t, database.LockUser(user.ID, later))
+	require.NoError(t, database.LockUser(user.ID, time.Now().Add(time.Minute)))
+
+	user, err = database.GetUserByEmail("tutor@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 5, user.FailedLoginAttempts)
+	assert.True(t, later.Equal(user.LockedUntil), "locked until %s, want %s", user.LockedUntil, later)
+}
@@ -1,16 +1,75 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// AttemptPolicy decides which version of a resubmitted submission counts towards
+// the student's grade.
+type AttemptPolicy string
+
+const (
+	AttemptPolicyLatest AttemptPolicy = "LATEST"
+	AttemptPolicyBest   AttemptPolicy = "BEST"
+	AttemptPolicyFirst  AttemptPolicy = "FIRST"
+)
+
+// LatePolicy decides how submissions made after an assignment's due date are penalised.
+type LatePolicy struct {
+	GracePeriod   time.Duration // submissions this soon after the due date aren't penalised
+	PenaltyPerDay float64       // percentage of the score deducted per day or part day late
+	MaxPenalty    float64       // cap on the total percentage deducted, 0 for no cap
+	Cutoff        *time.Time    // submissions after the cutoff score zero, nil for no cutoff
+}
+
+// FeedbackStatus is the stage an assignment's feedback has reached on its way to
+// students.
+type FeedbackStatus string
+
+const (
+	FeedbackStatusDraft           FeedbackStatus = "DRAFT"
+	FeedbackStatusUnderModeration FeedbackStatus = "UNDER_MODERATION"
+	FeedbackStatusReleased        FeedbackStatus = "RELEASED"
+)
+
+// FeedbackRelease decides when students can see their results, rubric marks and
+// comments for an assignment.
+type FeedbackRelease struct {
+	Status    FeedbackStatus `gorm:"default:DRAFT"`
+	ReleaseAt *time.Time     // released feedback stays hidden until then, nil to show it straight away
+}
+
+// Released reports whether students can see the feedback at the given time.
+func (r FeedbackRelease) Released(now time.Time) bool {
+	if r.Status != FeedbackStatusReleased {
+		return false
+	}
+
+	return r.ReleaseAt == nil || !now.Before(*r.ReleaseAt)
+}
+
 type Assignment struct {
 	gorm.Model
-	Name        string
-	DueDate     time.Time
-	Tests       []Test
-	Submissions []Submission
-	ClassID     uint // foreign key
+	Name          string
+	DueDate       time.Time
+	AttemptPolicy AttemptPolicy   `gorm:"default:LATEST"`
+	LatePolicy    LatePolicy      `gorm:"embedded;embeddedPrefix:late_"`
+	Feedback      FeedbackRelease `gorm:"embedded;embeddedPrefix:feedback_"`
+	Tests         []Test
+	Submissions   []Submission
+	ClassID       uint // foreign key
+	// ModerationThreshold is how far a second mark can be from the first before the
+	// marks need resolving.
+	ModerationThreshold float64
+	// GroupWork assignments are submitted by groups, with each member sharing the grade.
+	GroupWork bool
+}
+
+// ProjectsKey is the S3 prefix the assignment's submitted projects are uploaded under.
+// Keys are by ID, as the same assignment name recurs in every term a unit runs.
+func (a *Assignment) ProjectsKey() string {
+	return fmt.Sprintf("Assignments/%d/Projects/", a.ID)
 }
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditEvent is an append-only record of a mutation. Actor is the email of the
+// authenticated user, or "anonymous". AttemptedEmail is the unverified email given to an
+// unauthenticated mutation such as login. Before and After hold the JSON encoded state of
+// the affected entity and are empty when not applicable. Error is the error the mutation
+// failed with, empty if it succeeded.
+type AuditEvent struct {
+	gorm.Model
+	Actor          string
+	AttemptedEmail string
+	Action         string
+	EntityType     string
+	EntityID       string
+	Before         string
+	After          string
+	RequestIP      string
+	Error          string
+}
+
+// AuditEventFilter narrows a search of the audit log. Zero values are ignored.
+type AuditEventFilter struct {
+	Actor          string
+	AttemptedEmail string
+	Action         string
+	EntityType     string
+	EntityID       string
+	Since          time.Time
+	Until          time.Time
+}
@@ -9,4 +9,7 @@ type Class struct {
 	Name        string
 	Assignments []Assignment
 	UnitID      uint // foreign key
+	// OfferingID is the offering of the unit the class runs in, unset for classes from
+	// before terms were introduced.
+	OfferingID *uint // foreign key
 }
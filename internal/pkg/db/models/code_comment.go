@@ -0,0 +1,19 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// CodeComment is a tutor's comment on a range of lines of a file in a submission
+// version.
+type CodeComment struct {
+	gorm.Model
+	Path                string
+	StartLine           int
+	EndLine             int
+	Author              string // email of the user who wrote the comment
+	Body                string
+	Resolved            bool
+	SubmissionID        uint // foreign key
+	SubmissionVersionID uint // foreign key
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// CodeMetrics are the static analysis metrics of a single source file of a submission
+// version.
+type CodeMetrics struct {
+	gorm.Model
+	Path string
+	// Starter is set when the file is unchanged from the assignment's starter code.
+	Starter      bool
+	Lines        int
+	CodeLines    int
+	CommentLines int
+	BlankLines   int
+	Functions    []FunctionMetrics `gorm:"serializer:json"`
+	Classes      []string          `gorm:"serializer:json"`
+	// HasSetup and HasDraw are set when the file defines the sketch's top level setup()
+	// and draw() functions.
+	HasSetup            bool
+	HasDraw             bool
+	MagicNumbers        []MagicNumber `gorm:"serializer:json"`
+	NamingIssues        []NamingIssue `gorm:"serializer:json"`
+	SubmissionVersionID uint          // foreign key
+}
+
+// FunctionMetrics describes a function or method. Class is empty for functions
+// declared at the top level of a sketch.
+type FunctionMetrics struct {
+	Name       string
+	Class      string
+	StartLine  int
+	EndLine    int
+	Complexity int
+}
+
+// MagicNumber is a numeric literal used outside of a constant declaration.
+type MagicNumber struct {
+	Line  int
+	Value string
+}
+
+// NamingIssue is a declaration whose name doesn't follow Java naming conventions.
+type NamingIssue struct {
+	Line     int
+	Name     string
+	Kind     string
+	Expected string
+}
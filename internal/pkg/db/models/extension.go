@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Extension moves an assignment's due date for a single student, e.g. after special
+// consideration is approved. A student has at most one extension per assignment.
+type Extension struct {
+	gorm.Model
+	StudentID    uint `gorm:"uniqueIndex:idx_extension_student_assignment"` // foreign key
+	AssignmentID uint `gorm:"uniqueIndex:idx_extension_student_assignment"` // foreign key
+	DueDate      time.Time
+	Reason       string
+	ApprovedBy   string // email of the user who granted the extension
+}
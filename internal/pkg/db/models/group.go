@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Group is a team of students in a class who submit group work together.
+type Group struct {
+	gorm.Model
+	Name    string
+	ClassID uint // foreign key
+}
+
+// GroupMember records that a student is in a group. A student is in at most one group
+// per class.
+type GroupMember struct {
+	GroupID   uint `gorm:"primaryKey"`                                    // foreign key
+	StudentID uint `gorm:"primaryKey;uniqueIndex:idx_group_member_class"` // foreign key
+	ClassID   uint `gorm:"uniqueIndex:idx_group_member_class"`            // foreign key
+	CreatedAt time.Time
+}
+
+// MemberAdjustment changes a group member's grade for a group submission, e.g. to
+// reflect peer assessment of their contribution. A member has at most one adjustment
+// per submission.
+type MemberAdjustment struct {
+	gorm.Model
+	SubmissionID uint    `gorm:"uniqueIndex:idx_member_adjustment"` // foreign key
+	StudentID    uint    `gorm:"uniqueIndex:idx_member_adjustment"` // foreign key
+	Marks        float64 // added to the group's grade, negative for a deduction
+	Reason       string
+	AdjustedBy   string // email of the user who made the adjustment
+}
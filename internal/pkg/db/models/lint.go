@@ -0,0 +1,75 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// LintRuleKind is the check a lint rule performs.
+type LintRuleKind string
+
+const (
+	// LintMaxFunctionLength flags functions longer than Limit lines.
+	LintMaxFunctionLength LintRuleKind = "MAX_FUNCTION_LENGTH"
+	// LintMaxComplexity flags functions with a cyclomatic complexity above Limit.
+	LintMaxComplexity LintRuleKind = "MAX_COMPLEXITY"
+	// LintRequireComments flags functions without a comment before them.
+	LintRequireComments LintRuleKind = "REQUIRE_COMMENTS"
+	// LintBannedAPI flags uses of the functions or classes named in Names.
+	LintBannedAPI LintRuleKind = "BANNED_API"
+	// LintRequiredClass flags each class named in Names that the sketch doesn't declare.
+	LintRequiredClass LintRuleKind = "REQUIRED_CLASS"
+	// LintMagicNumbers flags numeric literals used outside of constant declarations.
+	LintMagicNumbers LintRuleKind = "MAGIC_NUMBERS"
+	// LintNamingConventions flags declarations that don't follow Java naming conventions.
+	LintNamingConventions LintRuleKind = "NAMING_CONVENTIONS"
+)
+
+type LintSeverity string
+
+const (
+	LintSeverityInfo    LintSeverity = "INFO"
+	LintSeverityWarning LintSeverity = "WARNING"
+	LintSeverityError   LintSeverity = "ERROR"
+)
+
+// LintRule is a style check applied to every submission to an assignment.
+type LintRule struct {
+	gorm.Model
+	Kind     LintRuleKind
+	Severity LintSeverity
+	Position int // order of the rule within the assignment's rules
+	// Limit is the threshold for the MAX_FUNCTION_LENGTH and MAX_COMPLEXITY rules.
+	Limit int
+	// Names are the APIs or classes for the BANNED_API and REQUIRED_CLASS rules, e.g.
+	// "delay" or "System.exit".
+	Names []string `gorm:"serializer:json"`
+	// Message replaces the default description of the rule's findings if set.
+	Message string
+	// Deduction is the number of marks deducted per finding.
+	Deduction float64
+	// MaxDeduction caps the marks deducted for the rule, 0 for no cap.
+	MaxDeduction float64
+	AssignmentID uint // foreign key
+}
+
+// LintFinding is a single violation of a lint rule in a submission version. Path is
+// empty and Line is 0 for findings about the sketch as a whole.
+type LintFinding struct {
+	gorm.Model
+	Path                string
+	Line                int
+	Severity            LintSeverity
+	Message             string
+	RuleID              uint // foreign key
+	SubmissionVersionID uint // foreign key
+}
+
+// DeductionFor returns the marks deducted for the given number of findings of the rule.
+func (r *LintRule) DeductionFor(findings int) float64 {
+	deduction := r.Deduction * float64(findings)
+	if r.MaxDeduction > 0 && deduction > r.MaxDeduction {
+		deduction = r.MaxDeduction
+	}
+
+	return deduction
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// MarkingStatus is how far a marker has got with a submission allocated to them.
+type MarkingStatus string
+
+const (
+	MarkingStatusPending    MarkingStatus = "PENDING"
+	MarkingStatusInProgress MarkingStatus = "IN_PROGRESS"
+	MarkingStatusDone       MarkingStatus = "DONE"
+)
+
+// MarkingAllocation assigns a submission to the tutor who marks it. A submission has
+// at most one marker.
+type MarkingAllocation struct {
+	gorm.Model
+	SubmissionID uint `gorm:"uniqueIndex"` // foreign key
+	Submission   Submission
+	AssignmentID uint          // foreign key
+	Marker       string        // email of the user marking the submission
+	Status       MarkingStatus `gorm:"default:PENDING"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// ModerationStatus is how far a sampled submission has got through moderation.
+type ModerationStatus string
+
+const (
+	ModerationStatusAwaitingSecondMark ModerationStatus = "AWAITING_SECOND_MARK"
+	// ModerationStatusAgreed means the marks are within the assignment's moderation
+	// threshold, so the first mark stands.
+	ModerationStatusAgreed      ModerationStatus = "AGREED"
+	ModerationStatusDiscrepancy ModerationStatus = "DISCREPANCY"
+	ModerationStatusResolved    ModerationStatus = "RESOLVED"
+)
+
+// ModerationSample is a submission chosen to be independently re-marked by a second
+// marker. A submission is sampled at most once.
+type ModerationSample struct {
+	gorm.Model
+	SubmissionID uint `gorm:"uniqueIndex"` // foreign key
+	Submission   Submission
+	AssignmentID uint             // foreign key
+	SecondMarker string           // email of the user re-marking the submission
+	Status       ModerationStatus `gorm:"default:AWAITING_SECOND_MARK"`
+	// FirstMark is the submission's grade when the second mark was entered.
+	FirstMark  *float64
+	SecondMark *float64
+	// AgreedMark is the first mark if the marks agreed, otherwise the mark recorded
+	// when the discrepancy was resolved.
+	AgreedMark     *float64
+	ResolvedBy     string // email of the user who resolved a discrepancy
+	ResolutionNote string
+}
+
+// BlindTo reports whether the user is the sample's second marker and hasn't entered
+// their mark yet, so mustn't see the first marker's marks.
+func (s *ModerationSample) BlindTo(user *User) bool {
+	return user.Role != UserRoleAdmin && s.SecondMarker == user.Email && s.SecondMark == nil
+}
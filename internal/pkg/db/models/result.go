@@ -6,6 +6,18 @@ import (
 
 type Result struct {
 	gorm.Model
-	Score        float64
-	SubmissionID uint // foreign key
+	Score               float64
+	OriginalScore       *float64 // score from the executor, nil unless the score has been overridden
+	SubmissionID        uint     // foreign key
+	SubmissionVersionID *uint    // foreign key, nil for results recorded before versioning
+}
+
+// ResultOverride records a tutor manually changing a result's score.
+type ResultOverride struct {
+	gorm.Model
+	PreviousScore float64
+	Score         float64
+	Reason        string
+	OverriddenBy  string // email of the user who overrode the score
+	ResultID      uint   // foreign key
 }
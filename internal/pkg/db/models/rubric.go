@@ -0,0 +1,47 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// RubricCriterion is a single row of an assignment's marking rubric, e.g. "Visual
+// design", with the levels a tutor can choose between.
+type RubricCriterion struct {
+	gorm.Model
+	Name         string
+	Description  string
+	Position     int           // order of the criterion within the rubric
+	Levels       []RubricLevel `gorm:"foreignKey:CriterionID"`
+	AssignmentID uint          // foreign key
+}
+
+// RubricLevel is one of the levels of achievement for a criterion, e.g. "Excellent".
+type RubricLevel struct {
+	gorm.Model
+	Name        string
+	Description string
+	Points      float64
+	CriterionID uint // foreign key
+}
+
+// RubricMark records the level a tutor selected for one criterion of a submission.
+type RubricMark struct {
+	gorm.Model
+	SubmissionID uint `gorm:"uniqueIndex:idx_rubric_mark_submission_criterion"` // foreign key
+	CriterionID  uint `gorm:"uniqueIndex:idx_rubric_mark_submission_criterion"` // foreign key
+	LevelID      uint // foreign key
+	Comment      string
+	MarkedBy     string // email of the tutor who marked the criterion
+}
+
+// MaxPoints returns the points for the highest level of the criterion.
+func (c *RubricCriterion) MaxPoints() float64 {
+	max := 0.0
+	for _, level := range c.Levels {
+		if level.Points > max {
+			max = level.Points
+		}
+	}
+
+	return max
+}
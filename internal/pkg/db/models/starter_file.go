@@ -0,0 +1,14 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// StarterFile is a file supplied to students as the starting point for an assignment.
+// Path is relative to the student's folder, as for SubmissionFile.
+type StarterFile struct {
+	gorm.Model
+	Path         string
+	Content      []byte
+	AssignmentID uint // foreign key
+}
@@ -0,0 +1,52 @@
+package models
+
+// AssignmentStatistics summarises how an assignment went. Scores are each submission's
+// final grade, so submissions that haven't been marked are included in Submissions but
+// not in Graded or the score aggregates.
+type AssignmentStatistics struct {
+	Submissions int
+	// Missing is the number of enrolled students who haven't submitted.
+	Missing int
+	Graded  int
+	// Score aggregates are nil when no submission has been graded.
+	Mean   *float64
+	Median *float64
+	StdDev *float64
+	Min    *float64
+	Max    *float64
+	// MaxScore is the most a submission can score from the assignment's tests and
+	// rubric.
+	MaxScore  float64
+	Histogram []HistogramBucket
+	Tests     []TestStatistics
+}
+
+// HistogramBucket counts the scores from Lower up to but not including Upper. The last
+// bucket also includes scores of Upper and above, and the first scores below Lower.
+type HistogramBucket struct {
+	Lower float64
+	Upper float64
+	Count int
+}
+
+// TestStatistics summarises a single test's outcomes across the counted versions of
+// an assignment's submissions.
+type TestStatistics struct {
+	Test *Test
+	// Attempts is the number of counted versions the test was run against.
+	Attempts int
+	// Passed is the number of attempts awarded the test's full points.
+	Passed     int
+	MeanPoints *float64
+}
+
+// PassRate returns the fraction of attempts that passed, or nil if there were none.
+func (s TestStatistics) PassRate() *float64 {
+	if s.Attempts == 0 {
+		return nil
+	}
+
+	rate := float64(s.Passed) / float64(s.Attempts)
+
+	return &rate
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Student struct {
+	gorm.Model
+	StudentNumber string `gorm:"uniqueIndex"`
+	Name          string
+	Email         string
+}
+
+// Enrolment records that a student is enrolled in a class.
+type Enrolment struct {
+	StudentID uint `gorm:"primaryKey"` // foreign key
+	ClassID   uint `gorm:"primaryKey"` // foreign key
+	CreatedAt time.Time
+}
+
+// ParseStudentNumber extracts the student number from a submission identifier such as
+// "s0001_Alice_Penguin", the format used by LMS bulk downloads.
+func ParseStudentNumber(identifier string) string {
+	number, _, _ := strings.Cut(strings.TrimSpace(identifier), "_")
+
+	return number
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InviteLifetime is how long a student has to use an invite code.
+const InviteLifetime = 14 * 24 * time.Hour
+
+// StudentInvite lets a student create a student portal account. Staff pass the code on
+// to the student, so holding it shows the account is theirs. A student has at most one
+// invite, and it's used up when their account is created.
+type StudentInvite struct {
+	gorm.Model
+	StudentID uint   `gorm:"uniqueIndex"` // foreign key
+	CodeHash  string // only the hash is stored, the code is shown once when issued
+	ExpiresAt time.Time
+}
+
+// NewInviteCode returns a random invite code and the hash to store for it.
+func NewInviteCode() (code, hash string, err error) {
+	b := make([]byte, 16)
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", "", err
+	}
+
+	code = hex.EncodeToString(b)
+
+	return code, hashInviteCode(code), nil
+}
+
+// hashInviteCode hashes a code with SHA-256. Codes are random, so unlike passwords
+// they don't need a slow hash.
+func hashInviteCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Check reports whether the code matches the invite and the invite hasn't expired.
+func (i *StudentInvite) Check(code string, now time.Time) bool {
+	match := subtle.ConstantTimeCompare([]byte(hashInviteCode(code)), []byte(i.CodeHash)) == 1
+
+	return match && now.Before(i.ExpiresAt)
+}
@@ -6,7 +6,9 @@ import (
 
 type Submission struct {
 	gorm.Model
-	StudentID    string
-	Result       Result
-	AssignmentID uint // foreign key
+	StudentID       string // identifier as submitted, e.g. "s0001_Alice_Penguin"
+	Result          Result
+	AssignmentID    uint  `gorm:"uniqueIndex:idx_submission_assignment_student"` // foreign key
+	StudentRecordID *uint `gorm:"uniqueIndex:idx_submission_assignment_student"` // foreign key, nil when no Student matches StudentID
+	GroupID         *uint // foreign key, set when the submission is group work
 }
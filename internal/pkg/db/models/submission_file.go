@@ -0,0 +1,14 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// SubmissionFile is a source file belonging to a submission version. Path is relative
+// to the student's folder, e.g. "MarchPenguin/Beak.pde".
+type SubmissionFile struct {
+	gorm.Model
+	Path                string
+	Content             []byte
+	SubmissionVersionID uint // foreign key
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// SubmissionVersion is a single attempt at a submission. Number starts at 1 for the
+// first attempt and increases with every resubmission.
+type SubmissionVersion struct {
+	gorm.Model
+	Number       int `gorm:"uniqueIndex:idx_submission_version_number"`
+	Files        []SubmissionFile
+	SubmissionID uint `gorm:"uniqueIndex:idx_submission_version_number,priority:1"` // foreign key
+}
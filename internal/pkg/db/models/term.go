@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Term is a teaching period, e.g. "2026 S1", that units are offered in.
+type Term struct {
+	gorm.Model
+	Name      string `gorm:"uniqueIndex"`
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// Offering is a unit running in a term, with its own classes and their assignments. A
+// unit is offered at most once per term.
+type Offering struct {
+	gorm.Model
+	UnitID  uint `gorm:"uniqueIndex:idx_offering_unit_term"` // foreign key
+	Unit    Unit
+	TermID  uint `gorm:"uniqueIndex:idx_offering_unit_term"` // foreign key
+	Term    Term
+	Classes []Class
+}
+
+// OfferingClone reports what was copied when an offering was cloned into another term.
+type OfferingClone struct {
+	Offering       *Offering
+	Classes        int
+	Assignments    int
+	Tests          int
+	RubricCriteria int
+	LintRules      int
+	StarterFiles   int
+}
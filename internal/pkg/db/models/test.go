@@ -1,11 +1,27 @@
 package models
 
 import (
+	"fmt"
+
 	"gorm.io/gorm"
 )
 
 type Test struct {
 	gorm.Model
 	Name         string
-	AssignmentID uint // foreign key
+	MaxPoints    float64 `gorm:"default:1"` // points awarded for passing the test in full
+	Weight       float64 `gorm:"default:1"` // multiplier applied to the test's points in the assignment total
+	AssignmentID uint    // foreign key
+	// Source is the S3 key of the source of the test this was cloned from, which the
+	// copy keeps using. It's empty for a test with a source of its own.
+	Source string
+}
+
+// SourceKey is the S3 key of the test's source.
+func (t *Test) SourceKey() string {
+	if t.Source != "" {
+		return t.Source
+	}
+
+	return fmt.Sprintf("Assignments/%d/Tests/%d/Test.java", t.AssignmentID, t.ID)
 }
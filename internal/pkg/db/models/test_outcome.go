@@ -0,0 +1,13 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// TestOutcome is the points a submission version was awarded by a single test run.
+type TestOutcome struct {
+	gorm.Model
+	Points              float64
+	TestID              uint // foreign key
+	SubmissionVersionID uint // foreign key
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -10,15 +12,27 @@ type UserRole int64
 const (
 	UserRoleAdmin UserRole = iota
 	UserRoleTutor
+	UserRoleStudent
 )
 
 type User struct {
 	gorm.Model
-	Email        string
-	PasswordHash string
-	Role         UserRole
+	Email               string
+	PasswordHash        string
+	Role                UserRole
+	FailedLoginAttempts int
+	LockedUntil         time.Time
+	StudentID           *uint `gorm:"uniqueIndex"` // foreign key, set for student accounts
+}
+
+// IsStaff reports whether the user is an admin or tutor rather than a student.
+func (u *User) IsStaff() bool {
+	return u.Role == UserRoleAdmin || u.Role == UserRoleTutor
 }
 
+// dummyPasswordHash is a bcrypt hash of a random password at the default cost.
+const dummyPasswordHash = "$2a$10$SrNj7cwoEYJT1.ZZSzf4legSVDK5EhftjvqOEqZuBQbm7OiYXnZOm"
+
 func HashPassword(password string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -31,3 +45,14 @@ func HashPassword(password string) (string, error) {
 func (u *User) CheckPassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
 }
+
+// CheckDummyPassword compares the password against a fixed hash. It is used when no
+// account exists so that failed logins take the same time either way.
+func CheckDummyPassword(password string) {
+	_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+}
+
+// IsLocked reports whether the account is temporarily locked out at the given time.
+func (u *User) IsLocked(now time.Time) bool {
+	return now.Before(u.LockedUntil)
+}
@@ -0,0 +1,317 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+// Line is a single line of an edit script.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// SplitLines splits text into lines without their line endings.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Lines returns the edit script that turns a into b, based on the longest common
+// subsequence of their lines. Within each run of changes, deletions come before
+// insertions.
+func Lines(a, b []string) []Line {
+	var edits []Line
+	edits = myers(a, b, edits)
+
+	// Myers' algorithm can interleave the deletions and insertions of a run of changes,
+	// which is harder to read.
+	for start := 0; start < len(edits); {
+		if edits[start].Op == Equal {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(edits) && edits[end].Op != Equal {
+			end++
+		}
+		sort.SliceStable(edits[start:end], func(i, j int) bool {
+			return edits[start+i].Op == Delete && edits[start+j].Op == Insert
+		})
+		start = end
+	}
+
+	return edits
+}
+
+// myers appends the edit script that turns a into b to edits, using the linear space
+// refinement of Myers' O(ND) algorithm: it finds the middle snake of a shortest edit
+// script, then diffs the lines either side of it. Unlike a table of the longest common
+// subsequence, memory grows with the number of lines rather than their square.
+func myers(a, b []string, edits []Line) []Line {
+	// Common prefixes and suffixes are cheap to match and shrink the search.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, line := range a[:prefix] {
+		edits = append(edits, Line{Op: Equal, Text: line})
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	switch {
+	case len(midA) == 0:
+		for _, line := range midB {
+			edits = append(edits, Line{Op: Insert, Text: line})
+		}
+	case len(midB) == 0:
+		for _, line := range midA {
+			edits = append(edits, Line{Op: Delete, Text: line})
+		}
+	default:
+		// With the common ends removed at least two edits are needed, so both sides of
+		// the middle snake need fewer edits and the recursion ends.
+		x, y, u, v := middleSnake(midA, midB)
+		edits = myers(midA[:x], midB[:y], edits)
+		for _, line := range midA[x:u] {
+			edits = append(edits, Line{Op: Equal, Text: line})
+		}
+		edits = myers(midA[u:], midB[v:], edits)
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, Line{Op: Equal, Text: line})
+	}
+
+	return edits
+}
+
+// middleSnake searches for a shortest edit script from both ends at once, returning
+// the snake (a run of equal lines from a[x:u] and b[y:v]) where the searches meet.
+// Both searches track the furthest x reached on each diagonal k = x - y, with the
+// backward search working on the reversed sequences.
+func middleSnake(a, b []string) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	max := (n + m + 1) / 2
+
+	// forward[max+k] and backward[max+k] are the furthest x reached on diagonal k.
+	forward := make([]int, 2*max+2)
+	backward := make([]int, 2*max+2)
+
+	for d := 0; d <= max; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[max+k-1] < forward[max+k+1]) {
+				x = forward[max+k+1]
+			} else {
+				x = forward[max+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[max+k] = x
+
+			// The backward search has taken d-1 steps, and reaches diagonal delta-k
+			// in its reversed coordinates.
+			if odd && delta-k >= -(d-1) && delta-k <= d-1 && x+backward[max+delta-k] >= n {
+				return startX, startY, x, y
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && backward[max+k-1] < backward[max+k+1]) {
+				x = backward[max+k+1]
+			} else {
+				x = backward[max+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x++
+				y++
+			}
+			backward[max+k] = x
+
+			if !odd && delta-k >= -d && delta-k <= d && x+forward[max+delta-k] >= n {
+				return n - x, m - y, n - startX, m - startY
+			}
+		}
+	}
+
+	// The searches always meet within max steps.
+	panic("diff: no middle snake")
+}
+
+// Unified renders an edit script in unified diff format with the given number of
+// lines of context around each change. It returns "" if there are no changes.
+func Unified(oldName, newName string, edits []Line, context int) string {
+	// oldLine[k] and newLine[k] are the 0-based line numbers at which edits[k] starts.
+	oldLine := make([]int, len(edits)+1)
+	newLine := make([]int, len(edits)+1)
+	var changes []int
+	for k, edit := range edits {
+		oldLine[k+1], newLine[k+1] = oldLine[k], newLine[k]
+		if edit.Op != Insert {
+			oldLine[k+1]++
+		}
+		if edit.Op != Delete {
+			newLine[k+1]++
+		}
+		if edit.Op != Equal {
+			changes = append(changes, k)
+		}
+	}
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	for c := 0; c < len(changes); {
+		start := changes[c] - context
+		if start < 0 {
+			start = 0
+		}
+
+		// Merge changes whose context would overlap into a single hunk.
+		last := changes[c]
+		for c+1 < len(changes) && changes[c+1]-last <= 2*context {
+			c++
+			last = changes[c]
+		}
+		c++
+
+		end := last + context + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		oldCount, newCount := oldLine[end]-oldLine[start], newLine[end]-newLine[start]
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldLine[start], oldCount), hunkRange(newLine[start], newCount))
+
+		for _, edit := range edits[start:end] {
+			switch edit.Op {
+			case Equal:
+				b.WriteString(" ")
+			case Insert:
+				b.WriteString("+")
+			case Delete:
+				b.WriteString("-")
+			}
+			b.WriteString(edit.Text)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// hunkRange formats the start and length of a hunk, where start is the 0-based line
+// the hunk begins at.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+type FileStatus string
+
+const (
+	FileAdded     FileStatus = "ADDED"
+	FileRemoved   FileStatus = "REMOVED"
+	FileModified  FileStatus = "MODIFIED"
+	FileUnchanged FileStatus = "UNCHANGED"
+)
+
+// FileDiff describes how a single file differs between two sets of files.
+type FileDiff struct {
+	Path      string
+	Status    FileStatus
+	Additions int
+	Deletions int
+	Patch     string
+}
+
+// Files compares two sets of files keyed by path, returning a diff for every path in
+// either set ordered by path.
+func Files(old, new map[string]string) []FileDiff {
+	paths := map[string]bool{}
+	for path := range old {
+		paths[path] = true
+	}
+	for path := range new {
+		paths[path] = true
+	}
+
+	var diffs []FileDiff
+	for path := range paths {
+		oldContent, inOld := old[path]
+		newContent, inNew := new[path]
+
+		fileDiff := FileDiff{Path: path, Status: FileModified}
+		oldName, newName := "a/"+path, "b/"+path
+		switch {
+		case !inOld:
+			fileDiff.Status = FileAdded
+			oldName = "/dev/null"
+		case !inNew:
+			fileDiff.Status = FileRemoved
+			newName = "/dev/null"
+		case oldContent == newContent:
+			fileDiff.Status = FileUnchanged
+		}
+
+		if fileDiff.Status != FileUnchanged {
+			edits := Lines(SplitLines(oldContent), SplitLines(newContent))
+			for _, edit := range edits {
+				switch edit.Op {
+				case Insert:
+					fileDiff.Additions++
+				case Delete:
+					fileDiff.Deletions++
+				}
+			}
+			fileDiff.Patch = Unified(oldName, newName, edits, 3)
+		}
+
+		diffs = append(diffs, fileDiff)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+
+	return diffs
+}
@@ -0,0 +1,142 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b string
+		want []Line
+	}{
+		{"Equal", "a\nb\n", "a\nb\n", []Line{{Equal, "a"}, {Equal, "b"}}},
+		{"Added", "", "a\n", []Line{{Insert, "a"}}},
+		{"Removed", "a\n", "", []Line{{Delete, "a"}}},
+		{"Changed Line", "a\nb\nc\n", "a\nx\nc\n", []Line{{Equal, "a"}, {Delete, "b"}, {Insert, "x"}, {Equal, "c"}}},
+		{
+			"Deletions Before Insertions",
+			"a\nb\nc\nd\n", "x\ny\nb\nd\n",
+			[]Line{{Delete, "a"}, {Insert, "x"}, {Insert, "y"}, {Equal, "b"}, {Delete, "c"}, {Equal, "d"}},
+		},
+		{"Windows Line Endings", "a\r\nb\r\n", "a\nb\n", []Line{{Equal, "a"}, {Equal, "b"}}},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Lines(SplitLines(tt.a), SplitLines(tt.b)))
+		})
+	}
+}
+
+// lcsLength is the length of the longest common subsequence of a and b, for checking
+// that edit scripts are as short as possible.
+func lcsLength(a, b []string) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				table[i][j] = table[i+1][j+1] + 1
+			case table[i+1][j] >= table[i][j+1]:
+				table[i][j] = table[i+1][j]
+			default:
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	return table[0][0]
+}
+
+func TestLinesShortest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rng.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rng.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		edits := Lines(a, b)
+
+		gotA, gotB := []string{}, []string{}
+		equal := 0
+		for _, edit := range edits {
+			if edit.Op != Insert {
+				gotA = append(gotA, edit.Text)
+			}
+			if edit.Op != Delete {
+				gotB = append(gotB, edit.Text)
+			}
+			if edit.Op == Equal {
+				equal++
+			}
+		}
+
+		require.Equal(t, a, gotA, "edits of %q to %q", a, b)
+		require.Equal(t, b, gotB, "edits of %q to %q", a, b)
+		require.Equal(t, lcsLength(a, b), equal, "edits of %q to %q", a, b)
+	}
+}
+
+func TestLinesLargeFiles(t *testing.T) {
+	// A table of the longest common subsequence of these would need tens of gigabytes.
+	a := make([]string, 100000)
+	for i := range a {
+		a[i] = fmt.Sprintf("line %d", i)
+	}
+	b := append([]string{}, a...)
+	b[50000] = "changed"
+	b = append(b[:70000], b[70010:]...)
+
+	additions, deletions := 0, 0
+	for _, edit := range Lines(a, b) {
+		switch edit.Op {
+		case Insert:
+			additions++
+		case Delete:
+			deletions++
+		}
+	}
+
+	assert.Equal(t, 1, additions)
+	assert.Equal(t, 11, deletions)
+}
+
+func TestUnified(t *testing.T) {
+	a := SplitLines("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n")
+	b := SplitLines("1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n")
+
+	want := strings.Join([]string{
+		"--- a/file",
+		"+++ b/file",
+		"@@ -1,6 +1,6 @@",
+		" 1",
+		" 2",
+		"-3",
+		"+three",
+		" 4",
+		" 5",
+		" 6",
+		"@@ -8,3 +8,4 @@",
+		" 8",
+		" 9",
+		" 10",
+		"+11",
+		"",
+	}, "\n")
+	assert.Equal(t, want, Unified("a/file", "b/file", Lines(a, b), 3))
+	assert.Equal(t, "", Unified("a/file", "b/file", Lines(a, a), 3))
+}
@@ -0,0 +1,203 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	// FormatCSV is a plain CSV with the raw score, penalty and final grade of each item.
+	FormatCSV Format = "CSV"
+	// FormatMoodle matches Moodle's gradebook CSV import, mapped by ID number.
+	FormatMoodle Format = "MOODLE"
+	// FormatCanvas matches the CSV Canvas exports from and imports into its gradebook.
+	FormatCanvas Format = "CANVAS"
+	// FormatBlackboard matches Blackboard's Grade Centre offline upload.
+	FormatBlackboard Format = "BLACKBOARD"
+)
+
+// ContentType is the media type of every export format.
+const ContentType = "text/csv"
+
+// ParseFormat parses a format name case-insensitively.
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToUpper(name))
+	switch format {
+	case FormatCSV, FormatMoodle, FormatCanvas, FormatBlackboard:
+		return format, nil
+	}
+
+	return "", fmt.Errorf("unknown export format %q", name)
+}
+
+var unsafeFilename = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// Filename returns the name the export should be downloaded as.
+func Filename(gradebook *Gradebook, format Format) string {
+	name := strings.Trim(unsafeFilename.ReplaceAllString(gradebook.Title, "-"), "-")
+	if name == "" {
+		name = "grades"
+	}
+
+	return fmt.Sprintf("%s-%s.csv", strings.ToLower(name), strings.ToLower(string(format)))
+}
+
+// Write writes the gradebook as CSV in the given format.
+func Write(w io.Writer, gradebook *Gradebook, format Format) error {
+	var records [][]string
+	switch format {
+	case FormatCSV:
+		records = plainRecords(gradebook)
+	case FormatMoodle:
+		records = moodleRecords(gradebook)
+	case FormatCanvas:
+		records = canvasRecords(gradebook)
+	case FormatBlackboard:
+		records = blackboardRecords(gradebook)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+
+	for _, record := range records {
+		for i, cell := range record {
+			record[i] = escapeFormula(cell)
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	err := writer.WriteAll(records)
+	if err != nil {
+		return fmt.Errorf("error writing export: %w", err)
+	}
+
+	return nil
+}
+
+func plainRecords(gradebook *Gradebook) [][]string {
+	header := []string{"Student ID", "Name", "Email"}
+	for _, item := range gradebook.Items {
+		header = append(header, item.Name+" Raw", item.Name+" Penalty", item.Name+" Final")
+	}
+
+	records := [][]string{header}
+	for _, student := range gradebook.Students {
+		record := []string{student.StudentNumber, student.Name, student.Email}
+		for _, entry := range student.Entries {
+			if entry == nil {
+				record = append(record, "", "", "")
+				continue
+			}
+			record = append(record, formatPoints(entry.Raw), formatPoints(entry.Penalty), formatPoints(entry.Final))
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func moodleRecords(gradebook *Gradebook) [][]string {
+	header := []string{"ID number", "First name", "Surname", "Email address"}
+	for _, item := range gradebook.Items {
+		header = append(header, item.Name)
+	}
+
+	records := [][]string{header}
+	for _, student := range gradebook.Students {
+		first, last := splitName(student.Name)
+		records = append(records, append([]string{student.StudentNumber, first, last, student.Email}, finals(student)...))
+	}
+
+	return records
+}
+
+func canvasRecords(gradebook *Gradebook) [][]string {
+	header := []string{"Student", "ID", "SIS User ID", "SIS Login ID", "Section"}
+	possible := []string{"    Points Possible", "", "", "", ""}
+	for _, item := range gradebook.Items {
+		header = append(header, item.Name)
+		possible = append(possible, formatPoints(item.Max))
+	}
+
+	records := [][]string{header, possible}
+	for _, student := range gradebook.Students {
+		// Canvas matches rows on SIS User ID, so its internal ID is left blank.
+		first, last := splitName(student.Name)
+		name := last
+		if first != "" {
+			name = last + ", " + first
+		}
+		records = append(records, append([]string{name, "", student.StudentNumber, student.Email, gradebook.Section}, finals(student)...))
+	}
+
+	return records
+}
+
+func blackboardRecords(gradebook *Gradebook) [][]string {
+	header := []string{"Last Name", "First Name", "Username", "Student ID"}
+	for _, item := range gradebook.Items {
+		header = append(header, fmt.Sprintf("%s [Total Pts: %s Score]", item.Name, formatPoints(item.Max)))
+	}
+
+	records := [][]string{header}
+	for _, student := range gradebook.Students {
+		first, last := splitName(student.Name)
+		username, _, _ := strings.Cut(student.Email, "@")
+		records = append(records, append([]string{last, first, username, student.StudentNumber}, finals(student)...))
+	}
+
+	return records
+}
+
+// escapeFormula stops spreadsheets from evaluating a cell as a formula, by prefixing
+// text that starts with a formula character with a quote. Names and item titles come
+// from users, so could otherwise run formulas when a teacher opens the export. Numbers
+// are left alone so negative points still import as numbers.
+func escapeFormula(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+
+	return "'" + cell
+}
+
+// finals returns the final grade of each of a student's entries, blank where the
+// student has no submission.
+func finals(student Student) []string {
+	var values []string
+	for _, entry := range student.Entries {
+		if entry == nil {
+			values = append(values, "")
+			continue
+		}
+		values = append(values, formatPoints(entry.Final))
+	}
+
+	return values
+}
+
+// splitName splits a full name into first names and a surname, treating the last word
+// as the surname.
+func splitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	i := strings.LastIndex(name, " ")
+	if i < 0 {
+		return "", name
+	}
+
+	return strings.TrimSpace(name[:i]), name[i+1:]
+}
+
+// formatPoints rounds points to two decimal places, dropping trailing zeros.
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(math.Round(points*100)/100, 'f', -1, 64)
+}
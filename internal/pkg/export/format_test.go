@@ -0,0 +1,49 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	for _, tt := range []struct {
+		cell, want string
+	}{
+		{"", ""},
+		{"Alice Penguin", "Alice Penguin"},
+		{"=HYPERLINK(\"http://example.com\")", "'=HYPERLINK(\"http://example.com\")"},
+		{"+1+2", "'+1+2"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"-1.5", "-1.5"},
+		{"+3", "+3"},
+		{"a=1", "a=1"},
+	} {
+		assert.Equal(t, tt.want, escapeFormula(tt.cell), "escaping %q", tt.cell)
+	}
+}
+
+func TestWriteEscapesFormulas(t *testing.T) {
+	gradebook := &Gradebook{
+		Title: "Assignment 1",
+		Items: []Item{{Name: "=1+1", Max: 10}},
+		Students: []Student{
+			{StudentNumber: "s0001", Name: "@Alice Penguin", Email: "alice@example.com", Entries: []*Entry{{Raw: 6, Penalty: -1, Final: 5}}},
+		},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatMoodle, FormatCanvas, FormatBlackboard} {
+		var out strings.Builder
+		require.NoError(t, Write(&out, gradebook, format))
+
+		assert.NotRegexp(t, `(^|\n|,)[=@]`, out.String(), format)
+	}
+
+	var out strings.Builder
+	require.NoError(t, Write(&out, gradebook, FormatCSV))
+	assert.Equal(t, "Student ID,Name,Email,'=1+1 Raw,'=1+1 Penalty,'=1+1 Final\ns0001,'@Alice Penguin,alice@example.com,6,-1,5\n", out.String())
+}
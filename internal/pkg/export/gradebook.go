@@ -0,0 +1,208 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/grading"
+)
+
+// Gradebook is a table of grades with a row per student and a graded item per
+// assignment, ready to be written in one of the export formats.
+type Gradebook struct {
+	Title string
+	// Section is the class the grades belong to.
+	Section  string
+	Items    []Item
+	Students []Student
+}
+
+// Item is a graded column of a gradebook.
+type Item struct {
+	Name string
+	Max  float64
+}
+
+// Student is a row of a gradebook. Entries line up with the gradebook's items, with
+// nil entries for items the student has no submission for.
+type Student struct {
+	StudentNumber string
+	Name          string
+	Email         string
+	Entries       []*Entry
+}
+
+// Entry is a student's grade for a single item.
+type Entry struct {
+	// Raw is the grade before any late penalty.
+	Raw float64
+	// Penalty is the number of points deducted for lateness.
+	Penalty float64
+	Final   float64
+}
+
+// AssignmentGradebook builds a gradebook for a single assignment, as seen by the
+// viewer.
+func AssignmentGradebook(database db.Database, assignmentID string, viewer *models.User) (*Gradebook, error) {
+	assignment, err := database.GetAssignment(assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignment: %w", err)
+	}
+
+	class, err := database.GetClass(fmt.Sprintf("%d", assignment.ClassID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting class: %w", err)
+	}
+
+	return build(database, assignment.Name, class, []*models.Assignment{assignment}, viewer)
+}
+
+// ClassGradebook builds a gradebook for every assignment in a class, as seen by the
+// viewer.
+func ClassGradebook(database db.Database, classID string, viewer *models.User) (*Gradebook, error) {
+	class, err := database.GetClass(classID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting class: %w", err)
+	}
+
+	assignments, err := database.GetAssignmentsForClass(class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting assignments: %w", err)
+	}
+
+	return build(database, class.Name, class, assignments, viewer)
+}
+
+// build grades every submission for the assignments. Enrolled students are always
+// included, while submissions that aren't matched to a student get a row of their
+// own keyed by the identifier they were submitted under. Each member of a group is
+// graded for the group's submission. Submissions the viewer is blind second marking
+// are left blank.
+func build(database db.Database, title string, class *models.Class, assignments []*models.Assignment, viewer *models.User) (*Gradebook, error) {
+	gradebook := &Gradebook{Title: title, Section: class.Name}
+
+	students, err := database.GetStudentsForClass(class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting students: %w", err)
+	}
+
+	rows := map[string]*Student{}
+	var keys []string
+	addRow := func(key string, student Student) *Student {
+		row, ok := rows[key]
+		if !ok {
+			student.Entries = make([]*Entry, len(assignments))
+			row = &student
+			rows[key] = row
+			keys = append(keys, key)
+		}
+
+		return row
+	}
+
+	for _, student := range students {
+		addRow(fmt.Sprintf("student:%d", student.ID), Student{
+			StudentNumber: student.StudentNumber,
+			Name:          student.Name,
+			Email:         student.Email,
+		})
+	}
+
+	for i, assignment := range assignments {
+		max, err := grading.LoadMaxGrade(database, assignment)
+		if err != nil {
+			return nil, err
+		}
+		gradebook.Items = append(gradebook.Items, Item{Name: assignment.Name, Max: max})
+
+		submissions, err := database.GetSubmissionsForAssignment(fmt.Sprintf("%d", assignment.ID))
+		if err != nil {
+			return nil, fmt.Errorf("error getting submissions: %w", err)
+		}
+
+		blind, err := blindSubmissions(database, assignment, viewer)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, submission := range submissions {
+			if blind[submission.ID] {
+				continue
+			}
+
+			if submission.GroupID != nil {
+				memberGrades, err := grading.LoadMemberGrades(database, assignment, submission)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, memberGrade := range memberGrades {
+					row := addRow(fmt.Sprintf("student:%d", memberGrade.Student.ID), Student{
+						StudentNumber: memberGrade.Student.StudentNumber,
+						Name:          memberGrade.Student.Name,
+						Email:         memberGrade.Student.Email,
+					})
+					row.Entries[i] = newEntry(memberGrade.Grade)
+				}
+				continue
+			}
+
+			var row *Student
+			if submission.StudentRecordID != nil {
+				row = addRow(fmt.Sprintf("student:%d", *submission.StudentRecordID), Student{})
+			} else {
+				row = addRow("submission:"+submission.StudentID, Student{
+					StudentNumber: models.ParseStudentNumber(submission.StudentID),
+					Name:          submission.StudentID,
+				})
+			}
+
+			grade, err := grading.LoadGrade(database, assignment, submission)
+			if err != nil {
+				return nil, err
+			}
+
+			row.Entries[i] = newEntry(grade)
+		}
+	}
+
+	for _, key := range keys {
+		gradebook.Students = append(gradebook.Students, *rows[key])
+	}
+	sort.SliceStable(gradebook.Students, func(i, j int) bool {
+		return gradebook.Students[i].StudentNumber < gradebook.Students[j].StudentNumber
+	})
+
+	return gradebook, nil
+}
+
+// blindSubmissions returns the IDs of the assignment's submissions the viewer is
+// waiting to second mark.
+func blindSubmissions(database db.Database, assignment *models.Assignment, viewer *models.User) (map[uint]bool, error) {
+	blind := map[uint]bool{}
+	if viewer.Role == models.UserRoleAdmin {
+		return blind, nil
+	}
+
+	samples, err := database.GetModerationSamples(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting moderation samples: %w", err)
+	}
+	for _, sample := range samples {
+		if sample.BlindTo(viewer) {
+			blind[sample.SubmissionID] = true
+		}
+	}
+
+	return blind, nil
+}
+
+func newEntry(grade grading.Grade) *Entry {
+	return &Entry{
+		Raw:     grade.Total(),
+		Penalty: grade.Total() - grade.Final(),
+		Final:   grade.Final(),
+	}
+}
@@ -0,0 +1,74 @@
+package grading
+
+import (
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+// LatestResults returns the most recent result recorded for each submission version,
+// keyed by version ID. Results without a version are ignored.
+func LatestResults(results []*models.Result) map[uint]*models.Result {
+	latest := map[uint]*models.Result{}
+	for _, result := range results {
+		if result.SubmissionVersionID == nil {
+			continue
+		}
+
+		existing, ok := latest[*result.SubmissionVersionID]
+		if !ok || result.CreatedAt.After(existing.CreatedAt) || (result.CreatedAt.Equal(existing.CreatedAt) && result.ID > existing.ID) {
+			latest[*result.SubmissionVersionID] = result
+		}
+	}
+
+	return latest
+}
+
+// CountedVersion returns the version that counts towards the grade under the policy,
+// along with its result if it has one. Under AttemptPolicyBest only versions with a
+// result are considered and ties go to the earlier version; if no version has a result
+// the latest version counts.
+func CountedVersion(policy models.AttemptPolicy, versions []*models.SubmissionVersion, results []*models.Result) (*models.SubmissionVersion, *models.Result) {
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	latestResults := LatestResults(results)
+
+	first, latest := versions[0], versions[0]
+	for _, version := range versions {
+		if version.Number < first.Number {
+			first = version
+		}
+		if version.Number > latest.Number {
+			latest = version
+		}
+	}
+
+	switch policy {
+	case models.AttemptPolicyFirst:
+		return first, latestResults[first.ID]
+	case models.AttemptPolicyBest:
+		var best *models.SubmissionVersion
+		for _, version := range versions {
+			result, ok := latestResults[version.ID]
+			if !ok {
+				continue
+			}
+
+			if best == nil {
+				best = version
+				continue
+			}
+
+			bestScore := latestResults[best.ID].Score
+			if result.Score > bestScore || (result.Score == bestScore && version.Number < best.Number) {
+				best = version
+			}
+		}
+
+		if best != nil {
+			return best, latestResults[best.ID]
+		}
+	}
+
+	return latest, latestResults[latest.ID]
+}
@@ -0,0 +1,137 @@
+package grading
+
+import (
+	"math"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+// RubricScore is the total of the levels selected for a submission's rubric.
+type RubricScore struct {
+	Total float64
+	Max   float64
+	// Marked is the number of criteria with a selected level.
+	Marked   int
+	Criteria int
+}
+
+// ScoreRubric totals the points of the level selected for each criterion. The maximum
+// is the sum of each criterion's highest level. Marks for levels that are no longer
+// part of the rubric are ignored.
+func ScoreRubric(criteria []*models.RubricCriterion, marks []*models.RubricMark) RubricScore {
+	selected := map[uint]uint{}
+	for _, mark := range marks {
+		selected[mark.CriterionID] = mark.LevelID
+	}
+
+	score := RubricScore{Criteria: len(criteria)}
+	for _, criterion := range criteria {
+		score.Max += criterion.MaxPoints()
+
+		levelID, ok := selected[criterion.ID]
+		if !ok {
+			continue
+		}
+
+		for _, level := range criterion.Levels {
+			if level.ID == levelID {
+				score.Total += level.Points
+				score.Marked++
+				break
+			}
+		}
+	}
+
+	return score
+}
+
+// DeductLint totals the marks deducted for a submission version's lint findings,
+// capping each rule's deduction at its maximum. Findings of rules that are no longer
+// part of the assignment are ignored.
+func DeductLint(rules []*models.LintRule, findings []*models.LintFinding) float64 {
+	counts := map[uint]int{}
+	for _, finding := range findings {
+		counts[finding.RuleID]++
+	}
+
+	deduction := 0.0
+	for _, rule := range rules {
+		deduction += rule.DeductionFor(counts[rule.ID])
+	}
+
+	return deduction
+}
+
+// Grade combines the automated test score and rubric marks of a submission. The
+// final grade is the sum of the two, less any lint deductions and then any late
+// penalty, out of the sum of their maximums.
+type Grade struct {
+	Automated    float64
+	AutomatedMax float64
+	Rubric       float64
+	RubricMax    float64
+	// Deductions are the marks deducted for lint findings.
+	Deductions float64
+	// Moderated is the agreed mark from resolving a moderation discrepancy, which
+	// replaces the marked total.
+	Moderated *float64
+	// Adjustment is added to a group's grade for one of its members.
+	Adjustment float64
+	// Penalty is the percentage deducted for lateness.
+	Penalty float64
+	// Complete is set once every rubric criterion has been marked.
+	Complete bool
+	// Marked is set once the counted version has a result or a rubric criterion has
+	// been marked.
+	Marked bool
+}
+
+func NewGrade(score Score, rubric RubricScore, deductions float64, lateness Lateness) Grade {
+	return Grade{
+		Automated:    score.Total,
+		AutomatedMax: score.Max,
+		Rubric:       rubric.Total,
+		RubricMax:    rubric.Max,
+		Deductions:   deductions,
+		Penalty:      lateness.Penalty,
+		Complete:     rubric.Marked == rubric.Criteria,
+		Marked:       rubric.Marked > 0,
+	}
+}
+
+// Total returns the grade after lint deductions, moderation and any member adjustment,
+// but before any late penalty. It is never negative, and an adjustment can't take it
+// above the maximum.
+func (g Grade) Total() float64 {
+	total := g.Automated + g.Rubric - g.Deductions
+	if g.Moderated != nil {
+		total = *g.Moderated
+	}
+	total += g.Adjustment
+	if g.Adjustment > 0 && total > g.Max() {
+		total = math.Max(g.Max(), total-g.Adjustment)
+	}
+	if total < 0 {
+		return 0
+	}
+
+	return total
+}
+
+func (g Grade) Max() float64 {
+	return g.AutomatedMax + g.RubricMax
+}
+
+// Final returns the grade after any late penalty.
+func (g Grade) Final() float64 {
+	return AdjustScore(g.Total(), Lateness{Penalty: g.Penalty})
+}
+
+// Percentage returns the final grade as a percentage of the maximum.
+func (g Grade) Percentage() float64 {
+	if g.Max() == 0 {
+		return 0
+	}
+
+	return g.Final() / g.Max() * 100
+}
@@ -0,0 +1,85 @@
+package grading
+
+import (
+	"time"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+const day = 24 * time.Hour
+
+// Lateness describes how late a submission was and the penalty it attracts.
+type Lateness struct {
+	// DueDate is the due date the submission was assessed against, which may have been
+	// extended for the student.
+	DueDate  time.Time
+	Extended bool
+	// Late is set when the submission was made after the due date and grace period.
+	Late bool
+	// Duration is how long after the due date the submission was made, including any
+	// grace period.
+	Duration time.Duration
+	// DaysLate counts part days as whole days.
+	DaysLate   int
+	PastCutoff bool
+	// Penalty is the percentage of the score deducted.
+	Penalty float64
+}
+
+// AssessLateness applies the late policy to a submission made at submitted for an
+// assignment due at due. Assignments without a due date are never late.
+func AssessLateness(policy models.LatePolicy, due, submitted time.Time) Lateness {
+	if due.IsZero() || !submitted.After(due) {
+		return Lateness{DueDate: due}
+	}
+
+	lateness := Lateness{DueDate: due, Duration: submitted.Sub(due)}
+	lateness.DaysLate = int((lateness.Duration + day - 1) / day)
+
+	if policy.Cutoff != nil && submitted.After(*policy.Cutoff) {
+		lateness.Late = true
+		lateness.PastCutoff = true
+		lateness.Penalty = 100
+		return lateness
+	}
+
+	if lateness.Duration <= policy.GracePeriod {
+		lateness.DaysLate = 0
+		return lateness
+	}
+
+	lateness.Late = true
+	lateness.Penalty = float64(lateness.DaysLate) * policy.PenaltyPerDay
+	if policy.MaxPenalty > 0 && lateness.Penalty > policy.MaxPenalty {
+		lateness.Penalty = policy.MaxPenalty
+	}
+	if lateness.Penalty > 100 {
+		lateness.Penalty = 100
+	}
+
+	return lateness
+}
+
+// AssessLatenessWithExtension is AssessLateness for a student who may have an
+// extension. The extension replaces the due date and moves any cutoff by the same
+// amount, so the student keeps the same window for late submissions.
+func AssessLatenessWithExtension(policy models.LatePolicy, due, submitted time.Time, extension *models.Extension) Lateness {
+	if extension == nil {
+		return AssessLateness(policy, due, submitted)
+	}
+
+	if policy.Cutoff != nil {
+		cutoff := policy.Cutoff.Add(extension.DueDate.Sub(due))
+		policy.Cutoff = &cutoff
+	}
+
+	lateness := AssessLateness(policy, extension.DueDate, submitted)
+	lateness.Extended = true
+
+	return lateness
+}
+
+// AdjustScore deducts the late penalty from a raw score.
+func AdjustScore(score float64, lateness Lateness) float64 {
+	return score * (100 - lateness.Penalty) / 100
+}
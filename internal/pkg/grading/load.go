@@ -0,0 +1,333 @@
+package grading
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+// LoadCountedVersion returns the version of a submission that counts under the
+// assignment's attempt policy, along with that version's result if it has one.
+func LoadCountedVersion(database db.Database, assignment *models.Assignment, submission *models.Submission) (*models.SubmissionVersion, *models.Result, error) {
+	versions, err := database.GetSubmissionVersions(submission.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting versions: %w", err)
+	}
+
+	results, err := database.GetResultsForSubmission(submission.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting results: %w", err)
+	}
+
+	version, result := CountedVersion(assignment.AttemptPolicy, versions, results)
+
+	return version, result, nil
+}
+
+// LoadLateness assesses a submission against the assignment's late policy and the
+// student's extension, using the time the version was submitted if given.
+func LoadLateness(database db.Database, assignment *models.Assignment, submission *models.Submission, version *models.SubmissionVersion) (Lateness, error) {
+	submittedAt := submission.CreatedAt
+	if version != nil {
+		submittedAt = version.CreatedAt
+	}
+
+	var extension *models.Extension
+	if submission.StudentRecordID != nil {
+		var err error
+		extension, err = database.GetExtensionForStudent(*submission.StudentRecordID, assignment.ID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return Lateness{}, fmt.Errorf("error getting extension: %w", err)
+		}
+	}
+
+	return AssessLatenessWithExtension(assignment.LatePolicy, assignment.DueDate, submittedAt, extension), nil
+}
+
+// LoadScore aggregates the test outcomes of a submission version. A nil version has no
+// outcomes, so scores zero out of the assignment's maximum.
+func LoadScore(database db.Database, assignmentID uint, version *models.SubmissionVersion) (Score, error) {
+	tests, err := database.GetTestsForAssignment(fmt.Sprintf("%d", assignmentID))
+	if err != nil {
+		return Score{}, fmt.Errorf("error getting tests: %w", err)
+	}
+
+	var outcomes []*models.TestOutcome
+	if version != nil {
+		outcomes, err = database.GetTestOutcomesForVersion(version.ID)
+		if err != nil {
+			return Score{}, fmt.Errorf("error getting test outcomes: %w", err)
+		}
+	}
+
+	return Aggregate(tests, outcomes), nil
+}
+
+// LoadDeductions returns the marks deducted for a submission version's lint findings.
+// Findings are only loaded if one of the assignment's rules deducts marks.
+func LoadDeductions(database db.Database, assignmentID uint, version *models.SubmissionVersion) (float64, error) {
+	rules, err := database.GetLintRules(assignmentID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting lint rules: %w", err)
+	}
+
+	deducts := false
+	for _, rule := range rules {
+		deducts = deducts || rule.Deduction > 0
+	}
+	if !deducts || version == nil {
+		return 0, nil
+	}
+
+	findings, err := database.GetLintFindings(version.ID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting lint findings: %w", err)
+	}
+
+	return DeductLint(rules, findings), nil
+}
+
+// LoadGrade combines the counted version's test score, or its overridden result, with
+// the submission's rubric marks, lint deductions and late penalty. The agreed mark of a
+// resolved moderation discrepancy replaces the marked total.
+func LoadGrade(database db.Database, assignment *models.Assignment, submission *models.Submission) (Grade, error) {
+	version, result, err := LoadCountedVersion(database, assignment, submission)
+	if err != nil {
+		return Grade{}, err
+	}
+
+	score, err := LoadScore(database, assignment.ID, version)
+	if err != nil {
+		return Grade{}, err
+	}
+
+	criteria, err := database.GetRubric(assignment.ID)
+	if err != nil {
+		return Grade{}, fmt.Errorf("error getting rubric: %w", err)
+	}
+
+	marks, err := database.GetRubricMarks(submission.ID)
+	if err != nil {
+		return Grade{}, fmt.Errorf("error getting rubric marks: %w", err)
+	}
+
+	deductions, err := LoadDeductions(database, assignment.ID, version)
+	if err != nil {
+		return Grade{}, err
+	}
+
+	lateness, err := LoadLateness(database, assignment, submission, version)
+	if err != nil {
+		return Grade{}, err
+	}
+
+	sample, err := database.GetModerationSample(submission.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return Grade{}, fmt.Errorf("error getting moderation sample: %w", err)
+	}
+
+	return combine(score, result, ScoreRubric(criteria, marks), deductions, lateness, sample), nil
+}
+
+// combine builds a grade from its parts. A tutor's override of the result takes
+// precedence over the score from the test outcomes, and the agreed mark of a resolved
+// moderation discrepancy replaces the marked total.
+func combine(score Score, result *models.Result, rubric RubricScore, deductions float64, lateness Lateness, sample *models.ModerationSample) Grade {
+	if result != nil && result.OriginalScore != nil {
+		score.Total = result.Score
+	}
+
+	grade := NewGrade(score, rubric, deductions, lateness)
+	grade.Marked = grade.Marked || result != nil
+
+	if sample != nil && sample.Status == models.ModerationStatusResolved {
+		grade.Moderated = sample.AgreedMark
+	}
+
+	return grade
+}
+
+// LoadGrades grades each of the assignment's submissions the same way as LoadGrade, but
+// loads the inputs once for the whole assignment rather than once per submission. The
+// grades are keyed by submission ID.
+func LoadGrades(database db.Database, assignment *models.Assignment, submissions []*models.Submission) (map[uint]Grade, error) {
+	tests, err := database.GetTestsForAssignment(fmt.Sprintf("%d", assignment.ID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting tests: %w", err)
+	}
+
+	criteria, err := database.GetRubric(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rubric: %w", err)
+	}
+
+	rules, err := database.GetLintRules(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting lint rules: %w", err)
+	}
+
+	versions, err := database.GetSubmissionVersionsForAssignment(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting versions: %w", err)
+	}
+	versionsBySubmission := map[uint][]*models.SubmissionVersion{}
+	for _, version := range versions {
+		versionsBySubmission[version.SubmissionID] = append(versionsBySubmission[version.SubmissionID], version)
+	}
+
+	results, err := database.GetResultsForAssignment(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting results: %w", err)
+	}
+	resultsBySubmission := map[uint][]*models.Result{}
+	for _, result := range results {
+		resultsBySubmission[result.SubmissionID] = append(resultsBySubmission[result.SubmissionID], result)
+	}
+
+	outcomes, err := database.GetTestOutcomesForAssignment(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting test outcomes: %w", err)
+	}
+	outcomesByVersion := map[uint][]*models.TestOutcome{}
+	for _, outcome := range outcomes {
+		outcomesByVersion[outcome.SubmissionVersionID] = append(outcomesByVersion[outcome.SubmissionVersionID], outcome)
+	}
+
+	marks, err := database.GetRubricMarksForAssignment(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rubric marks: %w", err)
+	}
+	marksBySubmission := map[uint][]*models.RubricMark{}
+	for _, mark := range marks {
+		marksBySubmission[mark.SubmissionID] = append(marksBySubmission[mark.SubmissionID], mark)
+	}
+
+	// As in LoadDeductions, findings are only loaded if one of the rules deducts marks.
+	findingsByVersion := map[uint][]*models.LintFinding{}
+	deducts := false
+	for _, rule := range rules {
+		deducts = deducts || rule.Deduction > 0
+	}
+	if deducts {
+		findings, err := database.GetLintFindingsForAssignment(assignment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting lint findings: %w", err)
+		}
+		for _, finding := range findings {
+			findingsByVersion[finding.SubmissionVersionID] = append(findingsByVersion[finding.SubmissionVersionID], finding)
+		}
+	}
+
+	extensions, err := database.GetExtensionsForAssignment(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting extensions: %w", err)
+	}
+	extensionsByStudent := map[uint]*models.Extension{}
+	for _, extension := range extensions {
+		extensionsByStudent[extension.StudentID] = extension
+	}
+
+	samples, err := database.GetModerationSamples(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting moderation samples: %w", err)
+	}
+	samplesBySubmission := map[uint]*models.ModerationSample{}
+	for _, sample := range samples {
+		samplesBySubmission[sample.SubmissionID] = sample
+	}
+
+	grades := map[uint]Grade{}
+	for _, submission := range submissions {
+		version, result := CountedVersion(assignment.AttemptPolicy, versionsBySubmission[submission.ID], resultsBySubmission[submission.ID])
+
+		var versionOutcomes []*models.TestOutcome
+		var versionFindings []*models.LintFinding
+		submittedAt := submission.CreatedAt
+		if version != nil {
+			versionOutcomes = outcomesByVersion[version.ID]
+			versionFindings = findingsByVersion[version.ID]
+			submittedAt = version.CreatedAt
+		}
+
+		var extension *models.Extension
+		if submission.StudentRecordID != nil {
+			extension = extensionsByStudent[*submission.StudentRecordID]
+		}
+
+		deductions := 0.0
+		if deducts && version != nil {
+			deductions = DeductLint(rules, versionFindings)
+		}
+
+		lateness := AssessLatenessWithExtension(assignment.LatePolicy, assignment.DueDate, submittedAt, extension)
+		grades[submission.ID] = combine(Aggregate(tests, versionOutcomes), result, ScoreRubric(criteria, marksBySubmission[submission.ID]), deductions, lateness, samplesBySubmission[submission.ID])
+	}
+
+	return grades, nil
+}
+
+// MemberGrade is a group member's share of a group submission's grade.
+type MemberGrade struct {
+	Student *models.Student
+	Grade   Grade
+	// Reason explains the member's adjustment, if they have one.
+	Reason string
+}
+
+// LoadMemberGrades gives each member of the group that made a submission the group's
+// grade with their own adjustment. Members share the group's late penalty.
+func LoadMemberGrades(database db.Database, assignment *models.Assignment, submission *models.Submission) ([]MemberGrade, error) {
+	if submission.GroupID == nil {
+		return nil, nil
+	}
+
+	grade, err := LoadGrade(database, assignment, submission)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := database.GetGroupMembers(*submission.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting group members: %w", err)
+	}
+
+	adjustments, err := database.GetMemberAdjustments(submission.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting member adjustments: %w", err)
+	}
+
+	byStudent := map[uint]*models.MemberAdjustment{}
+	for _, adjustment := range adjustments {
+		byStudent[adjustment.StudentID] = adjustment
+	}
+
+	memberGrades := []MemberGrade{}
+	for _, member := range members {
+		memberGrade := MemberGrade{Student: member, Grade: grade}
+		if adjustment, ok := byStudent[member.ID]; ok {
+			memberGrade.Grade.Adjustment = adjustment.Marks
+			memberGrade.Reason = adjustment.Reason
+		}
+		memberGrades = append(memberGrades, memberGrade)
+	}
+
+	return memberGrades, nil
+}
+
+// LoadMaxGrade returns the most a submission can score for the assignment, from its
+// tests and rubric.
+func LoadMaxGrade(database db.Database, assignment *models.Assignment) (float64, error) {
+	score, err := LoadScore(database, assignment.ID, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	criteria, err := database.GetRubric(assignment.ID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting rubric: %w", err)
+	}
+
+	return score.Max + ScoreRubric(criteria, nil).Max, nil
+}
@@ -0,0 +1,69 @@
+package grading
+
+import (
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+// TestScore is a single test's contribution to an assignment total.
+type TestScore struct {
+	Test *models.Test
+	// Points is nil if the test has no outcome for the submission.
+	Points      *float64
+	Weighted    float64
+	WeightedMax float64
+}
+
+// Score is an assignment total along with the contribution of each test.
+type Score struct {
+	Total float64
+	Max   float64
+	Tests []TestScore
+}
+
+// Percentage returns the total as a percentage of the maximum score.
+func (s Score) Percentage() float64 {
+	if s.Max == 0 {
+		return 0
+	}
+
+	return s.Total / s.Max * 100
+}
+
+// Aggregate totals the outcomes of an assignment's tests. Each test contributes its
+// points, clamped between 0 and the test's MaxPoints, multiplied by its Weight, so
+// the maximum score is the sum of Weight * MaxPoints over all tests. Tests without
+// an outcome contribute nothing, and where a test has several outcomes the most
+// recent counts.
+func Aggregate(tests []*models.Test, outcomes []*models.TestOutcome) Score {
+	latest := map[uint]*models.TestOutcome{}
+	for _, outcome := range outcomes {
+		existing, ok := latest[outcome.TestID]
+		if !ok || outcome.CreatedAt.After(existing.CreatedAt) || (outcome.CreatedAt.Equal(existing.CreatedAt) && outcome.ID > existing.ID) {
+			latest[outcome.TestID] = outcome
+		}
+	}
+
+	var score Score
+	for _, test := range tests {
+		testScore := TestScore{Test: test, WeightedMax: test.MaxPoints * test.Weight}
+
+		if outcome, ok := latest[test.ID]; ok {
+			points := outcome.Points
+			if points < 0 {
+				points = 0
+			}
+			if points > test.MaxPoints {
+				points = test.MaxPoints
+			}
+
+			testScore.Points = &points
+			testScore.Weighted = points * test.Weight
+		}
+
+		score.Total += testScore.Weighted
+		score.Max += testScore.WeightedMax
+		score.Tests = append(score.Tests, testScore)
+	}
+
+	return score
+}
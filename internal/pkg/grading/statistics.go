@@ -0,0 +1,98 @@
+package grading
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+// LoadStatistics summarises an assignment, aggregating the final grade of each marked
+// submission so the scores match what students see, with rubric marks, deductions,
+// moderation and late penalties applied. Scores are grouped into the given number of
+// equal width buckets between zero and the assignment's maximum. The grades are loaded
+// with a fixed number of queries however many submissions there are.
+func LoadStatistics(database db.Database, assignment *models.Assignment, buckets int) (*models.AssignmentStatistics, error) {
+	stats, err := database.GetAssignmentStatistics(assignment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting statistics: %w", err)
+	}
+
+	max, err := LoadMaxGrade(database, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := database.GetSubmissionsForAssignment(fmt.Sprintf("%d", assignment.ID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting submissions: %w", err)
+	}
+
+	grades, err := LoadGrades(database, assignment, submissions)
+	if err != nil {
+		return nil, err
+	}
+
+	var scores []float64
+	for _, submission := range submissions {
+		if grade := grades[submission.ID]; grade.Marked {
+			scores = append(scores, grade.Final())
+		}
+	}
+
+	Summarise(stats, scores, max, buckets)
+
+	return stats, nil
+}
+
+// Summarise fills in the score aggregates and histogram of the statistics from the
+// scores, out of max.
+func Summarise(stats *models.AssignmentStatistics, scores []float64, max float64, buckets int) {
+	stats.Graded = len(scores)
+	stats.MaxScore = max
+	stats.Mean, stats.Median, stats.StdDev, stats.Min, stats.Max = nil, nil, nil, nil, nil
+	stats.Histogram = nil
+
+	if buckets > 0 && max > 0 {
+		width := max / float64(buckets)
+		for i := 0; i < buckets; i++ {
+			stats.Histogram = append(stats.Histogram, models.HistogramBucket{Lower: width * float64(i), Upper: width * float64(i+1)})
+		}
+	}
+
+	if len(scores) == 0 {
+		return
+	}
+
+	sorted := append([]float64{}, scores...)
+	sort.Float64s(sorted)
+
+	sum, sumSquares := 0.0, 0.0
+	for _, score := range sorted {
+		sum += score
+		sumSquares += score * score
+
+		if len(stats.Histogram) > 0 {
+			bucket := int(score * float64(buckets) / max)
+			if bucket >= buckets {
+				bucket = buckets - 1
+			}
+			if bucket < 0 {
+				bucket = 0
+			}
+			stats.Histogram[bucket].Count++
+		}
+	}
+
+	n := float64(len(sorted))
+	mean := sum / n
+	// Population standard deviation.
+	stdDev := math.Sqrt(math.Max(sumSquares/n-mean*mean, 0))
+	// The median is the middle score, or the mean of the middle two scores.
+	median := (sorted[(len(sorted)-1)/2] + sorted[len(sorted)/2]) / 2
+	min, max := sorted[0], sorted[len(sorted)-1]
+
+	stats.Mean, stats.Median, stats.StdDev, stats.Min, stats.Max = &mean, &median, &stdDev, &min, &max
+}
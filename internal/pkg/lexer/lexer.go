@@ -0,0 +1,155 @@
+package lexer
+
+import (
+	"path"
+	"strings"
+	"unicode"
+)
+
+type Kind int
+
+const (
+	Identifier Kind = iota
+	Keyword
+	Number
+	String
+	// Punct is an operator or separator.
+	Punct
+	Comment
+)
+
+// Token is a lexical token of Processing or Java source. Line and EndLine are the
+// lines the token starts and ends on, which only differ for block comments.
+type Token struct {
+	Kind    Kind
+	Text    string
+	Line    int
+	EndLine int
+}
+
+var keywords = map[string]bool{}
+
+func init() {
+	for _, keyword := range strings.Fields(`
+		abstract assert boolean break byte case catch char class const continue default do
+		double else enum extends final finally float for goto if implements import instanceof
+		int interface long native new package private protected public return short static
+		strictfp super switch synchronized this throw throws transient try void volatile while
+		true false null var color`) {
+		keywords[keyword] = true
+	}
+}
+
+// SourceExtensions are the extensions of the files the lexer understands.
+var SourceExtensions = []string{".pde", ".java"}
+
+// IsSource reports whether a file is Processing or Java source, based on its extension.
+func IsSource(filePath string) bool {
+	ext := strings.ToLower(path.Ext(filePath))
+	for _, sourceExt := range SourceExtensions {
+		if ext == sourceExt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsKeyword reports whether word is a Java keyword or literal, or Processing's color type.
+func IsKeyword(word string) bool {
+	return keywords[word]
+}
+
+// Operators made up of more than one character, longest first.
+var operators = []string{
+	">>>=", "<<=", ">>=", ">>>", "==", "!=", "<=", ">=", "&&", "||", "++", "--", "+=", "-=",
+	"*=", "/=", "%=", "&=", "|=", "^=", "<<", ">>", "->", "::",
+}
+
+// Lex splits source into tokens, dropping whitespace. Unterminated strings and
+// comments end at the end of the line or file rather than failing, as student code
+// doesn't always compile.
+func Lex(source string) []Token {
+	var tokens []Token
+	line := 1
+	src := []rune(source)
+
+	emit := func(kind Kind, start, end, startLine int) {
+		tokens = append(tokens, Token{Kind: kind, Text: string(src[start:end]), Line: startLine, EndLine: line})
+	}
+
+	for i := 0; i < len(src); {
+		r := src[i]
+		start, startLine := i, line
+
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			emit(Comment, start, i, startLine)
+		case r == '/' && i+1 < len(src) && src[i+1] == '*':
+			i += 2
+			for i < len(src) && !(src[i] == '*' && i+1 < len(src) && src[i+1] == '/') {
+				if src[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			i = minInt(i+2, len(src))
+			emit(Comment, start, i, startLine)
+		case r == '"' || r == '\'':
+			i++
+			for i < len(src) && src[i] != r && src[i] != '\n' {
+				// Skip escaped characters, but not a line break ending an unterminated
+				// string, so that lines are still counted.
+				if src[i] == '\\' && i+1 < len(src) && src[i+1] != '\n' {
+					i++
+				}
+				i++
+			}
+			if i < len(src) && src[i] == r {
+				i++
+			}
+			emit(String, start, i, startLine)
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(src) && unicode.IsDigit(src[i+1])):
+			for i < len(src) && (unicode.IsLetter(src[i]) || unicode.IsDigit(src[i]) || src[i] == '.' || src[i] == '_') {
+				i++
+			}
+			emit(Number, start, i, startLine)
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			for i < len(src) && (unicode.IsLetter(src[i]) || unicode.IsDigit(src[i]) || src[i] == '_' || src[i] == '$') {
+				i++
+			}
+			kind := Identifier
+			if keywords[string(src[start:i])] {
+				kind = Keyword
+			}
+			emit(kind, start, i, startLine)
+		default:
+			i++
+			for _, operator := range operators {
+				if strings.HasPrefix(string(src[start:minInt(start+len(operator), len(src))]), operator) {
+					i = start + len(operator)
+					break
+				}
+			}
+			emit(Punct, start, i, startLine)
+		}
+	}
+
+	return tokens
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
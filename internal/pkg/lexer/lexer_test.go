@@ -0,0 +1,100 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLex(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		source string
+		want   []Token
+	}{
+		{"Empty", "", nil},
+		{"Whitespace", " \t\n\r\n", nil},
+		{
+			"Declaration",
+			"int x = 10;",
+			[]Token{{Keyword, "int", 1, 1}, {Identifier, "x", 1, 1}, {Punct, "=", 1, 1}, {Number, "10", 1, 1}, {Punct, ";", 1, 1}},
+		},
+		{
+			"Processing Color Type",
+			"color c;",
+			[]Token{{Keyword, "color", 1, 1}, {Identifier, "c", 1, 1}, {Punct, ";", 1, 1}},
+		},
+		{
+			"Identifiers",
+			"_tmp $x Penguin2",
+			[]Token{{Identifier, "_tmp", 1, 1}, {Identifier, "$x", 1, 1}, {Identifier, "Penguin2", 1, 1}},
+		},
+		{
+			"Numbers",
+			"2.5f .5 0xFF 1_000L",
+			[]Token{{Number, "2.5f", 1, 1}, {Number, ".5", 1, 1}, {Number, "0xFF", 1, 1}, {Number, "1_000L", 1, 1}},
+		},
+		{
+			"Strings",
+			`"say \"hi\"" 'c' '\''`,
+			[]Token{{String, `"say \"hi\""`, 1, 1}, {String, `'c'`, 1, 1}, {String, `'\''`, 1, 1}},
+		},
+		{
+			"Unterminated String",
+			"\"open\nx",
+			[]Token{{String, `"open`, 1, 1}, {Identifier, "x", 2, 2}},
+		},
+		{
+			"Unterminated String Ending In Backslash",
+			"\"open\\\nx",
+			[]Token{{String, `"open\`, 1, 1}, {Identifier, "x", 2, 2}},
+		},
+		{
+			"Line Comment",
+			"x; // done\ny",
+			[]Token{{Identifier, "x", 1, 1}, {Punct, ";", 1, 1}, {Comment, "// done", 1, 1}, {Identifier, "y", 2, 2}},
+		},
+		{
+			"Block Comment",
+			"/* one\ntwo */ x",
+			[]Token{{Comment, "/* one\ntwo */", 1, 2}, {Identifier, "x", 2, 2}},
+		},
+		{
+			"Unterminated Block Comment",
+			"x /* open\n",
+			[]Token{{Identifier, "x", 1, 1}, {Comment, "/* open\n", 1, 2}},
+		},
+		{
+			"Operators",
+			"a >>>= b->c && d<=e",
+			[]Token{
+				{Identifier, "a", 1, 1}, {Punct, ">>>=", 1, 1}, {Identifier, "b", 1, 1}, {Punct, "->", 1, 1}, {Identifier, "c", 1, 1},
+				{Punct, "&&", 1, 1}, {Identifier, "d", 1, 1}, {Punct, "<=", 1, 1}, {Identifier, "e", 1, 1},
+			},
+		},
+		{
+			"Division",
+			"a / b",
+			[]Token{{Identifier, "a", 1, 1}, {Punct, "/", 1, 1}, {Identifier, "b", 1, 1}},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Lex(tt.source))
+		})
+	}
+}
+
+func TestIsSource(t *testing.T) {
+	assert.True(t, IsSource("Sketch/Sketch.pde"))
+	assert.True(t, IsSource("src/Main.JAVA"))
+	assert.False(t, IsSource("data/image.png"))
+	assert.False(t, IsSource("pde"))
+}
+
+func TestIsKeyword(t *testing.T) {
+	assert.True(t, IsKeyword("while"))
+	assert.True(t, IsKeyword("color"))
+	assert.False(t, IsKeyword("setup"))
+	assert.False(t, IsKeyword("While"))
+}
@@ -0,0 +1,175 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/lexer"
+)
+
+// Check applies the rules to a submission version's source files, using the metrics
+// from analysing them. Files unchanged from the starter code aren't checked, though
+// classes they declare still satisfy REQUIRED_CLASS rules. Findings are ordered by
+// file and line.
+func Check(rules []*models.LintRule, files []models.SubmissionFile, metrics []models.CodeMetrics) []models.LintFinding {
+	sources := map[string]string{}
+	for _, file := range files {
+		sources[file.Path] = string(file.Content)
+	}
+
+	findings := []models.LintFinding{}
+	for _, rule := range rules {
+		report := func(path string, line int, message string) {
+			if rule.Message != "" {
+				message = rule.Message
+			}
+			findings = append(findings, models.LintFinding{
+				Path:     path,
+				Line:     line,
+				Severity: rule.Severity,
+				Message:  message,
+				RuleID:   rule.ID,
+			})
+		}
+
+		if rule.Kind == models.LintRequiredClass {
+			declared := map[string]bool{}
+			for _, file := range metrics {
+				for _, class := range file.Classes {
+					declared[class] = true
+				}
+			}
+			for _, name := range rule.Names {
+				if !declared[name] {
+					report("", 0, fmt.Sprintf("class %s is not declared", name))
+				}
+			}
+			continue
+		}
+
+		for _, file := range metrics {
+			if file.Starter {
+				continue
+			}
+			checkFile(rule, file, sources[file.Path], report)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Line < findings[j].Line
+	})
+
+	return findings
+}
+
+func checkFile(rule *models.LintRule, file models.CodeMetrics, source string, report func(path string, line int, message string)) {
+	switch rule.Kind {
+	case models.LintMaxFunctionLength:
+		for _, function := range file.Functions {
+			length := function.EndLine - function.StartLine + 1
+			if length > rule.Limit {
+				report(file.Path, function.StartLine, fmt.Sprintf("%s is %d lines long, more than %d", functionName(function), length, rule.Limit))
+			}
+		}
+	case models.LintMaxComplexity:
+		for _, function := range file.Functions {
+			if function.Complexity > rule.Limit {
+				report(file.Path, function.StartLine, fmt.Sprintf("%s has a complexity of %d, more than %d", functionName(function), function.Complexity, rule.Limit))
+			}
+		}
+	case models.LintRequireComments:
+		tokens := lexer.Lex(source)
+		for _, function := range file.Functions {
+			if !commented(tokens, function) {
+				report(file.Path, function.StartLine, fmt.Sprintf("%s has no comment", functionName(function)))
+			}
+		}
+	case models.LintBannedAPI:
+		tokens := lexer.Lex(source)
+		for _, name := range rule.Names {
+			for _, line := range uses(tokens, name) {
+				report(file.Path, line, fmt.Sprintf("%s must not be used", name))
+			}
+		}
+	case models.LintMagicNumbers:
+		for _, number := range file.MagicNumbers {
+			report(file.Path, number.Line, fmt.Sprintf("magic number %s", number.Value))
+		}
+	case models.LintNamingConventions:
+		for _, issue := range file.NamingIssues {
+			report(file.Path, issue.Line, fmt.Sprintf("%s %s should be %s", issue.Kind, issue.Name, issue.Expected))
+		}
+	}
+}
+
+func functionName(function models.FunctionMetrics) string {
+	if function.Class != "" {
+		return function.Class + "." + function.Name + "()"
+	}
+
+	return function.Name + "()"
+}
+
+// commented reports whether a comment comes directly before the declaration of the
+// function, ahead of any modifiers, annotations or return type.
+func commented(tokens []lexer.Token, function models.FunctionMetrics) bool {
+	for i, token := range tokens {
+		if token.Line != function.StartLine || token.Text != function.Name {
+			continue
+		}
+
+		for j := i - 1; j >= 0; j-- {
+			switch {
+			case tokens[j].Kind == lexer.Comment:
+				return true
+			case tokens[j].Text == ";" || tokens[j].Text == "{" || tokens[j].Text == "}":
+				return false
+			}
+		}
+
+		return false
+	}
+
+	return false
+}
+
+// uses returns the lines that refer to a name, which may be qualified, e.g.
+// "System.exit". References through a qualifier that isn't part of the name, e.g.
+// "other.delay" for "delay", don't count.
+func uses(tokens []lexer.Token, name string) []int {
+	parts := strings.Split(name, ".")
+
+	var lines []int
+	for i := range tokens {
+		if i > 0 && tokens[i-1].Text == "." {
+			continue
+		}
+		if matches(tokens[i:], parts) {
+			lines = append(lines, tokens[i].Line)
+		}
+	}
+
+	return lines
+}
+
+func matches(tokens []lexer.Token, parts []string) bool {
+	if len(tokens) < 2*len(parts)-1 {
+		return false
+	}
+
+	for k, part := range parts {
+		if tokens[2*k].Kind != lexer.Identifier || tokens[2*k].Text != part {
+			return false
+		}
+		if k > 0 && tokens[2*k-1].Text != "." {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,88 @@
+package marking
+
+import (
+	"sort"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+// RoundRobin shares the submissions that don't have a marker yet between the markers.
+// Each goes to the marker with the fewest submissions so far, counting those already
+// allocated, so markers take turns when they start level. There must be at least one
+// marker.
+func RoundRobin(submissions []*models.Submission, markers []string, existing []*models.MarkingAllocation) []models.MarkingAllocation {
+	load := map[string]int{}
+	for _, allocation := range existing {
+		load[allocation.Marker]++
+	}
+
+	allocations := []models.MarkingAllocation{}
+	for _, submission := range unallocated(submissions, existing) {
+		marker := markers[0]
+		for _, candidate := range markers[1:] {
+			if load[candidate] < load[marker] {
+				marker = candidate
+			}
+		}
+
+		load[marker]++
+		allocations = append(allocations, allocate(submission, marker))
+	}
+
+	return allocations
+}
+
+// ByClass gives each submission that doesn't have a marker yet to the marker of the
+// student's class, keyed by student ID. Submissions from students without a marker,
+// including those that don't match a student record, are returned unallocated.
+func ByClass(submissions []*models.Submission, studentMarkers map[uint]string, existing []*models.MarkingAllocation) ([]models.MarkingAllocation, []*models.Submission) {
+	allocations := []models.MarkingAllocation{}
+	remaining := []*models.Submission{}
+	for _, submission := range unallocated(submissions, existing) {
+		if submission.StudentRecordID == nil {
+			remaining = append(remaining, submission)
+			continue
+		}
+
+		marker, ok := studentMarkers[*submission.StudentRecordID]
+		if !ok {
+			remaining = append(remaining, submission)
+			continue
+		}
+
+		allocations = append(allocations, allocate(submission, marker))
+	}
+
+	return allocations, remaining
+}
+
+// unallocated returns the submissions without a marker in the order they were made.
+func unallocated(submissions []*models.Submission, existing []*models.MarkingAllocation) []*models.Submission {
+	allocated := map[uint]bool{}
+	for _, allocation := range existing {
+		allocated[allocation.SubmissionID] = true
+	}
+
+	remaining := []*models.Submission{}
+	for _, submission := range submissions {
+		if !allocated[submission.ID] {
+			remaining = append(remaining, submission)
+		}
+	}
+
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].ID < remaining[j].ID
+	})
+
+	return remaining
+}
+
+func allocate(submission *models.Submission, marker string) models.MarkingAllocation {
+	return models.MarkingAllocation{
+		SubmissionID: submission.ID,
+		Submission:   *submission,
+		AssignmentID: submission.AssignmentID,
+		Marker:       marker,
+		Status:       models.MarkingStatusPending,
+	}
+}
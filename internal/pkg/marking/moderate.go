@@ -0,0 +1,76 @@
+package marking
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+// Sample picks size of the submissions at random to be moderated, ordered by when they
+// were made. All of them are picked if there are fewer than size.
+func Sample(submissions []*models.Submission, size int, rng *rand.Rand) []*models.Submission {
+	sample := append([]*models.Submission{}, submissions...)
+	rng.Shuffle(len(sample), func(i, j int) {
+		sample[i], sample[j] = sample[j], sample[i]
+	})
+	if size < len(sample) {
+		sample = sample[:size]
+	}
+
+	sort.Slice(sample, func(i, j int) bool {
+		return sample[i].ID < sample[j].ID
+	})
+
+	return sample
+}
+
+// AssignSecondMarkers gives each sampled submission a second marker, taking the
+// markers in turn. A submission is never second marked by the marker it was allocated
+// to, so every submission needs at least one other marker.
+func AssignSecondMarkers(sample []*models.Submission, markers []string, allocations []*models.MarkingAllocation) ([]models.ModerationSample, error) {
+	firstMarkers := map[uint]string{}
+	for _, allocation := range allocations {
+		firstMarkers[allocation.SubmissionID] = allocation.Marker
+	}
+
+	samples := []models.ModerationSample{}
+	next := 0
+	for _, submission := range sample {
+		assigned := false
+		for tried := 0; tried < len(markers) && !assigned; tried++ {
+			marker := markers[(next+tried)%len(markers)]
+			if marker == firstMarkers[submission.ID] {
+				continue
+			}
+
+			samples = append(samples, models.ModerationSample{
+				SubmissionID: submission.ID,
+				Submission:   *submission,
+				AssignmentID: submission.AssignmentID,
+				SecondMarker: marker,
+				Status:       models.ModerationStatusAwaitingSecondMark,
+			})
+			next = (next + tried + 1) % len(markers)
+			assigned = true
+		}
+
+		if !assigned {
+			return nil, fmt.Errorf("submission %s has no second marker other than its first marker", submission.StudentID)
+		}
+	}
+
+	return samples, nil
+}
+
+// Compare decides whether a second mark agrees with the first, i.e. whether they're
+// no further apart than the threshold.
+func Compare(firstMark, secondMark, threshold float64) models.ModerationStatus {
+	if math.Abs(secondMark-firstMark) > threshold {
+		return models.ModerationStatusDiscrepancy
+	}
+
+	return models.ModerationStatusAgreed
+}
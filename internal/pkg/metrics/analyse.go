@@ -0,0 +1,295 @@
+package metrics
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/diff"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/lexer"
+)
+
+// Naming conventions, as checked against declarations.
+const (
+	UpperCamelCase = "UpperCamelCase"
+	LowerCamelCase = "lowerCamelCase"
+	UpperSnakeCase = "UPPER_SNAKE_CASE"
+)
+
+var conventions = map[string]*regexp.Regexp{
+	UpperCamelCase: regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`),
+	LowerCamelCase: regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`),
+	UpperSnakeCase: regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`),
+}
+
+// Types that can start a variable declaration, in addition to class names.
+var primitiveTypes = map[string]bool{
+	"boolean": true, "byte": true, "char": true, "short": true, "int": true, "long": true,
+	"float": true, "double": true, "color": true, "var": true,
+}
+
+// Tokens that add a path through a function, for cyclomatic complexity.
+var branches = map[string]bool{
+	"if": true, "for": true, "while": true, "case": true, "catch": true, "&&": true, "||": true, "?": true,
+}
+
+type frameKind int
+
+const (
+	classFrame frameKind = iota
+	functionFrame
+	blockFrame
+)
+
+type frame struct {
+	kind     frameKind
+	name     string
+	function *models.FunctionMetrics
+}
+
+// Analyse parses a Processing or Java source file into metrics. The parser only
+// tracks declarations and braces, so it copes with code that doesn't compile.
+func Analyse(filePath, source string) models.CodeMetrics {
+	metrics := models.CodeMetrics{
+		Path:         filePath,
+		Functions:    []models.FunctionMetrics{},
+		Classes:      []string{},
+		MagicNumbers: []models.MagicNumber{},
+		NamingIssues: []models.NamingIssue{},
+	}
+
+	tokens := lexer.Lex(source)
+	countLines(&metrics, source, tokens)
+
+	var code []lexer.Token
+	for _, token := range tokens {
+		if token.Kind != lexer.Comment {
+			code = append(code, token)
+		}
+	}
+
+	var stack []frame
+	statement, parens := 0, 0
+	reported := map[string]bool{}
+	issue := func(token lexer.Token, kind, expected string) {
+		key := strconv.Itoa(token.Line) + ":" + token.Text
+		if conventions[expected].MatchString(token.Text) || reported[key] {
+			return
+		}
+		reported[key] = true
+		metrics.NamingIssues = append(metrics.NamingIssues, models.NamingIssue{Line: token.Line, Name: token.Text, Kind: kind, Expected: expected})
+	}
+
+	for i, token := range code {
+		function := innermostFunction(stack)
+		if function != nil && branches[token.Text] && token.Kind != lexer.String {
+			function.Complexity++
+		}
+
+		switch {
+		case token.Text == "(":
+			parens++
+		case token.Text == ")":
+			parens--
+		case token.Text == ";" && parens <= 0:
+			statement = i + 1
+		case token.Text == "{":
+			next := declaration(code[statement:i], stack)
+			switch next.kind {
+			case classFrame:
+				metrics.Classes = append(metrics.Classes, next.name)
+			case functionFrame:
+				if next.name != enclosingClass(stack) {
+					issue(lexer.Token{Text: next.name, Line: next.function.StartLine}, "function", LowerCamelCase)
+				}
+			}
+			stack = append(stack, next)
+			statement, parens = i+1, 0
+		case token.Text == "}":
+			if len(stack) > 0 {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				if top.kind == functionFrame {
+					top.function.EndLine = token.Line
+					metrics.Functions = append(metrics.Functions, *top.function)
+					if top.function.Class == "" {
+						metrics.HasSetup = metrics.HasSetup || top.name == "setup"
+						metrics.HasDraw = metrics.HasDraw || top.name == "draw"
+					}
+				}
+			}
+			statement, parens = i+1, 0
+		case token.Kind == lexer.Number:
+			if !isConstant(code[statement:i]) && !trivialNumber(token.Text) {
+				metrics.MagicNumbers = append(metrics.MagicNumbers, models.MagicNumber{Line: token.Line, Value: token.Text})
+			}
+		case token.Kind == lexer.Identifier && i > 0 && i+1 < len(code) && isType(code[i-1]) && isDeclarator(code[i+1]):
+			if isConstant(code[statement:i]) {
+				if !conventions[LowerCamelCase].MatchString(token.Text) {
+					issue(token, "constant", UpperSnakeCase)
+				}
+			} else {
+				issue(token, "variable", LowerCamelCase)
+			}
+		}
+
+		if token.Text == "class" && i+1 < len(code) && code[i+1].Kind == lexer.Identifier {
+			issue(code[i+1], "class", UpperCamelCase)
+		}
+	}
+
+	return metrics
+}
+
+// declaration works out what the tokens before an opening brace declare.
+func declaration(header []lexer.Token, stack []frame) frame {
+	for i, token := range header {
+		if (token.Text == "class" || token.Text == "interface" || token.Text == "enum") && i+1 < len(header) {
+			return frame{kind: classFrame, name: header[i+1].Text}
+		}
+	}
+
+	if innermostFunction(stack) != nil {
+		return frame{kind: blockFrame}
+	}
+
+	// Skip a throws clause to find the parameter list.
+	end := len(header) - 1
+	for j, token := range header {
+		if token.Text == "throws" {
+			end = j - 1
+			break
+		}
+	}
+	if end < 0 || header[end].Text != ")" {
+		return frame{kind: blockFrame}
+	}
+
+	depth := 0
+	open := -1
+	for j := end; j >= 0; j-- {
+		switch header[j].Text {
+		case ")":
+			depth++
+		case "(":
+			depth--
+		}
+		if depth == 0 {
+			open = j
+			break
+		}
+	}
+	if open < 1 || header[open-1].Kind != lexer.Identifier {
+		return frame{kind: blockFrame}
+	}
+
+	name := header[open-1]
+	class := enclosingClass(stack)
+	constructor := name.Text == class
+	if !constructor && (open < 2 || !isType(header[open-2]) || header[open-2].Text == "new") {
+		return frame{kind: blockFrame}
+	}
+
+	return frame{
+		kind: functionFrame,
+		name: name.Text,
+		function: &models.FunctionMetrics{
+			Name:       name.Text,
+			Class:      class,
+			StartLine:  name.Line,
+			Complexity: 1,
+		},
+	}
+}
+
+func innermostFunction(stack []frame) *models.FunctionMetrics {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].kind == functionFrame {
+			return stack[i].function
+		}
+	}
+
+	return nil
+}
+
+func enclosingClass(stack []frame) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].kind == classFrame {
+			return stack[i].name
+		}
+	}
+
+	return ""
+}
+
+// isType reports whether a token can be the type of a declaration: a primitive type,
+// void, a class name or the end of an array type.
+func isType(token lexer.Token) bool {
+	switch token.Kind {
+	case lexer.Keyword:
+		return primitiveTypes[token.Text] || token.Text == "void"
+	case lexer.Identifier:
+		return conventions[UpperCamelCase].MatchString(token.Text) && !conventions[UpperSnakeCase].MatchString(token.Text)
+	case lexer.Punct:
+		return token.Text == "]"
+	}
+
+	return false
+}
+
+// isDeclarator reports whether a token can follow the name in a variable declaration.
+func isDeclarator(token lexer.Token) bool {
+	switch token.Text {
+	case "=", ";", ",", ")", ":", "[":
+		return true
+	}
+
+	return false
+}
+
+func isConstant(statement []lexer.Token) bool {
+	for _, token := range statement {
+		if token.Text == "final" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trivialNumber reports whether a literal is 0 or 1, which are too common to be magic.
+func trivialNumber(literal string) bool {
+	value, err := strconv.ParseFloat(strings.TrimRight(literal, "fFdDlL"), 64)
+
+	return err == nil && (value == 0 || value == 1)
+}
+
+// countLines counts the lines with code, the lines with only comments and the blank
+// lines of a file.
+func countLines(metrics *models.CodeMetrics, source string, tokens []lexer.Token) {
+	lines := diff.SplitLines(source)
+	metrics.Lines = len(lines)
+
+	code, comment := map[int]bool{}, map[int]bool{}
+	for _, token := range tokens {
+		if token.Kind != lexer.Comment {
+			code[token.Line] = true
+			continue
+		}
+		for line := token.Line; line <= token.EndLine; line++ {
+			comment[line] = true
+		}
+	}
+
+	for n, line := range lines {
+		switch {
+		case code[n+1]:
+			metrics.CodeLines++
+		case comment[n+1]:
+			metrics.CommentLines++
+		case strings.TrimSpace(line) == "":
+			metrics.BlankLines++
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+const sketch = `// A penguin walking across the screen.
+final int SPEED = 3;
+float penguin_x = 0;
+
+void setup() {
+  size(400, 300);
+}
+
+/*
+ * Moves and draws the penguin.
+ */
+void draw() {
+  background(255);
+  if (penguin_x > width || penguin_x < 0) {
+    penguin_x = 0;
+  }
+  for (int i = 0; i < 2; i++) {
+    penguin_x += SPEED;
+  }
+  String label = "(" + penguin_x + ")";
+}
+
+class penguin {
+  int Height = 1;
+
+  penguin() {
+  }
+
+  void Waddle(boolean fast) throws Exception {
+    while (fast) {
+      fast = false;
+    }
+  }
+}
+`
+
+func TestAnalyse(t *testing.T) {
+	metrics := Analyse("Sketch/Sketch.pde", sketch)
+
+	assert.Equal(t, "Sketch/Sketch.pde", metrics.Path)
+	assert.Equal(t, 34, metrics.Lines)
+	assert.Equal(t, 4, metrics.CommentLines)
+	assert.Equal(t, 5, metrics.BlankLines)
+	assert.Equal(t, 25, metrics.CodeLines)
+	assert.True(t, metrics.HasSetup)
+	assert.True(t, metrics.HasDraw)
+	assert.Equal(t, []string{"penguin"}, metrics.Classes)
+
+	assert.Equal(t, []models.FunctionMetrics{
+		{Name: "setup", StartLine: 5, EndLine: 7, Complexity: 1},
+		// The if, its || and the loop each add a path.
+		{Name: "draw", StartLine: 12, EndLine: 21, Complexity: 4},
+		{Name: "penguin", Class: "penguin", StartLine: 26, EndLine: 27, Complexity: 1},
+		{Name: "Waddle", Class: "penguin", StartLine: 29, EndLine: 33, Complexity: 2},
+	}, metrics.Functions)
+
+	// SPEED's value is a constant, and 0 and 1 are too common to count.
+	assert.Equal(t, []models.MagicNumber{
+		{Line: 6, Value: "400"},
+		{Line: 6, Value: "300"},
+		{Line: 13, Value: "255"},
+		{Line: 17, Value: "2"},
+	}, metrics.MagicNumbers)
+
+	assert.ElementsMatch(t, []models.NamingIssue{
+		{Line: 3, Name: "penguin_x", Kind: "variable", Expected: LowerCamelCase},
+		{Line: 23, Name: "penguin", Kind: "class", Expected: UpperCamelCase},
+		{Line: 24, Name: "Height", Kind: "variable", Expected: LowerCamelCase},
+		{Line: 29, Name: "Waddle", Kind: "function", Expected: LowerCamelCase},
+	}, metrics.NamingIssues)
+}
+
+func TestAnalyseConstants(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		source string
+		issues int
+	}{
+		{"Upper Snake Case", "final int MAX_SPEED = 10;", 0},
+		{"Lower Camel Case", "final int maxSpeed = 10;", 0},
+		{"Other", "final int Max_speed = 10;", 1},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := Analyse("Sketch.pde", tt.source)
+
+			assert.Empty(t, metrics.MagicNumbers)
+			assert.Len(t, metrics.NamingIssues, tt.issues)
+		})
+	}
+}
+
+func TestAnalyseUnbalancedBraces(t *testing.T) {
+	// Student code doesn't always compile, so a missing brace shouldn't stop analysis.
+	metrics := Analyse("Sketch.pde", "void setup() {\n  if (true) {\n}\n}\n}\nvoid draw() {")
+
+	require.Len(t, metrics.Functions, 1)
+	assert.Equal(t, "setup", metrics.Functions[0].Name)
+	assert.True(t, metrics.HasSetup)
+	assert.False(t, metrics.HasDraw)
+}
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/lexer"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/lint"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/starter"
+)
+
+// AnalyseFiles analyses each source file of a submission version, marking the files
+// that are unchanged from the starter code.
+func AnalyseFiles(files []models.SubmissionFile, baseline starter.Baseline) []models.CodeMetrics {
+	var metrics []models.CodeMetrics
+	for _, file := range files {
+		if !lexer.IsSource(file.Path) {
+			continue
+		}
+
+		fileMetrics := Analyse(file.Path, string(file.Content))
+		if starterPath, ok := baseline.Find(file.Path); ok && baseline[starterPath] == string(file.Content) {
+			fileMetrics.Starter = true
+		}
+		metrics = append(metrics, fileMetrics)
+	}
+
+	return metrics
+}
+
+// Settings are the assignment's starter code and lint rules that submission versions
+// are analysed against.
+type Settings struct {
+	Baseline starter.Baseline
+	Rules    []*models.LintRule
+}
+
+func LoadSettings(database db.Database, assignmentID uint) (Settings, error) {
+	baseline, err := starter.Load(database, assignmentID)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	rules, err := database.GetLintRules(assignmentID)
+	if err != nil {
+		return Settings{}, fmt.Errorf("error getting lint rules: %w", err)
+	}
+
+	return Settings{Baseline: baseline, Rules: rules}, nil
+}
+
+// AnalyseVersion analyses a submission version's files and checks them against the
+// lint rules, storing the metrics and findings in place of any from an earlier
+// analysis. The files are loaded if the version doesn't have them.
+func AnalyseVersion(database db.Database, version *models.SubmissionVersion, settings Settings) error {
+	files := version.Files
+	if files == nil {
+		loaded, err := database.GetSubmissionFiles(version.ID)
+		if err != nil {
+			return fmt.Errorf("error getting submission files: %w", err)
+		}
+		for _, file := range loaded {
+			files = append(files, *file)
+		}
+	}
+
+	metrics := AnalyseFiles(files, settings.Baseline)
+	err := database.SaveCodeMetrics(version.ID, metrics)
+	if err != nil {
+		return fmt.Errorf("error saving code metrics: %w", err)
+	}
+
+	err = database.SaveLintFindings(version.ID, lint.Check(settings.Rules, files, metrics))
+	if err != nil {
+		return fmt.Errorf("error saving lint findings: %w", err)
+	}
+
+	return nil
+}
+
+// AnalyseAssignment analyses every version of every submission to the assignment,
+// returning the number of versions analysed.
+func AnalyseAssignment(database db.Database, assignmentID uint) (int, error) {
+	settings, err := LoadSettings(database, assignmentID)
+	if err != nil {
+		return 0, err
+	}
+
+	submissions, err := database.GetSubmissionsForAssignment(fmt.Sprintf("%d", assignmentID))
+	if err != nil {
+		return 0, fmt.Errorf("error getting submissions: %w", err)
+	}
+
+	analysed := 0
+	for _, submission := range submissions {
+		versions, err := database.GetSubmissionVersions(submission.ID)
+		if err != nil {
+			return analysed, fmt.Errorf("error getting versions: %w", err)
+		}
+
+		for _, version := range versions {
+			err = AnalyseVersion(database, version, settings)
+			if err != nil {
+				return analysed, err
+			}
+			analysed++
+		}
+	}
+
+	return analysed, nil
+}
+
+// Summary totals the metrics of a submission's files. Files unchanged from the
+// starter code are left out, other than in HasSetup and HasDraw which describe the
+// sketch as a whole.
+type Summary struct {
+	Files             int
+	Lines             int
+	CodeLines         int
+	CommentLines      int
+	Functions         int
+	Classes           int
+	MaxComplexity     int
+	AverageComplexity float64
+	MagicNumbers      int
+	NamingIssues      int
+	HasSetup          bool
+	HasDraw           bool
+}
+
+func Summarise(metrics []*models.CodeMetrics) Summary {
+	var summary Summary
+	totalComplexity := 0
+	for _, file := range metrics {
+		summary.HasSetup = summary.HasSetup || file.HasSetup
+		summary.HasDraw = summary.HasDraw || file.HasDraw
+
+		if file.Starter {
+			continue
+		}
+
+		summary.Files++
+		summary.Lines += file.Lines
+		summary.CodeLines += file.CodeLines
+		summary.CommentLines += file.CommentLines
+		summary.Functions += len(file.Functions)
+		summary.Classes += len(file.Classes)
+		summary.MagicNumbers += len(file.MagicNumbers)
+		summary.NamingIssues += len(file.NamingIssues)
+
+		for _, function := range file.Functions {
+			totalComplexity += function.Complexity
+			if function.Complexity > summary.MaxComplexity {
+				summary.MaxComplexity = function.Complexity
+			}
+		}
+	}
+
+	if summary.Functions > 0 {
+		summary.AverageComplexity = float64(totalComplexity) / float64(summary.Functions)
+	}
+
+	return summary
+}
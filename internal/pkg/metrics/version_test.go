@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+func TestAnalyseFiles(t *testing.T) {
+	starter := "void setup() {\n  size(400, 300);\n}\n"
+	files := []models.SubmissionFile{
+		{Path: "Sketch/Sketch.pde", Content: []byte(starter)},
+		{Path: "Sketch/Penguin.pde", Content: []byte("class Penguin {\n}\n")},
+		{Path: "Sketch/data/penguin.png", Content: []byte{0x89, 'P', 'N', 'G'}},
+		{Path: "Sketch/Walrus.pde", Content: []byte(strings.Replace(starter, "400", "800", 1))},
+	}
+
+	metrics := AnalyseFiles(files, map[string]string{"Sketch/Sketch.pde": starter, "Sketch/Walrus.pde": starter})
+
+	require.Len(t, metrics, 3)
+	assert.Equal(t, "Sketch/Sketch.pde", metrics[0].Path)
+	assert.True(t, metrics[0].Starter)
+	assert.False(t, metrics[1].Starter)
+	assert.Equal(t, "Sketch/Walrus.pde", metrics[2].Path)
+	assert.False(t, metrics[2].Starter, "edited starter files are the student's work")
+}
+
+func TestSummarise(t *testing.T) {
+	summary := Summarise([]*models.CodeMetrics{
+		{
+			Starter:   true,
+			Lines:     10,
+			HasSetup:  true,
+			Functions: []models.FunctionMetrics{{Name: "setup", Complexity: 9}},
+		},
+		{
+			Lines:        20,
+			CodeLines:    15,
+			CommentLines: 3,
+			HasDraw:      true,
+			Functions:    []models.FunctionMetrics{{Name: "draw", Complexity: 4}, {Name: "move", Complexity: 2}},
+			Classes:      []string{"Penguin"},
+			MagicNumbers: []models.MagicNumber{{Line: 1, Value: "255"}},
+		},
+		{
+			Lines:        5,
+			CodeLines:    5,
+			NamingIssues: []models.NamingIssue{{Line: 1, Name: "Penguin_x"}},
+			Functions:    []models.FunctionMetrics{{Name: "walk", Complexity: 3}},
+		},
+	})
+
+	assert.Equal(t, Summary{
+		Files:             2,
+		Lines:             25,
+		CodeLines:         20,
+		CommentLines:      3,
+		Functions:         3,
+		Classes:           1,
+		MaxComplexity:     4,
+		AverageComplexity: 3,
+		MagicNumbers:      1,
+		NamingIssues:      1,
+		HasSetup:          true,
+		HasDraw:           true,
+	}, summary)
+
+	assert.Equal(t, Summary{}, Summarise(nil))
+}
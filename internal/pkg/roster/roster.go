@@ -0,0 +1,273 @@
+package roster
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+)
+
+type RowStatus string
+
+const (
+	RowStatusCreated RowStatus = "CREATED"
+	RowStatusUpdated RowStatus = "UPDATED"
+	RowStatusSkipped RowStatus = "SKIPPED"
+	RowStatusErrored RowStatus = "ERRORED"
+)
+
+// Row is a single student parsed from a roster CSV.
+type Row struct {
+	Line          int
+	StudentNumber string
+	Name          string
+	Email         string
+}
+
+// RowReport describes what happened to a single row of an import.
+type RowReport struct {
+	Line          int
+	StudentNumber string
+	Status        RowStatus
+	Message       string
+}
+
+type Report struct {
+	Rows []RowReport
+}
+
+// Count returns the number of rows in the report with the given status.
+func (r *Report) Count(status RowStatus) int {
+	count := 0
+	for _, row := range r.Rows {
+		if row.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Header aliases used by the university's student system, LMS gradebooks and common
+// spreadsheet exports, normalised by normaliseHeader. Aliases are in order of
+// preference: when several columns match, the one with the earliest alias is used.
+// Canvas and Moodle exports have a bare "ID" column holding the LMS's internal ID, so it
+// is only used as a last resort. Canvas gradebooks put the student's name under
+// "Student".
+var (
+	studentNumberHeaders = []string{"studentnumber", "studentid", "sisuserid", "idnumber", "sid", "id"}
+	nameHeaders          = []string{"name", "fullname", "studentname", "student"}
+	firstNameHeaders     = []string{"firstname", "givenname", "givennames"}
+	lastNameHeaders      = []string{"lastname", "surname", "familyname"}
+	emailHeaders         = []string{"email", "emailaddress", "studentemail"}
+)
+
+// columns holds the index of each known column in the CSV, or -1 if absent.
+type columns struct {
+	studentNumber, name, firstName, lastName, email int
+}
+
+// Parse reads a roster CSV. The header row must contain a student number column and
+// either a name column or first and last name columns. Rows are returned even when
+// they are missing values so they can be reported individually.
+func Parse(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("roster is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading roster header: %w", err)
+	}
+
+	cols, err := findColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading roster: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{
+			Line:          line,
+			StudentNumber: field(record, cols.studentNumber),
+			Name:          field(record, cols.name),
+			Email:         field(record, cols.email),
+		}
+		if row.Name == "" {
+			row.Name = strings.TrimSpace(field(record, cols.firstName) + " " + field(record, cols.lastName))
+		}
+
+		if row == (Row{Line: line}) {
+			// Skip blank lines, which spreadsheets often leave at the end of an export.
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Import parses the roster and upserts each student before enrolling them in the class.
+// Rows with missing values are recorded in the report rather than aborting the import,
+// but the import runs in a transaction so a database error leaves the class unchanged.
+func Import(database db.Database, classID uint, r io.Reader) (*Report, error) {
+	rows, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	seen := map[string]int{}
+
+	err = database.Transaction(func(tx db.Database) error {
+		for _, row := range rows {
+			rowReport := RowReport{Line: row.Line, StudentNumber: row.StudentNumber}
+
+			switch {
+			case row.StudentNumber == "":
+				rowReport.Status = RowStatusErrored
+				rowReport.Message = "missing student number"
+			case row.Name == "":
+				rowReport.Status = RowStatusErrored
+				rowReport.Message = "missing name"
+			case seen[row.StudentNumber] != 0:
+				rowReport.Status = RowStatusSkipped
+				rowReport.Message = fmt.Sprintf("duplicate of line %d", seen[row.StudentNumber])
+			default:
+				seen[row.StudentNumber] = row.Line
+
+				var err error
+				rowReport.Status, err = importRow(tx, classID, row)
+				if err != nil {
+					return fmt.Errorf("line %d: %w", row.Line, err)
+				}
+			}
+
+			report.Rows = append(report.Rows, rowReport)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func importRow(database db.Database, classID uint, row Row) (RowStatus, error) {
+	status := RowStatusSkipped
+
+	student, err := database.GetStudentByNumber(row.StudentNumber)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return "", fmt.Errorf("error getting student: %w", err)
+	}
+
+	switch {
+	case student == nil:
+		student, err = database.CreateStudent(row.StudentNumber, row.Name, row.Email)
+		if err != nil {
+			return "", fmt.Errorf("error creating student: %w", err)
+		}
+		status = RowStatusCreated
+	case student.Name != row.Name || (row.Email != "" && student.Email != row.Email):
+		email := row.Email
+		if email == "" {
+			email = student.Email
+		}
+
+		student, err = database.UpdateStudent(student.ID, row.Name, email)
+		if err != nil {
+			return "", fmt.Errorf("error updating student: %w", err)
+		}
+		status = RowStatusUpdated
+	}
+
+	err = database.EnrolStudent(student.ID, classID)
+	if err != nil {
+		return "", fmt.Errorf("error enrolling student: %w", err)
+	}
+
+	return status, nil
+}
+
+func findColumns(header []string) (columns, error) {
+	cols := columns{
+		studentNumber: bestColumn(header, studentNumberHeaders),
+		name:          bestColumn(header, nameHeaders),
+		firstName:     bestColumn(header, firstNameHeaders),
+		lastName:      bestColumn(header, lastNameHeaders),
+		email:         bestColumn(header, emailHeaders),
+	}
+
+	if cols.studentNumber < 0 {
+		return cols, fmt.Errorf("roster is missing a student number column")
+	}
+	if cols.name < 0 && (cols.firstName < 0 || cols.lastName < 0) {
+		return cols, fmt.Errorf("roster is missing a name column or first and last name columns")
+	}
+
+	return cols, nil
+}
+
+// normaliseHeader lowercases a header and strips everything but letters and digits, so
+// that "Student ID", "student_id" and "StudentID" all match.
+func normaliseHeader(h string) string {
+	var b strings.Builder
+	for _, r := range h {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return b.String()
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+// bestColumn returns the index of the column whose header matches the earliest of the
+// aliases, taking the leftmost column on a tie, or -1 if no header matches.
+func bestColumn(header []string, aliases []string) int {
+	best, bestRank := -1, len(aliases)
+	for i, h := range header {
+		rank := indexOf(aliases, normaliseHeader(h))
+		if rank >= 0 && rank < bestRank {
+			best, bestRank = i, rank
+		}
+	}
+
+	return best
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,144 @@
+package roster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindColumns(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		header []string
+		want   columns
+	}{
+		{
+			"Student System",
+			[]string{"Student ID", "Name", "Email"},
+			columns{studentNumber: 0, name: 1, firstName: -1, lastName: -1, email: 2},
+		},
+		{
+			"First And Last Names",
+			[]string{"student_number", "Given Names", "Surname"},
+			columns{studentNumber: 0, name: -1, firstName: 1, lastName: 2, email: -1},
+		},
+		{
+			"Canvas Gradebook",
+			[]string{"Student", "ID", "SIS User ID", "SIS Login ID", "Section"},
+			columns{studentNumber: 2, name: 0, firstName: -1, lastName: -1, email: -1},
+		},
+		{
+			"Moodle Participants",
+			[]string{"ID", "First name", "Last name", "ID number", "Email address"},
+			columns{studentNumber: 3, name: -1, firstName: 1, lastName: 2, email: 4},
+		},
+		{
+			"Bare ID As Last Resort",
+			[]string{"ID", "Name"},
+			columns{studentNumber: 0, name: 1, firstName: -1, lastName: -1, email: -1},
+		},
+		{
+			"Preferred Alias Wins Over Earlier Column",
+			[]string{"SID", "Student Number", "Name"},
+			columns{studentNumber: 1, name: 2, firstName: -1, lastName: -1, email: -1},
+		},
+		{
+			"Leftmost Column On A Tie",
+			[]string{"Name", "Student ID", "Student ID"},
+			columns{studentNumber: 1, name: 0, firstName: -1, lastName: -1, email: -1},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cols, err := findColumns(tt.header)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cols)
+		})
+	}
+}
+
+func TestFindColumnsMissing(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		header []string
+		want   string
+	}{
+		{"Student Number", []string{"Name", "Email"}, "roster is missing a student number column"},
+		{"Name", []string{"Student ID", "First Name", "Email"}, "roster is missing a name column or first and last name columns"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := findColumns(tt.header)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		csv  string
+		want []Row
+	}{
+		{
+			"Names",
+			"Student ID,Name,Email\ns0001,Alice Penguin,alice@example.com\ns0003, Bob Eagle ,\n",
+			[]Row{
+				{Line: 2, StudentNumber: "s0001", Name: "Alice Penguin", Email: "alice@example.com"},
+				{Line: 3, StudentNumber: "s0003", Name: "Bob Eagle"},
+			},
+		},
+		{
+			"First And Last Names",
+			"Student Number,First Name,Last Name\ns0001,Alice,Penguin\ns0003,,Eagle\n",
+			[]Row{
+				{Line: 2, StudentNumber: "s0001", Name: "Alice Penguin"},
+				{Line: 3, StudentNumber: "s0003", Name: "Eagle"},
+			},
+		},
+		{
+			"Canvas Gradebook",
+			"Student,ID,SIS User ID,SIS Login ID,Section\nAlice Penguin,4821,s0001,alice,COMP4050\n",
+			[]Row{{Line: 2, StudentNumber: "s0001", Name: "Alice Penguin"}},
+		},
+		{
+			"Missing Values Are Kept",
+			"Student ID,Name\n,Alice Penguin\ns0003\n",
+			[]Row{
+				{Line: 2, Name: "Alice Penguin"},
+				{Line: 3, StudentNumber: "s0003"},
+			},
+		},
+		{
+			"Blank Lines Skipped",
+			"Student ID,Name\ns0001,Alice Penguin\n,\n\n",
+			[]Row{{Line: 2, StudentNumber: "s0001", Name: "Alice Penguin"}},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(strings.NewReader(tt.csv))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rows)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		csv  string
+		want string
+	}{
+		{"Empty", "", "roster is empty"},
+		{"Missing Student Number", "Name,Email\nAlice Penguin,alice@example.com\n", "roster is missing a student number column"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.csv))
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package similarity
+
+import (
+	"sort"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/lexer"
+)
+
+// File is a source file of a document.
+type File struct {
+	Path    string
+	Content string
+}
+
+// Document is the set of files submitted by a single student.
+type Document struct {
+	ID    uint
+	Name  string
+	Files []File
+}
+
+type Options struct {
+	// K is the number of tokens hashed into each fingerprint.
+	K int
+	// Window is the number of consecutive hashes each fingerprint is chosen from.
+	Window int
+	// MaxShare is the largest fraction of documents a fingerprint may appear in before
+	// it is treated as common code, such as starter code, and ignored. It only
+	// applies when there are at least three documents.
+	MaxShare float64
+	// Baseline is code given to every student, whose fingerprints are ignored.
+	Baseline []File
+}
+
+var DefaultOptions = Options{K: 12, Window: 8, MaxShare: 0.8}
+
+// Region is a span of lines in a file.
+type Region struct {
+	Path      string
+	StartLine int
+	EndLine   int
+}
+
+// Match is a region of one document that matches a region of another.
+type Match struct {
+	A Region
+	B Region
+}
+
+// Pair is the similarity between two documents. SimilarityA is the fraction of A's
+// fingerprints that also appear in B, and SimilarityB the reverse.
+type Pair struct {
+	A           uint
+	B           uint
+	SimilarityA float64
+	SimilarityB float64
+	Matches     []Match
+}
+
+// Similarity returns the larger of the pair's similarities, so that a small document
+// copied into a larger one still ranks highly.
+func (p Pair) Similarity() float64 {
+	if p.SimilarityA > p.SimilarityB {
+		return p.SimilarityA
+	}
+
+	return p.SimilarityB
+}
+
+// occurrence is where a fingerprint appears in a document.
+type occurrence struct {
+	file      string
+	startLine int
+	endLine   int
+}
+
+// Compare fingerprints every document and compares all pairs, returning the pairs that
+// share code ordered from most to least similar.
+func Compare(documents []Document, options Options) []Pair {
+	baseline := map[uint64]bool{}
+	for _, file := range options.Baseline {
+		if !lexer.IsSource(file.Path) {
+			continue
+		}
+
+		for _, fingerprint := range Fingerprints(Tokenize(file.Content), options.K, options.Window) {
+			baseline[fingerprint.Hash] = true
+		}
+	}
+
+	// prints[d] maps each of document d's fingerprint hashes to every place it occurs,
+	// so code copied more than once is highlighted everywhere.
+	prints := make([]map[uint64][]occurrence, len(documents))
+	frequency := map[uint64]int{}
+	for d, document := range documents {
+		prints[d] = map[uint64][]occurrence{}
+		for _, file := range document.Files {
+			if !lexer.IsSource(file.Path) {
+				continue
+			}
+
+			for _, fingerprint := range Fingerprints(Tokenize(file.Content), options.K, options.Window) {
+				if baseline[fingerprint.Hash] {
+					continue
+				}
+				if _, ok := prints[d][fingerprint.Hash]; !ok {
+					frequency[fingerprint.Hash]++
+				}
+				prints[d][fingerprint.Hash] = append(prints[d][fingerprint.Hash], occurrence{file.Path, fingerprint.StartLine, fingerprint.EndLine})
+			}
+		}
+	}
+
+	if len(documents) >= 3 {
+		for hash, count := range frequency {
+			if float64(count) > options.MaxShare*float64(len(documents)) {
+				for d := range prints {
+					delete(prints[d], hash)
+				}
+			}
+		}
+	}
+
+	// Group documents by the fingerprints they contain so that only pairs sharing
+	// at least one fingerprint are compared.
+	holders := map[uint64][]int{}
+	for d := range documents {
+		for hash := range prints[d] {
+			holders[hash] = append(holders[hash], d)
+		}
+	}
+
+	shared := map[[2]int][]uint64{}
+	for hash, docs := range holders {
+		sort.Ints(docs)
+		for i, a := range docs {
+			for _, b := range docs[i+1:] {
+				shared[[2]int{a, b}] = append(shared[[2]int{a, b}], hash)
+			}
+		}
+	}
+
+	var pairs []Pair
+	for key, hashes := range shared {
+		a, b := key[0], key[1]
+
+		var matches []Match
+		for _, hash := range hashes {
+			for _, inA := range prints[a][hash] {
+				for _, inB := range prints[b][hash] {
+					matches = append(matches, Match{
+						A: Region{Path: inA.file, StartLine: inA.startLine, EndLine: inA.endLine},
+						B: Region{Path: inB.file, StartLine: inB.startLine, EndLine: inB.endLine},
+					})
+				}
+			}
+		}
+
+		pairs = append(pairs, Pair{
+			A:           documents[a].ID,
+			B:           documents[b].ID,
+			SimilarityA: float64(len(hashes)) / float64(len(prints[a])),
+			SimilarityB: float64(len(hashes)) / float64(len(prints[b])),
+			Matches:     mergeMatches(matches),
+		})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Similarity() != pairs[j].Similarity() {
+			return pairs[i].Similarity() > pairs[j].Similarity()
+		}
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+
+		return pairs[i].B < pairs[j].B
+	})
+
+	return pairs
+}
+
+// mergeMatches joins matches between the same pair of files whose regions overlap or
+// are adjacent in both files, ordered by file and line.
+func mergeMatches(matches []Match) []Match {
+	sortMatches(matches)
+
+	var merged []Match
+	for _, match := range matches {
+		// Absorbing a match can grow it to touch one that was merged earlier, so start
+		// over after each one until nothing else touches it.
+		for i := 0; i < len(merged); {
+			if !touches(merged[i], match) {
+				i++
+				continue
+			}
+
+			match.A = extend(match.A, merged[i].A)
+			match.B = extend(match.B, merged[i].B)
+			merged = append(merged[:i], merged[i+1:]...)
+			i = 0
+		}
+		merged = append(merged, match)
+	}
+
+	sortMatches(merged)
+
+	return merged
+}
+
+func sortMatches(matches []Match) {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].A.Path != matches[j].A.Path {
+			return matches[i].A.Path < matches[j].A.Path
+		}
+		if matches[i].B.Path != matches[j].B.Path {
+			return matches[i].B.Path < matches[j].B.Path
+		}
+		if matches[i].A.StartLine != matches[j].A.StartLine {
+			return matches[i].A.StartLine < matches[j].A.StartLine
+		}
+
+		return matches[i].B.StartLine < matches[j].B.StartLine
+	})
+}
+
+// touches reports whether two matches are between the same files and overlap or are
+// adjacent in both.
+func touches(a, b Match) bool {
+	return a.A.Path == b.A.Path && a.B.Path == b.B.Path && adjacent(a.A, b.A) && adjacent(a.B, b.B)
+}
+
+func adjacent(a, b Region) bool {
+	return b.StartLine <= a.EndLine+1 && a.StartLine <= b.EndLine+1
+}
+
+func extend(a, b Region) Region {
+	if b.StartLine < a.StartLine {
+		a.StartLine = b.StartLine
+	}
+	if b.EndLine > a.EndLine {
+		a.EndLine = b.EndLine
+	}
+
+	return a
+}
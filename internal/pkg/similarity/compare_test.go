@@ -0,0 +1,129 @@
+package similarity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func match(aPath string, aStart, aEnd int, bPath string, bStart, bEnd int) Match {
+	return Match{
+		A: Region{Path: aPath, StartLine: aStart, EndLine: aEnd},
+		B: Region{Path: bPath, StartLine: bStart, EndLine: bEnd},
+	}
+}
+
+func TestMergeMatches(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		matches []Match
+		want    []Match
+	}{
+		{"None", nil, nil},
+		{
+			"Single",
+			[]Match{match("a.pde", 1, 3, "b.pde", 4, 6)},
+			[]Match{match("a.pde", 1, 3, "b.pde", 4, 6)},
+		},
+		{
+			"Overlapping",
+			[]Match{match("a.pde", 1, 3, "b.pde", 4, 6), match("a.pde", 2, 5, "b.pde", 5, 8)},
+			[]Match{match("a.pde", 1, 5, "b.pde", 4, 8)},
+		},
+		{
+			"Adjacent",
+			[]Match{match("a.pde", 4, 6, "b.pde", 4, 6), match("a.pde", 1, 3, "b.pde", 1, 3)},
+			[]Match{match("a.pde", 1, 6, "b.pde", 1, 6)},
+		},
+		{
+			"Apart In One File",
+			[]Match{match("a.pde", 1, 3, "b.pde", 1, 3), match("a.pde", 4, 6, "b.pde", 20, 22)},
+			[]Match{match("a.pde", 1, 3, "b.pde", 1, 3), match("a.pde", 4, 6, "b.pde", 20, 22)},
+		},
+		{
+			"Different Files",
+			[]Match{match("a.pde", 1, 3, "b.pde", 1, 3), match("a.pde", 2, 4, "c.pde", 2, 4)},
+			[]Match{match("a.pde", 1, 3, "b.pde", 1, 3), match("a.pde", 2, 4, "c.pde", 2, 4)},
+		},
+		{
+			// The second match sorts between the two that belong together.
+			"Interleaved",
+			[]Match{match("a.pde", 1, 2, "b.pde", 1, 2), match("a.pde", 2, 3, "b.pde", 20, 21), match("a.pde", 3, 4, "b.pde", 2, 3)},
+			[]Match{match("a.pde", 1, 4, "b.pde", 1, 3), match("a.pde", 2, 3, "b.pde", 20, 21)},
+		},
+		{
+			// The last match bridges the first two once they have grown.
+			"Bridged",
+			[]Match{match("a.pde", 1, 2, "b.pde", 1, 2), match("a.pde", 10, 11, "b.pde", 10, 11), match("a.pde", 3, 9, "b.pde", 3, 9)},
+			[]Match{match("a.pde", 1, 11, "b.pde", 1, 11)},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeMatches(tt.matches))
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	copied := strings.Join([]string{
+		"void drawPenguin(float x, float y) {",
+		"  fill(0);",
+		"  ellipse(x, y, 40, 60);",
+		"  fill(255);",
+		"  ellipse(x, y + 5, 30, 45);",
+		"}",
+	}, "\n")
+	unrelated := strings.Join([]string{
+		"int count = 0;",
+		"while (count < 10) {",
+		"  count += 2;",
+		"  if (count > 5) break;",
+		"}",
+	}, "\n")
+	other := strings.Join([]string{
+		"class Walrus {",
+		"  boolean tusks = true;",
+		"  String name() { return \"walrus\"; }",
+		"}",
+	}, "\n")
+
+	documents := []Document{
+		{ID: 1, Name: "s0001", Files: []File{{Path: "Main.pde", Content: copied}}},
+		// The copy appears twice, starting on lines 1 and 14.
+		{ID: 2, Name: "s0003", Files: []File{
+			{Path: "Main.pde", Content: copied + "\n\n" + unrelated + "\n\n" + copied},
+			{Path: "README.md", Content: copied},
+		}},
+		{ID: 3, Name: "s0005", Files: []File{{Path: "Main.pde", Content: other}}},
+	}
+
+	pairs := Compare(documents, Options{K: 5, Window: 4, MaxShare: 0.8})
+	require.Len(t, pairs, 1)
+
+	pair := pairs[0]
+	assert.Equal(t, uint(1), pair.A)
+	assert.Equal(t, uint(2), pair.B)
+	assert.Equal(t, 1.0, pair.SimilarityA)
+	assert.Less(t, pair.SimilarityB, 1.0)
+
+	require.Len(t, pair.Matches, 2)
+	for i, start := range []int{1, 14} {
+		assert.Equal(t, "Main.pde", pair.Matches[i].B.Path)
+		assert.Equal(t, start, pair.Matches[i].B.StartLine)
+		assert.Equal(t, 1, pair.Matches[i].A.StartLine)
+	}
+}
+
+func TestCompareIgnoresBaseline(t *testing.T) {
+	starter := "void setup() {\n  size(400, 400);\n  background(255);\n  noStroke();\n}"
+	documents := []Document{
+		{ID: 1, Files: []File{{Path: "Main.pde", Content: starter}}},
+		{ID: 2, Files: []File{{Path: "Main.pde", Content: starter}}},
+	}
+
+	assert.NotEmpty(t, Compare(documents, Options{K: 5, Window: 4, MaxShare: 0.8}))
+	assert.Empty(t, Compare(documents, Options{K: 5, Window: 4, MaxShare: 0.8, Baseline: []File{{Path: "Main.pde", Content: starter}}}))
+}
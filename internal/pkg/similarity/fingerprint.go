@@ -0,0 +1,66 @@
+package similarity
+
+import (
+	"hash/fnv"
+)
+
+// Fingerprint is the hash of a run of tokens selected by winnowing, along with the
+// lines the run spans.
+type Fingerprint struct {
+	Hash      uint64
+	StartLine int
+	EndLine   int
+}
+
+// Fingerprints hashes every run of k tokens and keeps the smallest hash in each window
+// of consecutive runs, as described in "Winnowing: Local Algorithms for Document
+// Fingerprinting" (Schleimer et al., 2003). Any match of at least window+k-1 tokens
+// is guaranteed to share a fingerprint, while matches shorter than k tokens are
+// ignored as noise.
+func Fingerprints(tokens []Token, k, window int) []Fingerprint {
+	if k < 1 || window < 1 || len(tokens) < k {
+		return nil
+	}
+
+	hashes := make([]uint64, len(tokens)-k+1)
+	for i := range hashes {
+		h := fnv.New64a()
+		for _, token := range tokens[i : i+k] {
+			h.Write([]byte(token.Text))
+			h.Write([]byte{0})
+		}
+		hashes[i] = h.Sum64()
+	}
+
+	if window > len(hashes) {
+		window = len(hashes)
+	}
+
+	var fingerprints []Fingerprint
+	selected := -1
+	for start := 0; start+window <= len(hashes); start++ {
+		// The rightmost minimum is taken, and a tie with the previous selection keeps it,
+		// so that a window sliding past a run of equal hashes doesn't select a new
+		// fingerprint at every step.
+		minimum := start
+		for i := start; i < start+window; i++ {
+			if hashes[i] <= hashes[minimum] {
+				minimum = i
+			}
+		}
+		if selected >= start && hashes[selected] == hashes[minimum] {
+			minimum = selected
+		}
+
+		if minimum != selected {
+			selected = minimum
+			fingerprints = append(fingerprints, Fingerprint{
+				Hash:      hashes[minimum],
+				StartLine: tokens[minimum].Line,
+				EndLine:   tokens[minimum+k-1].Line,
+			})
+		}
+	}
+
+	return fingerprints
+}
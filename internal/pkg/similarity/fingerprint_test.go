@@ -0,0 +1,85 @@
+package similarity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineTokens returns a token for each text, each on its own line.
+func lineTokens(texts ...string) []Token {
+	tokens := make([]Token, len(texts))
+	for i, text := range texts {
+		tokens[i] = Token{Text: text, Line: i + 1}
+	}
+
+	return tokens
+}
+
+func TestFingerprints(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		tokens    []Token
+		k, window int
+		want      int
+	}{
+		{"No Tokens", nil, 3, 2, 0},
+		{"Fewer Tokens Than K", lineTokens("a", "b"), 3, 2, 0},
+		{"Invalid K", lineTokens("a", "b", "c"), 0, 2, 0},
+		{"Invalid Window", lineTokens("a", "b", "c"), 2, 0, 0},
+		{"Exactly K Tokens", lineTokens("a", "b", "c"), 3, 2, 1},
+		{"Window Larger Than Input", lineTokens("a", "b", "c", "d"), 2, 10, 1},
+		// Every run hashes the same, so sliding the window keeps its selection until
+		// that drops out.
+		{"Repeated Tokens", lineTokens("a", "a", "a", "a", "a", "a", "a", "a"), 2, 3, 2},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, Fingerprints(tt.tokens, tt.k, tt.window), tt.want)
+		})
+	}
+}
+
+func TestFingerprintsCoverEveryWindow(t *testing.T) {
+	var texts []string
+	for i := 0; i < 200; i++ {
+		texts = append(texts, fmt.Sprintf("t%d", i*7%13))
+	}
+	tokens := lineTokens(texts...)
+	k, window := 5, 4
+
+	fingerprints := Fingerprints(tokens, k, window)
+	require.NotEmpty(t, fingerprints)
+
+	selected := map[int]bool{}
+	for _, fingerprint := range fingerprints {
+		// Each fingerprint spans the k tokens it hashes.
+		assert.Equal(t, k-1, fingerprint.EndLine-fingerprint.StartLine)
+		selected[fingerprint.StartLine-1] = true
+	}
+
+	// Winnowing guarantees a fingerprint from every window of consecutive hashes.
+	for start := 0; start+window <= len(tokens)-k+1; start++ {
+		found := false
+		for i := start; i < start+window; i++ {
+			found = found || selected[i]
+		}
+		assert.True(t, found, "no fingerprint in window starting at %d", start)
+	}
+}
+
+func TestFingerprintsMatchShiftedCode(t *testing.T) {
+	shared := []string{"for", "(", "id", "=", "0", ";", "id", "<", "0", ";", "id", "++", ")"}
+	a := Fingerprints(lineTokens(shared...), 4, 3)
+	b := Fingerprints(lineTokens(append([]string{"void", "id", "(", ")", "{"}, shared...)...), 4, 3)
+
+	hashes := map[uint64]bool{}
+	for _, fingerprint := range b {
+		hashes[fingerprint.Hash] = true
+	}
+	for _, fingerprint := range a {
+		assert.True(t, hashes[fingerprint.Hash], "fingerprint from line %d isn't found after shifting", fingerprint.StartLine)
+	}
+}
@@ -0,0 +1,57 @@
+package similarity
+
+import (
+	"fmt"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/grading"
+)
+
+// LoadDocuments returns a document for each of the assignment's submissions, made up
+// of the files of its counted version. Submissions without a version are skipped.
+func LoadDocuments(database db.Database, assignment *models.Assignment) ([]Document, error) {
+	submissions, err := database.GetSubmissionsForAssignment(fmt.Sprintf("%d", assignment.ID))
+	if err != nil {
+		return nil, fmt.Errorf("error getting submissions: %w", err)
+	}
+
+	var documents []Document
+	for _, submission := range submissions {
+		version, _, err := grading.LoadCountedVersion(database, assignment, submission)
+		if err != nil {
+			return nil, err
+		}
+		if version == nil {
+			continue
+		}
+
+		files, err := database.GetSubmissionFiles(version.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting submission files: %w", err)
+		}
+
+		document := Document{ID: submission.ID, Name: submission.StudentID}
+		for _, file := range files {
+			document.Files = append(document.Files, File{Path: file.Path, Content: string(file.Content)})
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// LoadBaseline returns the assignment's starter code, to be ignored when comparing.
+func LoadBaseline(database db.Database, assignmentID uint) ([]File, error) {
+	starterFiles, err := database.GetStarterFiles(assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting starter files: %w", err)
+	}
+
+	var files []File
+	for _, file := range starterFiles {
+		files = append(files, File{Path: file.Path, Content: string(file.Content)})
+	}
+
+	return files, nil
+}
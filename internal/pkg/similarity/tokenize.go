@@ -0,0 +1,42 @@
+package similarity
+
+import (
+	"github.com/COMP4050/square-team-5/api/internal/pkg/lexer"
+)
+
+// Token is a normalised lexical token of Processing or Java source.
+type Token struct {
+	Text string
+	Line int
+}
+
+// Placeholders that identifiers and literals are normalised to, so that renaming a
+// variable or changing a colour doesn't hide copied code.
+const (
+	identifierToken = "id"
+	numberToken     = "0"
+	stringToken     = `""`
+)
+
+// Tokenize splits source into tokens, dropping comments and normalising identifiers
+// and literals. Keywords and punctuation are kept as is.
+func Tokenize(source string) []Token {
+	var tokens []Token
+	for _, token := range lexer.Lex(source) {
+		text := token.Text
+		switch token.Kind {
+		case lexer.Comment:
+			continue
+		case lexer.Identifier:
+			text = identifierToken
+		case lexer.Number:
+			text = numberToken
+		case lexer.String:
+			text = stringToken
+		}
+
+		tokens = append(tokens, Token{Text: text, Line: token.Line})
+	}
+
+	return tokens
+}
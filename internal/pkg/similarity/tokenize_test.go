@@ -0,0 +1,49 @@
+package similarity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		source string
+		want   []Token
+	}{
+		{"Empty", "", nil},
+		{
+			"Identifiers And Numbers",
+			"int x = 10;",
+			[]Token{{"int", 1}, {"id", 1}, {"=", 1}, {"0", 1}, {";", 1}},
+		},
+		{
+			"Strings",
+			`text("hi", 'c');`,
+			[]Token{{"id", 1}, {"(", 1}, {`""`, 1}, {",", 1}, {`""`, 1}, {")", 1}, {";", 1}},
+		},
+		{
+			"Comments Dropped",
+			"// setup\n/* a\nblock */ void setup() {}",
+			[]Token{{"void", 3}, {"id", 3}, {"(", 3}, {")", 3}, {"{", 3}, {"}", 3}},
+		},
+		{
+			"Lines",
+			"if (a >= b)\n  b++;",
+			[]Token{{"if", 1}, {"(", 1}, {"id", 1}, {">=", 1}, {"id", 1}, {")", 1}, {"id", 2}, {"++", 2}, {";", 2}},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Tokenize(tt.source))
+		})
+	}
+}
+
+func TestTokenizeIgnoresRenaming(t *testing.T) {
+	a := Tokenize("float speed = 2.5;\nfill(255, 0, 0);")
+	b := Tokenize("float velocity = 4; // faster\nfill(0, 128, 255);")
+
+	assert.Equal(t, a, b)
+}
@@ -0,0 +1,79 @@
+package starter
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/diff"
+)
+
+// Baseline is an assignment's starter code, keyed by path.
+type Baseline map[string]string
+
+// Load returns the assignment's starter code.
+func Load(database db.Database, assignmentID uint) (Baseline, error) {
+	files, err := database.GetStarterFiles(assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting starter files: %w", err)
+	}
+
+	return NewBaseline(files), nil
+}
+
+func NewBaseline(files []*models.StarterFile) Baseline {
+	baseline := Baseline{}
+	for _, file := range files {
+		baseline[file.Path] = string(file.Content)
+	}
+
+	return baseline
+}
+
+// Find returns the path of the starter file a submitted file is based on. Files are
+// matched by path, or failing that by name as long as only one starter file has that
+// name, so that starter code still matches when students rename the sketch folder.
+func (b Baseline) Find(filePath string) (string, bool) {
+	if _, ok := b[filePath]; ok {
+		return filePath, true
+	}
+
+	found := ""
+	for starterPath := range b {
+		if path.Base(starterPath) != path.Base(filePath) {
+			continue
+		}
+		if found != "" {
+			return "", false
+		}
+		found = starterPath
+	}
+
+	return found, found != ""
+}
+
+// Diff compares a submission's files, keyed by path, against the starter code. Each
+// submitted file is compared with the starter file it is based on, starter files that
+// weren't submitted are reported as removed and files the student created as added.
+func (b Baseline) Diff(files map[string]string) []diff.FileDiff {
+	old := map[string]string{}
+	used := map[string]bool{}
+	for filePath := range files {
+		starterPath, ok := b.Find(filePath)
+		if !ok {
+			continue
+		}
+		old[filePath] = b[starterPath]
+		used[starterPath] = true
+	}
+
+	for starterPath, content := range b {
+		_, submitted := files[starterPath]
+		if !used[starterPath] && !submitted {
+			old[starterPath] = content
+		}
+	}
+
+	return diff.Files(old, files)
+}
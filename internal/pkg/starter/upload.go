@@ -0,0 +1,39 @@
+package starter
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/submissions"
+)
+
+// Parse reads an uploaded starter file. Zip archives are extracted, keeping the paths
+// inside them, and anything else is taken as a single file named after the upload.
+func Parse(filename string, r io.Reader) ([]models.StarterFile, error) {
+	if strings.EqualFold(path.Ext(filename), ".zip") {
+		files, err := submissions.ParseFiles(r)
+		if err != nil {
+			return nil, err
+		}
+
+		var starterFiles []models.StarterFile
+		for _, file := range files {
+			starterFiles = append(starterFiles, models.StarterFile{Path: file.Path, Content: file.Content})
+		}
+
+		return starterFiles, nil
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r, submissions.MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", filename, err)
+	}
+	if len(content) > submissions.MaxFileSize {
+		return nil, fmt.Errorf("file %s is too large", filename)
+	}
+
+	return []models.StarterFile{{Path: path.Base(filename), Content: content}}, nil
+}
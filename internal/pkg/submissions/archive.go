@@ -0,0 +1,201 @@
+package submissions
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+const (
+	// MaxFileSize is the largest uncompressed file accepted from an archive.
+	MaxFileSize = 5 << 20
+	// MaxArchiveSize is the largest total uncompressed size accepted from an archive.
+	MaxArchiveSize = 200 << 20
+)
+
+// File is a single file extracted from a student's folder.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Folder is a student's folder from a bulk download, named following the
+// "<student number>_<first name>_<last name>" convention, e.g. "s0001_Alice_Penguin".
+type Folder struct {
+	Name          string
+	StudentNumber string
+	Files         []File
+}
+
+// ParseArchive reads a zip archive laid out as one folder per student. A single
+// enclosing folder around the student folders, as produced by zipping the download
+// directory, is ignored.
+func ParseArchive(r io.Reader) ([]Folder, error) {
+	files, err := openArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	root := commonRoot(files)
+
+	folders := map[string]*Folder{}
+	var total uint64
+	for _, file := range files {
+		name := strings.TrimPrefix(path.Clean(file.Name), root)
+
+		folderName, filePath, ok := strings.Cut(name, "/")
+		if !ok || folderName == "" {
+			return nil, fmt.Errorf("file %s is not inside a student folder", file.Name)
+		}
+
+		total += file.UncompressedSize64
+		if file.UncompressedSize64 > MaxFileSize || total > MaxArchiveSize {
+			return nil, fmt.Errorf("file %s is too large", file.Name)
+		}
+
+		content, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		folder, ok := folders[folderName]
+		if !ok {
+			folder = &Folder{Name: folderName, StudentNumber: studentNumber(folderName)}
+			folders[folderName] = folder
+		}
+		folder.Files = append(folder.Files, File{Path: filePath, Content: content})
+	}
+
+	var result []Folder
+	for _, folder := range folders {
+		sort.Slice(folder.Files, func(i, j int) bool { return folder.Files[i].Path < folder.Files[j].Path })
+		result = append(result, *folder)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
+
+// ParseFiles reads every file from a zip archive, keeping the paths they have in the
+// archive.
+func ParseFiles(r io.Reader) ([]File, error) {
+	entries, err := openArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	var total uint64
+	for _, entry := range entries {
+		total += entry.UncompressedSize64
+		if entry.UncompressedSize64 > MaxFileSize || total > MaxArchiveSize {
+			return nil, fmt.Errorf("file %s is too large", entry.Name)
+		}
+
+		content, err := readFile(entry)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Clean(entry.Name), Content: content})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return files, nil
+}
+
+// openArchive reads a zip archive, returning its files other than directories and
+// operating system metadata.
+func openArchive(r io.Reader) ([]*zip.File, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading archive: %w", err)
+	}
+	if len(data) > MaxArchiveSize {
+		return nil, fmt.Errorf("archive is larger than %d bytes", MaxArchiveSize)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error opening archive: %w", err)
+	}
+
+	var files []*zip.File
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || ignored(file.Name) {
+			continue
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// studentNumber returns the student number a folder is named after, or "" if the
+// folder doesn't follow the naming convention.
+func studentNumber(folderName string) string {
+	if !strings.Contains(folderName, "_") {
+		return ""
+	}
+
+	return models.ParseStudentNumber(folderName)
+}
+
+// commonRoot returns the enclosing folder shared by every file, including the
+// trailing slash, if that folder isn't itself a student folder.
+func commonRoot(files []*zip.File) string {
+	root := ""
+	for i, file := range files {
+		first, _, ok := strings.Cut(path.Clean(file.Name), "/")
+		if !ok {
+			return ""
+		}
+		if i == 0 {
+			root = first
+		} else if first != root {
+			return ""
+		}
+	}
+
+	if root == "" || studentNumber(root) != "" {
+		return ""
+	}
+
+	return root + "/"
+}
+
+// ignored reports whether an archive entry is operating system metadata rather than
+// part of a submission.
+func ignored(name string) bool {
+	for _, part := range strings.Split(path.Clean(name), "/") {
+		if part == "__MACOSX" || strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", file.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", file.Name, err)
+	}
+	if len(content) > MaxFileSize {
+		return nil, fmt.Errorf("file %s is too large", file.Name)
+	}
+
+	return content, nil
+}
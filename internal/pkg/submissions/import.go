@@ -0,0 +1,126 @@
+package submissions
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+type Status string
+
+const (
+	StatusCreated     Status = "CREATED"
+	StatusResubmitted Status = "RESUBMITTED"
+	StatusDuplicate   Status = "DUPLICATE"
+	StatusUnmatched   Status = "UNMATCHED"
+	StatusErrored     Status = "ERRORED"
+)
+
+// FolderReport describes what happened to a single student folder of an import.
+// Unmatched folders are still imported as new submissions, but aren't linked to a
+// student.
+type FolderReport struct {
+	Folder        string
+	StudentNumber string
+	Status        Status
+	Files         int
+	Submission    *models.Submission
+	// Version is the version created from the folder's files.
+	Version *models.SubmissionVersion
+	Message string
+}
+
+type Report struct {
+	Folders []FolderReport
+}
+
+// Count returns the number of folders in the report with the given status.
+func (r *Report) Count(status Status) int {
+	count := 0
+	for _, folder := range r.Folders {
+		if folder.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Import creates a submission version for the assignment from each student folder in
+// the zip archive. Folders are matched to students enrolled in the assignment's class
+// by student number. Students who have already submitted get a new version of their
+// existing submission.
+func Import(database db.Database, assignment *models.Assignment, r io.Reader) (*Report, error) {
+	folders, err := ParseArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	students, err := database.GetStudentsForClass(assignment.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting students: %w", err)
+	}
+
+	enrolled := map[string]*models.Student{}
+	for _, student := range students {
+		enrolled[student.StudentNumber] = student
+	}
+
+	// seen maps student numbers to the folder they were imported from, as the same
+	// student can't submit twice in one archive.
+	seen := map[string]string{}
+
+	report := &Report{}
+	for _, folder := range folders {
+		folderReport := FolderReport{Folder: folder.Name, StudentNumber: folder.StudentNumber, Files: len(folder.Files)}
+
+		if folder.StudentNumber == "" {
+			folderReport.Status = StatusErrored
+			folderReport.Message = "folder name does not start with a student number"
+		} else if previous, ok := seen[folder.StudentNumber]; ok {
+			folderReport.Status = StatusDuplicate
+			folderReport.Message = fmt.Sprintf("duplicate of folder %s", previous)
+		} else {
+			seen[folder.StudentNumber] = folder.Name
+			importFolder(database, assignment, folder, enrolled[folder.StudentNumber], &folderReport)
+		}
+
+		report.Folders = append(report.Folders, folderReport)
+	}
+
+	return report, nil
+}
+
+func importFolder(database db.Database, assignment *models.Assignment, folder Folder, student *models.Student, report *FolderReport) {
+	files := make([]models.SubmissionFile, 0, len(folder.Files))
+	for _, file := range folder.Files {
+		files = append(files, models.SubmissionFile{Path: file.Path, Content: file.Content})
+	}
+
+	var studentRecordID *uint
+	if student != nil {
+		studentRecordID = &student.ID
+	}
+
+	submission, version, created, err := database.SubmitVersion(assignment.ID, folder.Name, studentRecordID, files)
+	if err != nil {
+		report.Status = StatusErrored
+		report.Message = fmt.Sprintf("error storing files: %v", err)
+		return
+	}
+
+	status := StatusResubmitted
+	if created {
+		status = StatusCreated
+	}
+
+	report.Submission = submission
+	report.Version = version
+	report.Status = status
+	if status == StatusCreated && student == nil {
+		report.Status = StatusUnmatched
+		report.Message = fmt.Sprintf("no student enrolled in the class with number %s", folder.StudentNumber)
+	}
+}
@@ -4,19 +4,39 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
 	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
 )
 
-var userCtxKey = &contextKey{"user"}
+var (
+	userCtxKey = &contextKey{"user"}
+	ipCtxKey   = &contextKey{"ip"}
+)
 
 type contextKey struct {
 	name string
 }
 
-func getUserFromJWT(tokenString, secret string) (string, error) {
+// TokenLifetime is how long a token can be used after it's issued.
+const TokenLifetime = 24 * time.Hour
+
+// NewToken signs a JWT identifying the user. The user's role isn't part of the token,
+// it's looked up on every request so that role changes take effect straight away.
+func NewToken(user *models.User, secret string) (string, error) {
+	claims := jwt.MapClaims{
+		"sub": user.Email,
+		"exp": time.Now().Add(TokenLifetime).Unix(),
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+func getUserFromJWT(tokenString, secret string, database db.Database) (*models.User, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// Don't forget to validate the alg is what you expect:
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -28,35 +48,53 @@ func getUserFromJWT(tokenString, secret string) (string, error) {
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if token == nil {
-		return "", errors.New("token is nil")
+		return nil, errors.New("token is nil")
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		return claims["sub"].(string), nil
-	} else {
-		return "", errors.New("token invalid")
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("token invalid")
+	}
+
+	// Tokens without an expiry were issued with the user's role in them, and are
+	// rejected along with expired tokens.
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("token has expired")
+	}
+
+	email, ok := claims["sub"].(string)
+	if !ok {
+		return nil, errors.New("token has no subject")
+	}
+
+	user, err := database.GetUserByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
 	}
+	if user.Role == models.UserRoleStudent && user.StudentID == nil {
+		return nil, errors.New("student account has no student")
+	}
+
+	return user, nil
 }
 
-func AuthHandler(jwtSecret string) gin.HandlerFunc {
+func AuthHandler(jwtSecret string, database db.Database) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		jwt := c.GetHeader("Authorization")
 		if jwt == "" {
 			return
 		}
 
-		username, err := getUserFromJWT(jwt, jwtSecret)
+		user, err := getUserFromJWT(jwt, jwtSecret, database)
 		if err != nil {
-			c.AbortWithError(500, err)
+			c.AbortWithError(http.StatusUnauthorized, err)
 			return
 		}
 
-		user := models.User{Email: username, Role: models.UserRoleAdmin}
-
-		ctx := context.WithValue(c, userCtxKey, user)
+		ctx := context.WithValue(c, userCtxKey, *user)
 
 		c.Request = c.Request.WithContext(ctx)
 	}
@@ -74,3 +112,22 @@ func ExtractUser(ctx context.Context) *models.User {
 		return &user
 	}
 }
+
+// ClientIPHandler stores the client's IP address in the request context. It must be
+// registered after AuthHandler, which replaces the request context.
+func ClientIPHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := context.WithValue(c.Request.Context(), ipCtxKey, c.ClientIP())
+
+		c.Request = c.Request.WithContext(ctx)
+	}
+}
+
+func ExtractIP(ctx context.Context) string {
+	ip, ok := ctx.Value(ipCtxKey).(string)
+	if !ok {
+		return ""
+	}
+
+	return ip
+}
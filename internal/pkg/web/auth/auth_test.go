@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+)
+
+func TestGetUserFromJWT(t *testing.T) {
+	database := db.NewDB(filepath.Join(t.TempDir(), "test.sqlite3"))
+	user, err := database.CreateUser("tutor@example.com", "hash", models.UserRoleTutor)
+	require.NoError(t, err)
+
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("Role From Database", func(t *testing.T) {
+		// A role claim from an old token is ignored.
+		token := sign(jwt.MapClaims{"sub": user.Email, "role": int64(models.UserRoleAdmin), "exp": time.Now().Add(time.Hour).Unix()})
+
+		got, err := getUserFromJWT(token, "secret", database)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleTutor, got.Role)
+	})
+
+	t.Run("New Token", func(t *testing.T) {
+		token, err := NewToken(user, "secret")
+		require.NoError(t, err)
+
+		got, err := getUserFromJWT(token, "secret", database)
+
+		require.NoError(t, err)
+		assert.Equal(t, user.Email, got.Email)
+	})
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{"No Expiry", jwt.MapClaims{"sub": user.Email, "role": int64(models.UserRoleAdmin)}},
+		{"Expired", jwt.MapClaims{"sub": user.Email, "exp": time.Now().Add(-time.Minute).Unix()}},
+		{"Unknown User", jwt.MapClaims{"sub": "nobody@example.com", "exp": time.Now().Add(time.Hour).Unix()}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := getUserFromJWT(sign(tt.claims), "secret", database)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClientIPHandler(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		proxies []string
+		want    string
+	}{
+		{"No Trusted Proxies", nil, "10.0.0.1"},
+		{"Trusted Proxy", []string{"10.0.0.0/8"}, "203.0.113.7"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			require.NoError(t, r.SetTrustedProxies(tt.proxies))
+			r.Use(ClientIPHandler())
+
+			var ip string
+			r.GET("/", func(c *gin.Context) { ip = ExtractIP(c.Request.Context()) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.want, ip)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// BackoffPolicy describes how long a client must wait between login attempts
+// after a run of consecutive failures.
+type BackoffPolicy struct {
+	// FreeAttempts is the number of failures allowed before any delay is imposed.
+	FreeAttempts int
+	// MaxAttempts is the number of failures after which the client is locked out.
+	MaxAttempts int
+	// MaxBackoff caps the exponential delay applied between FreeAttempts and MaxAttempts.
+	MaxBackoff time.Duration
+	// Lockout is how long the client is locked out for once MaxAttempts is reached.
+	Lockout time.Duration
+}
+
+var (
+	// AccountPolicy applies to failed logins against a single account.
+	AccountPolicy = BackoffPolicy{FreeAttempts: 3, MaxAttempts: 10, MaxBackoff: 5 * time.Minute, Lockout: 30 * time.Minute}
+	// IPPolicy applies to failed logins from a single IP address. It is more lenient than
+	// AccountPolicy as many users may share an address on a campus network.
+	IPPolicy = BackoffPolicy{FreeAttempts: 20, MaxAttempts: 100, MaxBackoff: 5 * time.Minute, Lockout: 30 * time.Minute}
+)
+
+// Backoff returns how long to wait before the next attempt after the given number of
+// consecutive failures. The delay doubles with every failure past FreeAttempts.
+func (p BackoffPolicy) Backoff(failures int) time.Duration {
+	if failures >= p.MaxAttempts {
+		return p.Lockout
+	}
+	if failures < p.FreeAttempts {
+		return 0
+	}
+
+	shift := failures - p.FreeAttempts
+	if shift > 30 {
+		return p.MaxBackoff
+	}
+
+	backoff := time.Second << shift
+	if backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return backoff
+}
+
+// pruneThreshold is the number of tracked keys above which stale entries are dropped.
+const pruneThreshold = 1024
+
+type loginAttempts struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// LoginThrottle tracks failed login attempts in memory, keyed by an arbitrary string
+// such as an IP address or an email that does not belong to any account.
+type LoginThrottle struct {
+	mu       sync.Mutex
+	policy   BackoffPolicy
+	attempts map[string]*loginAttempts
+	now      func() time.Time
+}
+
+func NewLoginThrottle(policy BackoffPolicy) *LoginThrottle {
+	return &LoginThrottle{
+		policy:   policy,
+		attempts: map[string]*loginAttempts{},
+		now:      time.Now,
+	}
+}
+
+// RetryAfter returns how long the key must wait before it may attempt to log in again.
+func (t *LoginThrottle) RetryAfter(key string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	attempts, ok := t.attempts[key]
+	if !ok {
+		return 0
+	}
+
+	wait := attempts.blockedUntil.Sub(t.now())
+	if wait < 0 {
+		return 0
+	}
+
+	return wait
+}
+
+// RecordFailure registers a failed login attempt for the key.
+func (t *LoginThrottle) RecordFailure(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.prune(now)
+
+	attempts, ok := t.attempts[key]
+	if !ok {
+		attempts = &loginAttempts{}
+		t.attempts[key] = attempts
+	}
+
+	attempts.failures++
+	attempts.lastFailure = now
+	attempts.blockedUntil = now.Add(t.policy.Backoff(attempts.failures))
+}
+
+// Reset forgets all failed attempts for the key.
+func (t *LoginThrottle) Reset(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.attempts, key)
+}
+
+// prune drops keys that have not failed for longer than the lockout period so the
+// map does not grow without bound. The caller must hold t.mu.
+func (t *LoginThrottle) prune(now time.Time) {
+	if len(t.attempts) < pruneThreshold {
+		return
+	}
+
+	for key, attempts := range t.attempts {
+		if now.Sub(attempts.lastFailure) > t.policy.Lockout && now.After(attempts.blockedUntil) {
+			delete(t.attempts, key)
+		}
+	}
+}
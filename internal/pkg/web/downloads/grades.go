@@ -0,0 +1,72 @@
+package downloads
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/db/models"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/export"
+	"github.com/COMP4050/square-team-5/api/internal/pkg/web/auth"
+)
+
+// AssignmentGradesHandler downloads the grades for the assignment in the :id path
+// parameter, in the format given by the format query parameter (CSV by default).
+func AssignmentGradesHandler(database db.Database) gin.HandlerFunc {
+	return gradesHandler(func(id string, user *models.User) (*export.Gradebook, error) {
+		return export.AssignmentGradebook(database, id, user)
+	})
+}
+
+// ClassGradesHandler downloads the grades for every assignment in the class in the
+// :id path parameter, in the format given by the format query parameter (CSV by default).
+func ClassGradesHandler(database db.Database) gin.HandlerFunc {
+	return gradesHandler(func(id string, user *models.User) (*export.Gradebook, error) {
+		return export.ClassGradebook(database, id, user)
+	})
+}
+
+func gradesHandler(load func(id string, user *models.User) (*export.Gradebook, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := auth.ExtractUser(c.Request.Context())
+		if user == nil {
+			c.String(http.StatusUnauthorized, "user not authenticated")
+			return
+		}
+		if !user.IsStaff() {
+			c.String(http.StatusForbidden, "only staff can do this")
+			return
+		}
+
+		format, err := export.ParseFormat(c.DefaultQuery("format", string(export.FormatCSV)))
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		gradebook, err := load(c.Param("id"), user)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		// Write to a buffer first so a failure can still be reported with an error status.
+		var buf bytes.Buffer
+		err = export.Write(&buf, gradebook, format)
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename(gradebook, format)))
+		c.Data(http.StatusOK, export.ContentType, buf.Bytes())
+	}
+}
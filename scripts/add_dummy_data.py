@@ -16,34 +16,19 @@ transport = AIOHTTPTransport(url="http://localhost:8081/query")
 # Create a GraphQL client using the defined transport
 client = Client(transport=transport, fetch_schema_from_transport=True)
 
-try:
-    # Register an account
-    register = gql("""
-        mutation register($email: String!, $password: String!) {
-            register(email: $email, password: $password)
-        }
-        """)
-
-    result = client.execute(register,
-                            variable_values={
-                                "email": "admin@admin.com",
-                                "password": "password"
-                            })
-
-    token = result['register']
-except:
-    # Login to get a token
-    login = gql("""
-        mutation login($email: String!, $password: String!) {
-            login(email: $email, password: $password)
-        }
-        """)
-    result = client.execute(login,
-                            variable_values={
-                                "email": "admin@admin.com",
-                                "password": "password"
-                            })
-    token = result['login']
+# Login to get a token. The admin account is created beforehand with
+# `go run ./cmd create-admin -email admin@admin.com`
+login = gql("""
+    mutation login($email: String!, $password: String!) {
+        login(email: $email, password: $password)
+    }
+    """)
+result = client.execute(login,
+                        variable_values={
+                            "email": "admin@admin.com",
+                            "password": "password"
+                        })
+token = result['login']
 
 # Select your transport with a defined url endpoint
 transport = AIOHTTPTransport(url="http://localhost:8081/query",
@@ -162,7 +147,7 @@ test_id = test['createTest']['id']
 
 # Upload the test file
 upload_file('./data/Test.java', S3_BUCKET,
-            f'{UNIT_NAME}/{ASSIGNMENT_NAME}/Tests/{test_id}/Test.java')
+            f'Assignments/{assignment_id}/Tests/{test_id}/Test.java')
 
 # Upload all project directories
 for dir in os.listdir('./data/submissions'):
@@ -176,7 +161,7 @@ for dir in os.listdir('./data/submissions'):
     submission_id = submission['createSubmission']['id']
 
     upload_directory(f'{os.path.join("./data/submissions", dir)}', S3_BUCKET,
-                    f'{UNIT_NAME}/{ASSIGNMENT_NAME}/Projects')
+                    f'Assignments/{assignment_id}/Projects')
 
 # Execute the query on the transport
 # result = client.execute(query)